@@ -2,17 +2,20 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/richard-senior/1pcc/internal/config"
 	"github.com/richard-senior/1pcc/internal/game"
 	"github.com/richard-senior/1pcc/internal/handlers"
+	"github.com/richard-senior/1pcc/internal/importer"
 	"github.com/richard-senior/1pcc/internal/logger"
 	"github.com/richard-senior/1pcc/internal/session"
 )
@@ -78,6 +81,160 @@ func baseHandler(next http.Handler) http.Handler {
 	})
 }
 
+// validate checks a questions file, by default questions.json, printing every
+// problem found. It returns the exit status, non-zero if there were errors
+// rather than just warnings
+func validate(args []string) int {
+	path := "questions.json"
+	if len(args) > 0 {
+		path = args[0]
+	}
+	problems, count, err := game.ValidateQuestions(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read %s: %v\n", path, err)
+		return 1
+	}
+	// warnings are printed but only errors stop the questions being played
+	errs, warnings := 0, 0
+	for _, p := range problems {
+		fmt.Println(p)
+		if p.Warning {
+			warnings++
+		} else {
+			errs++
+		}
+	}
+	if len(problems) == 0 {
+		fmt.Printf("%d questions in %s, no problems\n", count, path)
+		return 0
+	}
+	fmt.Printf("%d questions in %s, %d errors and %d warnings\n", count, path, errs, warnings)
+	if errs > 0 {
+		return 1
+	}
+	return 0
+}
+
+// migrate upgrades questions files written for older versions of 1pcc, by
+// default questions.json and the questions of every pack, keeping each old
+// file with .bak added to its name. It returns the exit status
+func migrate(args []string) int {
+	paths := args
+	if len(paths) == 0 {
+		packs, _ := filepath.Glob(filepath.Join("packs", "*", "questions.json"))
+		paths = append([]string{"questions.json"}, packs...)
+	}
+	status := 0
+	for _, path := range paths {
+		version, notes, err := game.MigrateQuestionsFile(path)
+		for _, note := range notes {
+			fmt.Printf("%s: %s\n", path, note)
+		}
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Failed to migrate %s: %v\n", path, err)
+			status = 1
+		case version == game.SchemaVersion:
+			fmt.Printf("%s is already schema version %d\n", path, version)
+		default:
+			fmt.Printf("%s upgraded from schema version %d to %d, the old file is %s.bak\n", path, version, game.SchemaVersion, path)
+		}
+	}
+	return status
+}
+
+// exportCSV writes a questions file, by default questions.json, as CSV to
+// stdout for editing in a spreadsheet. It returns the exit status
+func exportCSV(args []string) int {
+	path := "questions.json"
+	if len(args) > 0 {
+		path = args[0]
+	}
+	questions, err := game.ReadQuestionsFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read %s, run '1pcc validate %s' for details: %v\n", path, path, err)
+		return 1
+	}
+	if err := game.ExportCSV(os.Stdout, questions); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write CSV: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// importCSV makes a question pack from a CSV file, eg. 1pcc import-csv
+// music.csv music "Music Night", printing any problems with its rows.
+// It returns the exit status, non-zero if the pack wasn't made
+func importCSV(args []string) int {
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: 1pcc import-csv <csv file> <pack name> [title]")
+		return 1
+	}
+	title := ""
+	if len(args) > 2 {
+		title = args[2]
+	}
+	f, err := os.Open(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read %s: %v\n", args[0], err)
+		return 1
+	}
+	defer f.Close()
+	problems, err := game.ImportCSVPack(f, args[0], args[1], title)
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("pack %s made from %s\n", args[1], args[0])
+	return 0
+}
+
+// importBank makes a question pack from a quiz bank written for other
+// software, eg. 1pcc import gift geography.gift geography, printing the items
+// which were left out and any problems. It returns the exit status, non-zero
+// if the pack wasn't made
+func importBank(args []string) int {
+	if len(args) < 3 {
+		fmt.Fprintf(os.Stderr, "usage: 1pcc import <%s> <file> <pack name> [title]\n", strings.Join(importer.Formats, "|"))
+		return 1
+	}
+	title := ""
+	if len(args) > 3 {
+		title = args[3]
+	}
+	f, err := os.Open(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read %s: %v\n", args[1], err)
+		return 1
+	}
+	defer f.Close()
+	questions, lines, skipped, err := importer.Import(args[0], f, args[1])
+	for _, p := range skipped {
+		fmt.Println(p)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if len(questions) == 0 {
+		fmt.Fprintf(os.Stderr, "there are no questions in %s which can be imported\n", args[1])
+		return 1
+	}
+	problems, err := game.CreateImportedPack(game.Pack{Name: args[2], Title: title}, questions, args[1], lines)
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("pack %s made from %d questions in %s, %d left out\n", args[2], len(questions), args[1], len(skipped))
+	return 0
+}
+
 // Add this helper function
 func getHostIP() string {
 	addrs, err := net.InterfaceAddrs()
@@ -98,6 +255,22 @@ func main() {
 	// Create API handler
 	//apiHandler := handlers.NewAPIHandler(game.GetGame())
 
+	// commands which work on questions files then exit, eg. 1pcc validate questions.json
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "validate":
+			os.Exit(validate(os.Args[2:]))
+		case "migrate":
+			os.Exit(migrate(os.Args[2:]))
+		case "export-csv":
+			os.Exit(exportCSV(os.Args[2:]))
+		case "import-csv":
+			os.Exit(importCSV(os.Args[2:]))
+		case "import":
+			os.Exit(importBank(os.Args[2:]))
+		}
+	}
+
 	// Load configuration
 	config.Load()
 	// Get server port from config
@@ -128,6 +301,8 @@ func main() {
 	mux.HandleFunc("/observe", handlers.ObserveHandler)
 	mux.HandleFunc("/scoreboard", handlers.ScoreboardHandler)
 	mux.HandleFunc("/qr", handlers.QRCodeHandler)
+	mux.HandleFunc("/media/", handlers.MediaHandler)
+	mux.HandleFunc("/packs/", handlers.PackHandler)
 	mux.HandleFunc("/api/", handlers.HandleAPI) // Note the trailing slash
 
 	// add shutdown handler
@@ -137,6 +312,8 @@ func main() {
 
 	// load the game state and instantiate the singleton
 	game.GetGame()
+	// pick up changes to the questions file without a restart
+	go game.GetGame().WatchQuestions(2 * time.Second)
 
 	// Listen and serve
 	logger.Info("1pcc Server is ready to handle requests at", getHostIP(), serverPort)
@@ -150,6 +327,7 @@ func isPublicPath(path string) bool {
 	publicPaths := []string{
 		"/qr",
 		"/static",
+		"/packs",
 	}
 
 	for _, publicPath := range publicPaths {
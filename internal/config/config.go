@@ -18,11 +18,25 @@ type KioskMode struct {
 	BetweenQuestionDelay int  `json:"betweenQuestionDelay"`
 }
 
+// MapProjection declares how the pixels of a map image relate to latitude
+// and longitude so that clicks on the map can be scored as real locations.
+// The edges of the image are given as the longitude or latitude they lie on
+type MapProjection struct {
+	Projection string  `json:"projection"` // "equirectangular" or "mercator"
+	Width      float64 `json:"width"`      // the width of the image in its own (svg) coordinates
+	Height     float64 `json:"height"`     // the height of the image in its own (svg) coordinates
+	West       float64 `json:"west"`       // the longitude of the left hand edge
+	East       float64 `json:"east"`       // the longitude of the right hand edge, may be more than 180 if the map wraps
+	North      float64 `json:"north"`      // the latitude of the top edge
+	South      float64 `json:"south"`      // the latitude of the bottom edge
+}
+
 type Config struct {
-	ServerPort  int        `json:"SERVER_PORT" env:"1pcc_port" flag:"1pcc-port"`
-	MapScale    float32    `json:"MAP_SCALE" env:"" flag:"map-scale"`
-	TestingMode bool       `json:"TESTING_MODE" env:"" flag:"testing-mode"`
-	KioskMode   KioskMode  `json:"KIOSK_MODE"`
+	ServerPort  int                      `json:"SERVER_PORT" env:"1pcc_port" flag:"1pcc-port"`
+	MapScale    float32                  `json:"MAP_SCALE" env:"" flag:"map-scale"`
+	TestingMode bool                     `json:"TESTING_MODE" env:"" flag:"testing-mode"`
+	KioskMode   KioskMode                `json:"KIOSK_MODE"`
+	Maps        map[string]MapProjection `json:"MAPS"` // map projections keyed by the image url used in questions
 }
 
 var configuration *Config
@@ -131,3 +145,10 @@ func GetMapScale() float32 {
 func GetKioskMode() KioskMode {
 	return Get().KioskMode
 }
+
+// GetMapProjection returns the projection declared for the map image
+// with the given url and false if there isn't one
+func GetMapProjection(url string) (MapProjection, bool) {
+	mp, exists := Get().Maps[url]
+	return mp, exists
+}
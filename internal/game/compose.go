@@ -0,0 +1,226 @@
+// internal/game/compose.go
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+/*
+Composition describes a game built from a pool of questions rather than
+played top to bottom, eg. 20 questions with percents falling from 90 to 1,
+no more than 3 from any category and at least one geolocation. The same
+composition and seed always build the same game from the same pool
+*/
+type Composition struct {
+	Count       int            `json:"count"`                 // how many questions to play
+	MaxPercent  int            `json:"maxPercent"`            // the percent of the first (easiest) question
+	MinPercent  int            `json:"minPercent"`            // the percent of the last (hardest) question
+	PerCategory int            `json:"perCategory,omitempty"` // the most questions from any one category, zero for no limit
+	Require     []string       `json:"require,omitempty"`     // question types which must be played, repeated to require more than one
+	Packs       []string       `json:"packs,omitempty"`       // the packs making up the pool, none for questions.json
+	Seed        int64          `json:"seed"`                  // the seed the questions were picked with
+	Questions   []string       `json:"questions,omitempty"`   // the ids of the questions picked, in the order they're played
+	Positions   []int          `json:"positions,omitempty"`   // where each of the questions picked was in the pool
+	PoolSize    int            `json:"poolSize,omitempty"`    // how many questions there were in the pool
+	seen        map[string]int // how many of the players had seen each question when the game was composed
+}
+
+/**
+* Picks questions from the pool to fit the composition. The percents from
+* MaxPercent down to MinPercent are split evenly between the questions and
+* each is filled by the question nearest to its percent, after the required
+* types have been placed. Questions the players have seen before are avoided,
+* each player who has seen one counting as much as being a whole band away
+* from the percent wanted
+* @param pool the questions to pick from
+* @param c the composition
+* @return the questions in order of falling percent, or an error if the pool can't fill the composition
+ */
+func Compose(pool []Question, c Composition) ([]Question, error) {
+	if c.Count < 1 {
+		return nil, fmt.Errorf("can't compose a game of %d questions", c.Count)
+	}
+	if c.MinPercent > c.MaxPercent {
+		c.MinPercent, c.MaxPercent = c.MaxPercent, c.MinPercent
+	}
+	rng := rand.New(rand.NewSource(c.Seed))
+	var candidates []Question
+	for _, q := range pool {
+		if q.Percent >= c.MinPercent && q.Percent <= c.MaxPercent {
+			candidates = append(candidates, q)
+		}
+	}
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	// the percent each question should have, easiest first
+	targets := make([]int, c.Count)
+	for i := range targets {
+		targets[i] = c.MaxPercent
+		if c.Count > 1 {
+			targets[i] -= (c.MaxPercent - c.MinPercent) * i / (c.Count - 1)
+		}
+	}
+	slots := make([]int, c.Count) // the candidate in each slot plus one, zero while empty
+	used := make([]bool, len(candidates))
+	perCategory := make(map[string]int)
+	usable := func(i int) bool {
+		return !used[i] && (c.PerCategory <= 0 || perCategory[strings.ToLower(candidates[i].Category)] < c.PerCategory)
+	}
+	// puts a candidate in a slot
+	place := func(i int, slot int) {
+		used[i] = true
+		perCategory[strings.ToLower(candidates[i].Category)]++
+		slots[slot] = i + 1
+	}
+	band := max(1, (c.MaxPercent-c.MinPercent)/c.Count)
+	// how far a candidate is from the given percent, allowing for the players who've seen it
+	distance := func(i int, percent int) int {
+		return abs(candidates[i].Percent-percent) + band*c.seen[questionId(&candidates[i])]
+	}
+	// the usable candidate nearest the given percent
+	nearest := func(percent int) int {
+		best := -1
+		for i := range candidates {
+			if usable(i) && (best < 0 || distance(i, percent) < distance(best, percent)) {
+				best = i
+			}
+		}
+		return best
+	}
+
+	for _, t := range c.Require {
+		// the least seen question of the type
+		i := -1
+		for j := range candidates {
+			if usable(j) && strings.EqualFold(candidates[j].Type, t) && (i < 0 || c.seen[questionId(&candidates[j])] < c.seen[questionId(&candidates[i])]) {
+				i = j
+			}
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("there aren't enough %s questions between %d%% and %d%%", t, c.MinPercent, c.MaxPercent)
+		}
+		slot := -1
+		for s := range slots {
+			if slots[s] == 0 && (slot < 0 || abs(targets[s]-candidates[i].Percent) < abs(targets[slot]-candidates[i].Percent)) {
+				slot = s
+			}
+		}
+		if slot < 0 {
+			return nil, errors.New("more types are required than there are questions")
+		}
+		place(i, slot)
+	}
+	// fill the remaining slots in a random order so no part of the game gets the pick of the pool
+	for _, s := range rng.Perm(c.Count) {
+		if slots[s] != 0 {
+			continue
+		}
+		i := nearest(targets[s])
+		if i < 0 {
+			return nil, fmt.Errorf("there aren't enough questions between %d%% and %d%% to fill %d, with no more than %d per category",
+				c.MinPercent, c.MaxPercent, c.Count, c.PerCategory)
+		}
+		place(i, s)
+	}
+
+	questions := make([]Question, c.Count)
+	for s, i := range slots {
+		questions[s] = candidates[i-1]
+	}
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].Percent > questions[j].Percent
+	})
+	return questions, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+/**
+* Builds the game from the composition, replacing all of the questions. The
+* composition, including the ids and positions of the questions picked, is
+* kept so that reloading the questions reads the same questions again from the
+* changed pool. This can only be done before any question has been played
+* @param c the composition, whose Packs are the pool
+* @return the problems with the pool and an error if the game wasn't built
+ */
+func (gs *GameState) ComposeGame(c Composition) ([]Problem, error) {
+	pool, problems, err := loadQuestions(c.Packs)
+	if err != nil {
+		return problems, err
+	}
+	mu.RLock()
+	c.seen = seenCounts(pool, gs.Players)
+	mu.RUnlock()
+	questions, err := Compose(pool, c)
+	if err != nil {
+		return nil, err
+	}
+	positions := make(map[string]int)
+	for i := len(pool) - 1; i >= 0; i-- {
+		positions[questionId(&pool[i])] = i
+	}
+	c.Questions = make([]string, len(questions))
+	c.Positions = make([]int, len(questions))
+	c.PoolSize = len(pool)
+	for i := range questions {
+		c.Questions[i] = questionId(&questions[i])
+		c.Positions[i] = positions[c.Questions[i]]
+	}
+	if err := gs.startWith(questions, c.Packs, &c); err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("%d questions composed from a pool of %d with seed %d", len(questions), len(pool), c.Seed))
+	return nil, nil
+}
+
+/*
+composed finds the questions of a composed game in the pool again, so that
+changes to them are picked up without anything else changing, returning them
+and where they are in the pool. Questions are
+found by their ids, and a question without an id of its own gets a new one
+when its text is changed, so a question whose id has gone is taken to be the
+one in the same position as long as the pool is the same size and that
+question isn't one of the others. Anything else means questions have been
+added to or deleted from the pool and the game must be composed again
+*/
+func composed(pool []Question, c Composition) ([]Question, []int, error) {
+	byId := make(map[string]int)
+	for i := len(pool) - 1; i >= 0; i-- {
+		byId[questionId(&pool[i])] = i
+	}
+	found := make([]int, len(c.Questions))
+	taken := make(map[int]bool)
+	for i, id := range c.Questions {
+		found[i] = -1
+		if at, exists := byId[id]; exists {
+			found[i] = at
+			taken[at] = true
+		}
+	}
+	questions := make([]Question, len(c.Questions))
+	for i, at := range found {
+		if at < 0 && len(pool) == c.PoolSize && i < len(c.Positions) && !taken[c.Positions[i]] {
+			at = c.Positions[i]
+			taken[at] = true
+		}
+		if at < 0 || at >= len(pool) {
+			return nil, nil, fmt.Errorf("question %d of the composed game, %s, is no longer in the pool, compose the game again to replace it", i+1, c.Questions[i])
+		}
+		questions[i] = pool[at]
+		found[i] = at
+	}
+	return questions, found, nil
+}
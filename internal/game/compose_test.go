@@ -0,0 +1,190 @@
+// internal/game/compose_test.go
+package game
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// composePool is 30 questions with percents 3 to 90 in three categories,
+// every fifth a geolocation
+func composePool() []Question {
+	var pool []Question
+	for i := 1; i <= 30; i++ {
+		q := Question{Id: fmt.Sprintf("q%d", i), Type: "freetext", Percent: 3 * i, Category: []string{"history", "Science", "sport"}[i%3]}
+		if i%5 == 0 {
+			q.Type = "geolocation"
+		}
+		pool = append(pool, q)
+	}
+	return pool
+}
+
+// ids returns the ids of the questions
+func ids(questions []Question) []string {
+	var ret []string
+	for i := range questions {
+		ret = append(ret, questions[i].Id)
+	}
+	return ret
+}
+
+func TestCompose(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       Composition
+		wantErr bool
+	}{
+		{"the whole band", Composition{Count: 10, MaxPercent: 90, MinPercent: 3, Seed: 1}, false},
+		{"the wrong way round", Composition{Count: 5, MaxPercent: 30, MinPercent: 60, Seed: 1}, false},
+		{"one question", Composition{Count: 1, MaxPercent: 51, MinPercent: 51, Seed: 1}, false},
+		{"two per category", Composition{Count: 6, MaxPercent: 90, MinPercent: 3, PerCategory: 2, Seed: 1}, false},
+		{"geolocations required", Composition{Count: 4, MaxPercent: 90, MinPercent: 3, Require: []string{"Geolocation", "geolocation"}, Seed: 1}, false},
+		{"no questions", Composition{Count: 0, MaxPercent: 90, MinPercent: 3}, true},
+		{"too many for the band", Composition{Count: 5, MaxPercent: 12, MinPercent: 3}, true},
+		{"too many for the categories", Composition{Count: 7, MaxPercent: 90, MinPercent: 3, PerCategory: 2}, true},
+		{"too many required", Composition{Count: 1, MaxPercent: 90, MinPercent: 3, Require: []string{"geolocation", "geolocation"}}, true},
+		{"a type not in the pool", Composition{Count: 3, MaxPercent: 90, MinPercent: 3, Require: []string{"wordle"}}, true},
+	}
+	for _, tt := range tests {
+		questions, err := Compose(composePool(), tt.c)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error %v, want an error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(questions) != tt.c.Count {
+			t.Errorf("%s: %d questions, want %d", tt.name, len(questions), tt.c.Count)
+		}
+		lo, hi := min(tt.c.MinPercent, tt.c.MaxPercent), max(tt.c.MinPercent, tt.c.MaxPercent)
+		picked := make(map[string]bool)
+		categories := make(map[string]int)
+		geolocations := 0
+		for i, q := range questions {
+			if q.Percent < lo || q.Percent > hi {
+				t.Errorf("%s: %s is %d%%, outside %d%% to %d%%", tt.name, q.Id, q.Percent, lo, hi)
+			}
+			if i > 0 && q.Percent > questions[i-1].Percent {
+				t.Errorf("%s: %s at %d%% comes after %d%%", tt.name, q.Id, q.Percent, questions[i-1].Percent)
+			}
+			if picked[q.Id] {
+				t.Errorf("%s: %s picked twice", tt.name, q.Id)
+			}
+			picked[q.Id] = true
+			categories[q.Category]++
+			if q.Type == "geolocation" {
+				geolocations++
+			}
+		}
+		for category, n := range categories {
+			if tt.c.PerCategory > 0 && n > tt.c.PerCategory {
+				t.Errorf("%s: %d questions from %s", tt.name, n, category)
+			}
+		}
+		if geolocations < len(tt.c.Require) {
+			t.Errorf("%s: %d geolocations, want at least %d", tt.name, geolocations, len(tt.c.Require))
+		}
+	}
+}
+
+func TestComposeSpread(t *testing.T) {
+	// ten questions from a pool with one every 3% should follow the band down closely
+	questions, err := Compose(composePool(), Composition{Count: 10, MaxPercent: 90, MinPercent: 0, Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, q := range questions {
+		want := 90 - 90*i/9
+		if abs(q.Percent-want) > 3 {
+			t.Errorf("question %d is %d%%, want about %d%%", i+1, q.Percent, want)
+		}
+	}
+}
+
+func TestComposeSeed(t *testing.T) {
+	// with two questions at every percent the seed decides which is played
+	pool := composePool()
+	for _, q := range composePool() {
+		q.Id = "r" + q.Id[1:]
+		pool = append(pool, q)
+	}
+	c := Composition{Count: 5, MaxPercent: 90, MinPercent: 3, PerCategory: 2, Seed: 42}
+	first, _ := Compose(pool, c)
+	again, _ := Compose(pool, c)
+	if !reflect.DeepEqual(ids(first), ids(again)) {
+		t.Errorf("the same seed composed %v then %v", ids(first), ids(again))
+	}
+	differ := false
+	for seed := int64(1); seed <= 10 && !differ; seed++ {
+		c.Seed = seed
+		other, _ := Compose(pool, c)
+		differ = !reflect.DeepEqual(ids(first), ids(other))
+	}
+	if !differ {
+		t.Errorf("ten other seeds all composed %v", ids(first))
+	}
+}
+
+func TestComposeSeen(t *testing.T) {
+	// one question at 45% picks q15, unless the players have seen it
+	c := Composition{Count: 1, MaxPercent: 45, MinPercent: 42, Seed: 1}
+	questions, _ := Compose(composePool(), c)
+	if got := ids(questions); !reflect.DeepEqual(got, []string{"q15"}) {
+		t.Fatalf("picked %v, want q15", got)
+	}
+	c.seen = map[string]int{"q15": 2}
+	questions, _ = Compose(composePool(), c)
+	if got := ids(questions); !reflect.DeepEqual(got, []string{"q14"}) {
+		t.Errorf("picked %v, want q14 which nobody has seen", got)
+	}
+}
+
+func TestComposed(t *testing.T) {
+	pool := []Question{
+		{Id: "a", Question: "one"},
+		{Question: "two"},
+		{Id: "c", Question: "three"},
+		{Question: "four"},
+	}
+	c := Composition{Questions: []string{"c", questionId(&pool[1]), "a"}, Positions: []int{2, 1, 0}, PoolSize: 4}
+	edited := func(at int, text string) []Question {
+		p := append([]Question(nil), pool...)
+		p[at].Question = text
+		return p
+	}
+	tests := []struct {
+		name    string
+		pool    []Question
+		want    []string
+		at      []int
+		wantErr bool
+	}{
+		{"unchanged", pool, []string{"three", "two", "one"}, []int{2, 1, 0}, false},
+		{"an id'd question moved", []Question{pool[2], pool[1], pool[0], pool[3]}, []string{"three", "two", "one"}, []int{0, 1, 2}, false},
+		{"a question without an id edited", edited(1, "two!"), []string{"three", "two!", "one"}, []int{2, 1, 0}, false},
+		{"a question with an id edited", edited(2, "three!"), []string{"three!", "two", "one"}, []int{2, 1, 0}, false},
+		{"edited and the pool has grown", append(edited(1, "two!"), Question{Question: "five"}), nil, nil, true},
+		{"a question deleted", []Question{pool[0], pool[2], pool[3]}, nil, nil, true},
+		{"moved and edited", []Question{pool[0], pool[2], {Question: "two!"}, pool[3]}, nil, nil, true},
+	}
+	for _, tt := range tests {
+		questions, at, err := composed(tt.pool, c)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error %v, want an error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		var got []string
+		for _, q := range questions {
+			got = append(got, q.Question)
+		}
+		if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(at, tt.at) {
+			t.Errorf("%s: found %v at %v, want %v at %v", tt.name, got, at, tt.want, tt.at)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// internal/game/country.go
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/imaging"
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+/**
+* Checks that the click image of a 'country' question is an SVG whose
+* shapes have ids and that every id in CorrectAnswers is one of them.
+* Problems are logged, the question is still asked
+ */
+func (q *Question) prepareCountries() {
+	for _, p := range q.countryProblems(imaging.LocalPath(q.ClickImage)) {
+		logger.Warn(fmt.Sprintf("Question %d: %s", q.QuestionNumber, p))
+	}
+}
+
+// countryProblems describes what's wrong with the countries of a 'country'
+// question whose click image is in the given file
+func (q *Question) countryProblems(path string) []string {
+	shapes, err := imaging.LoadShapes(path)
+	if err != nil {
+		return []string{fmt.Sprintf("cannot read the countries in %s: %s", q.ClickImage, err.Error())}
+	}
+	var problems []string
+	if len(q.CorrectAnswers) == 0 {
+		problems = append(problems, "there are no correctAnswers")
+	}
+	for _, id := range q.CorrectAnswers {
+		if findShape(shapes, id) == nil {
+			problems = append(problems, fmt.Sprintf("%s has no shape with the id %s", q.ClickImage, id))
+		}
+	}
+	return problems
+}
+
+// findShape returns the shape with the given id, ignoring case, or nil
+func findShape(shapes []imaging.Shape, id string) *imaging.Shape {
+	for i := range shapes {
+		if strings.EqualFold(shapes[i].Id, id) {
+			return &shapes[i]
+		}
+	}
+	return nil
+}
+
+/**
+* Scores a 'country' answer by finding which shape of the click image the
+* player clicked in. Any click inside one of the countries named by id in
+* CorrectAnswers earns full marks, anywhere else earns nothing
+* @param cq the question being answered
+* @param answer the answer to score
+ */
+func scoreCountry(cq *Question, answer *Answer) {
+	answer.Points = 0
+	answer.Country = ""
+	if len(answer.Clicks) == 0 || len(answer.Clicks[0]) != 2 {
+		answer.Clicks = nil
+		return
+	}
+	answer.Clicks = answer.Clicks[:1]
+	answer.Answer = "nowhere"
+	answer.Comment = "missed"
+	shapes, err := imaging.LoadShapes(imaging.LocalPath(cq.ClickImage))
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Question %d: cannot score the click: %s", cq.QuestionNumber, err.Error()))
+		return
+	}
+	x, y := answer.Clicks[0][0], answer.Clicks[0][1]
+	// later shapes are drawn on top of earlier ones so check them first
+	for i := len(shapes) - 1; i >= 0; i-- {
+		if !shapes[i].Contains(x, y) {
+			continue
+		}
+		answer.Country = shapes[i].Id
+		answer.Answer = shapes[i].Name
+		break
+	}
+	for _, id := range cq.CorrectAnswers {
+		if answer.Country != "" && strings.EqualFold(id, answer.Country) {
+			answer.Points = float32(cq.PointsAvailable)
+			answer.Comment = "hit"
+			return
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// internal/game/country_test.go
+package game
+
+import (
+	"testing"
+)
+
+// centralAsia returns the map point of the given longitude and latitude on
+// static/images/central_asia.svg, which is 20 units a degree from 50E 56N
+func centralAsia(lng, lat float64) [][]float64 {
+	return [][]float64{{(lng - 50) * 20, (56 - lat) * 20}}
+}
+
+func TestScoreCountry(t *testing.T) {
+	t.Chdir("../..")
+	q := &Question{Type: "country", ClickImage: "/static/images/central_asia.svg", PointsAvailable: 4, CorrectAnswers: []string{"kg"}}
+	tests := []struct {
+		name    string
+		clicks  [][]float64
+		points  float32
+		country string
+		answer  string
+	}{
+		{"Bishkek", centralAsia(74.6, 42.87), 4, "KG", "Kyrgyzstan"},
+		{"Osh", centralAsia(72.8, 40.53), 4, "KG", "Kyrgyzstan"},
+		{"Almaty", centralAsia(76.9, 43.25), 0, "KZ", "Kazakhstan"},
+		{"Tashkent", centralAsia(69.24, 41.3), 0, "UZ", "Uzbekistan"},
+		{"Dushanbe", centralAsia(68.78, 38.56), 0, "TJ", "Tajikistan"},
+		{"the Caspian", centralAsia(50.5, 42), 0, "", "nowhere"},
+		{"only the first click counts", append(centralAsia(76.9, 43.25), centralAsia(74.6, 42.87)...), 0, "KZ", "Kazakhstan"},
+		{"a bad click", [][]float64{{1}}, 0, "", ""},
+		{"no click", nil, 0, "", ""},
+	}
+	for _, tt := range tests {
+		a := Answer{Clicks: tt.clicks, Points: 99}
+		scoreCountry(q, &a)
+		if a.Points != tt.points || a.Country != tt.country || a.Answer != tt.answer {
+			t.Errorf("%s: scored %v in %q %q, want %v in %q %q", tt.name, a.Points, a.Country, a.Answer, tt.points, tt.country, tt.answer)
+		}
+		if len(a.Clicks) > 1 {
+			t.Errorf("%s: kept %d clicks", tt.name, len(a.Clicks))
+		}
+	}
+
+	// any of the correct answers will do
+	q.CorrectAnswers = []string{"UZ", "TJ"}
+	for _, c := range [][][]float64{centralAsia(69.24, 41.3), centralAsia(68.78, 38.56)} {
+		a := Answer{Clicks: c}
+		scoreCountry(q, &a)
+		if a.Points != 4 || a.Comment != "hit" {
+			t.Errorf("%s scored %v %q, want 4 and hit", a.Country, a.Points, a.Comment)
+		}
+	}
+}
+
+func TestCountryProblems(t *testing.T) {
+	t.Chdir("../..")
+	tests := []struct {
+		name     string
+		image    string
+		answers  []string
+		problems int
+	}{
+		{"all there", "/static/images/central_asia.svg", []string{"KZ", "kg"}, 0},
+		{"one missing", "/static/images/central_asia.svg", []string{"KZ", "GB"}, 1},
+		{"no answers", "/static/images/central_asia.svg", nil, 1},
+		{"no image", "/static/images/nothing.svg", []string{"KZ"}, 1},
+	}
+	for _, tt := range tests {
+		q := &Question{ClickImage: tt.image, CorrectAnswers: tt.answers}
+		if problems := q.countryProblems(tt.image[1:]); len(problems) != tt.problems {
+			t.Errorf("%s: %q, want %d problems", tt.name, problems, tt.problems)
+		}
+	}
+}
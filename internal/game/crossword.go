@@ -0,0 +1,196 @@
+// internal/game/crossword.go
+package game
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// CrosswordClue is a single clue of a 'crossword' question.
+// Only Number, Direction, Clue and Answer are given in the questions file,
+// the position and length are worked out from the grid when it is loaded
+type CrosswordClue struct {
+	Number    int    `json:"number"`           // the number printed in the first cell of the word
+	Direction string `json:"direction"`        // "across" or "down"
+	Clue      string `json:"clue"`             // the clue text shown to the players
+	Answer    string `json:"answer,omitempty"` // the word, hidden from players until the question ends
+	Row       int    `json:"row"`              // the row of the first cell of the word
+	Col       int    `json:"col"`              // the column of the first cell of the word
+	Length    int    `json:"length"`           // the number of cells in the word, zero if the clue doesn't fit the grid
+}
+
+// crosswordBlack is the character used for unused cells in CrosswordGrid and Answer.Cells
+const crosswordBlack = '#'
+
+// crosswordBlank is the character used for an empty white cell in Answer.Cells
+const crosswordBlank = ' '
+
+// Id returns the short form of the clue used in results, eg. 3A or 12D
+func (c CrosswordClue) Id() string {
+	return fmt.Sprintf("%d%s", c.Number, strings.ToUpper(c.Direction[:1]))
+}
+
+// cell returns the row and column of the i'th letter of the clues word
+func (c CrosswordClue) cell(i int) (int, int) {
+	if c.Direction == "down" {
+		return c.Row + i, c.Col
+	}
+	return c.Row, c.Col + i
+}
+
+// isCrosswordWhite reports whether the given cell is inside the grid and not black
+func isCrosswordWhite(grid [][]rune, row int, col int) bool {
+	if row < 0 || row >= len(grid) || col < 0 || col >= len(grid[row]) {
+		return false
+	}
+	return grid[row][col] != crosswordBlack
+}
+
+/**
+* Numbers the grid in the usual crossword fashion, working along each row in
+* turn and giving a number to every white cell which starts a word across or
+* down, then fills in the position and length of each clue from the numbering.
+* Any clue that doesn't fit the grid is logged and left with a zero length
+* so that it can never be scored as correct
+ */
+func (q *Question) layoutCrossword() {
+	for _, p := range q.placeClues() {
+		logger.Warn(fmt.Sprintf("Question %d: %s", q.QuestionNumber, p))
+	}
+}
+
+// placeClues does the work of layoutCrossword, returning a description of
+// each clue that doesn't fit the grid so that validate can report them too
+func (q *Question) placeClues() []string {
+	grid := make([][]rune, len(q.CrosswordGrid))
+	for r, row := range q.CrosswordGrid {
+		grid[r] = []rune(row)
+	}
+	type slot struct{ row, col, length int }
+	slots := make(map[string]slot)
+	number := 0
+	for r := range grid {
+		for c := range grid[r] {
+			if !isCrosswordWhite(grid, r, c) {
+				continue
+			}
+			across := !isCrosswordWhite(grid, r, c-1) && isCrosswordWhite(grid, r, c+1)
+			down := !isCrosswordWhite(grid, r-1, c) && isCrosswordWhite(grid, r+1, c)
+			if !across && !down {
+				continue
+			}
+			number++
+			if across {
+				l := 0
+				for isCrosswordWhite(grid, r, c+l) {
+					l++
+				}
+				slots[fmt.Sprintf("%d%s", number, "A")] = slot{r, c, l}
+			}
+			if down {
+				l := 0
+				for isCrosswordWhite(grid, r+l, c) {
+					l++
+				}
+				slots[fmt.Sprintf("%d%s", number, "D")] = slot{r, c, l}
+			}
+		}
+	}
+	var problems []string
+	if len(q.Clues) == 0 {
+		problems = append(problems, "the crossword has no clues")
+	}
+	for i := range q.Clues {
+		c := &q.Clues[i]
+		c.Direction = strings.ToLower(strings.TrimSpace(c.Direction))
+		c.Answer = strings.ToUpper(strings.ReplaceAll(c.Answer, " ", ""))
+		c.Length = 0
+		if c.Direction != "across" && c.Direction != "down" {
+			problems = append(problems, fmt.Sprintf("crossword clue %d has direction %q, expected across or down", c.Number, c.Direction))
+			continue
+		}
+		s, exists := slots[c.Id()]
+		if !exists {
+			problems = append(problems, fmt.Sprintf("crossword clue %s doesn't start a word in the grid", c.Id()))
+			continue
+		}
+		if len([]rune(c.Answer)) != s.length {
+			problems = append(problems, fmt.Sprintf("crossword clue %s answer %s doesn't fill its %d cells", c.Id(), c.Answer, s.length))
+			continue
+		}
+		c.Row, c.Col, c.Length = s.row, s.col, s.length
+	}
+	return problems
+}
+
+/**
+* Checks the cells submitted by a player cell by cell against the grid.
+* Letters are upper cased and anything which isn't a letter in a white cell
+* is treated as empty, so the result always has the same shape as the grid
+* @param grid the crossword grid from the question
+* @param cells the rows of letters the player submitted
+* @return the cleaned rows of letters
+ */
+func cleanCrosswordCells(grid []string, cells []string) []string {
+	ret := make([]string, len(grid))
+	for r, row := range grid {
+		var given []rune
+		if r < len(cells) {
+			given = []rune(cells[r])
+		}
+		clean := []rune(row)
+		for c := range clean {
+			if clean[c] == crosswordBlack {
+				continue
+			}
+			clean[c] = crosswordBlank
+			if c < len(given) && unicode.IsLetter(given[c]) {
+				clean[c] = unicode.ToUpper(given[c])
+			}
+		}
+		ret[r] = string(clean)
+	}
+	return ret
+}
+
+/**
+* Scores a 'crossword' answer. The submitted cells are cleaned against the
+* grid then each clue whose word is filled in correctly earns an equal share
+* of PointsAvailable. The clues answered correctly are recorded in the answer
+* so that the reveal can show who got which words
+* @param cq the question being answered
+* @param answer the answer to score
+ */
+func scoreCrossword(cq *Question, answer *Answer) {
+	answer.Points = 0
+	answer.Words = nil
+	if len(answer.Cells) == 0 || len(cq.Clues) == 0 {
+		answer.Cells = nil
+		return
+	}
+	answer.Cells = cleanCrosswordCells(cq.CrosswordGrid, answer.Cells)
+	grid := make([][]rune, len(answer.Cells))
+	for r, row := range answer.Cells {
+		grid[r] = []rune(row)
+	}
+	for _, clue := range cq.Clues {
+		if clue.Length == 0 {
+			continue
+		}
+		word := []rune(clue.Answer)
+		correct := true
+		for i := 0; i < clue.Length && correct; i++ {
+			r, c := clue.cell(i)
+			correct = grid[r][c] == word[i]
+		}
+		if correct {
+			answer.Words = append(answer.Words, clue.Id())
+		}
+	}
+	answer.Points = float32(cq.PointsAvailable) * float32(len(answer.Words)) / float32(len(cq.Clues))
+	answer.Answer = fmt.Sprintf("%d / %d words", len(answer.Words), len(cq.Clues))
+	answer.Comment = strings.Join(answer.Words, " ")
+}
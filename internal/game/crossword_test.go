@@ -0,0 +1,111 @@
+// internal/game/crossword_test.go
+package game
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// crosswordQuestion returns a laid out crossword of
+//
+//	P A T
+//	O # O
+//	D O E
+//
+// whose clues are 1A PAT, 1D POD, 2D TOE and 3A DOE
+func crosswordQuestion() *Question {
+	q := &Question{
+		Type:            "crossword",
+		PointsAvailable: 8,
+		CrosswordGrid:   []string{"PAT", "O#O", "DOE"},
+		Clues: []CrosswordClue{
+			{Number: 1, Direction: "across", Answer: "pat"},
+			{Number: 1, Direction: " Down ", Answer: "pod"},
+			{Number: 2, Direction: "down", Answer: "TOE"},
+			{Number: 3, Direction: "across", Answer: "D O E"},
+		},
+	}
+	q.layoutCrossword()
+	return q
+}
+
+func TestPlaceClues(t *testing.T) {
+	q := crosswordQuestion()
+	want := []CrosswordClue{
+		{Number: 1, Direction: "across", Answer: "PAT", Row: 0, Col: 0, Length: 3},
+		{Number: 1, Direction: "down", Answer: "POD", Row: 0, Col: 0, Length: 3},
+		{Number: 2, Direction: "down", Answer: "TOE", Row: 0, Col: 2, Length: 3},
+		{Number: 3, Direction: "across", Answer: "DOE", Row: 2, Col: 0, Length: 3},
+	}
+	if !reflect.DeepEqual(q.Clues, want) {
+		t.Errorf("clues laid out as %+v, want %+v", q.Clues, want)
+	}
+
+	tests := []struct {
+		clue    CrosswordClue
+		problem string
+	}{
+		{CrosswordClue{Number: 2, Direction: "across", Answer: "AT"}, "2A doesn't start a word"},
+		{CrosswordClue{Number: 4, Direction: "down", Answer: "X"}, "4D doesn't start a word"},
+		{CrosswordClue{Number: 1, Direction: "across", Answer: "PATS"}, "doesn't fill its 3 cells"},
+		{CrosswordClue{Number: 1, Direction: "sideways", Answer: "PAT"}, `direction "sideways"`},
+		{CrosswordClue{Number: 1, Direction: "", Answer: "PAT"}, `direction ""`},
+	}
+	for _, tt := range tests {
+		q := &Question{CrosswordGrid: []string{"PAT", "O#O", "DOE"}, Clues: []CrosswordClue{tt.clue}}
+		problems := q.placeClues()
+		if len(problems) != 1 || !strings.Contains(problems[0], tt.problem) {
+			t.Errorf("placing %+v gave %q, want %q", tt.clue, problems, tt.problem)
+		}
+		if q.Clues[0].Length != 0 {
+			t.Errorf("%+v doesn't fit but has length %d", tt.clue, q.Clues[0].Length)
+		}
+	}
+	if problems := (&Question{CrosswordGrid: []string{"AB"}}).placeClues(); len(problems) != 1 {
+		t.Errorf("a crossword without clues gave %q", problems)
+	}
+}
+
+func TestScoreCrossword(t *testing.T) {
+	tests := []struct {
+		name   string
+		cells  []string
+		points float32
+		words  []string
+		clean  []string
+	}{
+		{"all right", []string{"PAT", "O#O", "DOE"}, 8, []string{"1A", "1D", "2D", "3A"}, []string{"PAT", "O#O", "DOE"}},
+		{"lower case", []string{"pat", "o o", "doe"}, 8, []string{"1A", "1D", "2D", "3A"}, []string{"PAT", "O#O", "DOE"}},
+		{"one word", []string{"PAT", "   ", "   "}, 2, []string{"1A"}, []string{"PAT", " # ", "   "}},
+		{"one wrong letter", []string{"PAT", "O#O", "DUE"}, 6, []string{"1A", "1D", "2D"}, []string{"PAT", "O#O", "DUE"}},
+		{"short rows", []string{"PA", "O"}, 0, nil, []string{"PA ", "O# ", "   "}},
+		{"not letters", []string{"P4T", "O#O", "D?E"}, 4, []string{"1D", "2D"}, []string{"P T", "O#O", "D E"}},
+		{"too many rows", []string{"PAT", "O#O", "DOE", "XYZ"}, 8, []string{"1A", "1D", "2D", "3A"}, []string{"PAT", "O#O", "DOE"}},
+		{"nothing", nil, 0, nil, nil},
+	}
+	for _, tt := range tests {
+		q := crosswordQuestion()
+		a := Answer{Cells: tt.cells}
+		scoreCrossword(q, &a)
+		if a.Points != tt.points {
+			t.Errorf("%s: scored %v, want %v", tt.name, a.Points, tt.points)
+		}
+		if !reflect.DeepEqual(a.Words, tt.words) {
+			t.Errorf("%s: words %q, want %q", tt.name, a.Words, tt.words)
+		}
+		if !reflect.DeepEqual(a.Cells, tt.clean) {
+			t.Errorf("%s: cells cleaned to %q, want %q", tt.name, a.Cells, tt.clean)
+		}
+	}
+
+	// a clue that doesn't fit the grid can't be scored but still takes its share
+	q := crosswordQuestion()
+	q.Clues = append(q.Clues, CrosswordClue{Number: 9, Direction: "across", Answer: "X"})
+	q.layoutCrossword()
+	a := Answer{Cells: []string{"PAT", "O#O", "DOE"}}
+	scoreCrossword(q, &a)
+	if a.Points != 6.4 || a.Answer != "4 / 5 words" {
+		t.Errorf("with a clue that doesn't fit scored %v %q, want 6.4 and 4 / 5 words", a.Points, a.Answer)
+	}
+}
@@ -0,0 +1,355 @@
+// internal/game/csv.go
+package game
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+/*
+Questions can be written in a spreadsheet and saved as CSV, one row per
+question. The first row names the columns, which can be in any order and any
+of which can be left out. Choices and correct answers are separated by |
+eg. a multichoice question has choices "Paris|London|Rome" and correct answers
+"Paris". Anything without a column of its own, such as the statements of a
+truefalse question, is written as JSON in the extra column
+*/
+var csvColumns = []string{
+	"id", "type", "category", "percent", "question", "choices", "correctAnswers", "hostAnswer", "link",
+	"imageUrl", "clickImage", "answerImage", "audioUrl", "videoUrl", "mediaStart", "mediaEnd",
+	"timeLimit", "readTime", "pointsAvailable", "penalisationFactor", "streetView", "extra",
+}
+
+// headings are matched to columns ignoring case and spaces, eg. "Correct Answers"
+var csvHeading = strings.NewReplacer(" ", "", "_", "", "-", "")
+
+// columns holding whole numbers and numbers which may have a fraction
+var (
+	csvInts   = map[string]bool{"percent": true, "timeLimit": true, "readTime": true, "pointsAvailable": true}
+	csvFloats = map[string]bool{"mediaStart": true, "mediaEnd": true, "penalisationFactor": true}
+)
+
+// fields the server works out as a game is played, which are never exported
+var runtimeFields = []string{
+	"answers", "questionNumber", "seenBy", "tiles", "tileSize", "timeLeft", "timeStarted", "isTimedOut",
+	"statementResponses", "revealStage", "mediaStartsAt", "wordLength", "guesses", "featured", "targets",
+}
+
+// choiceLetter returns the answer given to the nth choice of a multichoice question, A, B, C...
+func choiceLetter(n int) string {
+	return string(rune('A' + n))
+}
+
+// hasSimpleChoices reports whether the choices are plain text answered A, B, C...
+// so that they can be written as text in the choices column
+func hasSimpleChoices(q *Question) bool {
+	for i, c := range q.Choices {
+		if c.ImgUrl != "" || c.Answer != choiceLetter(i) || strings.Contains(c.Choice, "|") {
+			return false
+		}
+	}
+	return len(q.Choices) > 0
+}
+
+// questionFields returns the fields of the question as they would be written
+// in a questions file, leaving out those worked out during a game and any
+// which are empty
+func questionFields(q *Question) (map[string]any, error) {
+	data, err := json.Marshal(q)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]any)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	for _, f := range runtimeFields {
+		delete(fields, f)
+	}
+	for k, v := range fields {
+		if v == nil {
+			delete(fields, k)
+		}
+	}
+	return fields, nil
+}
+
+/**
+* Writes the questions as CSV with a header row naming the columns
+* @param w where to write the CSV
+* @param questions the questions to write
+* @return an error if the questions couldn't be written
+ */
+func ExportCSV(w io.Writer, questions []Question) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return err
+	}
+	for i := range questions {
+		q := questions[i]
+		fields, err := questionFields(&q)
+		if err != nil {
+			return err
+		}
+		// the id is written even if it was made from the question, so that
+		// editing the question in the spreadsheet keeps its history
+		fields["id"] = questionId(&q)
+		row := make([]string, len(csvColumns))
+		if hasSimpleChoices(&q) {
+			texts := make([]string, len(q.Choices))
+			letters := make(map[string]string)
+			for j, c := range q.Choices {
+				texts[j] = c.Choice
+				letters[c.Answer] = c.Choice
+			}
+			correct := make([]string, len(q.CorrectAnswers))
+			for j, ca := range q.CorrectAnswers {
+				correct[j] = ca
+				if text, ok := letters[ca]; ok {
+					correct[j] = text
+				}
+			}
+			fields["choices"] = strings.Join(texts, "|")
+			fields["correctAnswers"] = strings.Join(correct, "|")
+		} else if len(q.CorrectAnswers) > 0 && !strings.Contains(strings.Join(q.CorrectAnswers, ""), "|") {
+			fields["correctAnswers"] = strings.Join(q.CorrectAnswers, "|")
+		}
+		for j, col := range csvColumns {
+			v, ok := fields[col]
+			if !ok || col == "extra" {
+				continue
+			}
+			switch v := v.(type) {
+			case string:
+				row[j] = v
+				delete(fields, col)
+			case json.Number:
+				if v.String() != "0" {
+					row[j] = v.String()
+				}
+				delete(fields, col)
+			}
+		}
+		if len(fields) > 0 {
+			extra, err := json.Marshal(fields)
+			if err != nil {
+				return err
+			}
+			row[len(row)-1] = string(extra)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+/**
+* Reads questions written as CSV by a spreadsheet or ExportCSV
+* @param r the CSV
+* @param source the name of the CSV file, used in problems
+* @return the questions, the line each started on and the problems with the rows, the questions can't be used if there are any
+ */
+func readCSV(r io.Reader, source string) ([]Question, []int, []Problem) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	var problems []Problem
+	add := func(line int, question int, format string, a ...any) {
+		problems = append(problems, Problem{source, line, question, fmt.Sprintf(format, a...), false})
+	}
+	header, err := cr.Read()
+	if err != nil {
+		add(1, 0, "there is no header row: %v", err)
+		return nil, nil, problems
+	}
+	columns := make([]string, len(header))
+	for i, h := range header {
+		// spreadsheets may start the file with a byte order mark
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		for _, col := range csvColumns {
+			if strings.EqualFold(csvHeading.Replace(h), col) {
+				columns[i] = col
+			}
+		}
+		if columns[i] == "" && h != "" {
+			add(1, 0, "unknown column %q", h)
+		}
+	}
+
+	var questions []Question
+	var lines []int
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := cr.FieldPos(0)
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			add(parseErr.Line, 0, "%v", parseErr.Err)
+			break
+		}
+		if err != nil {
+			add(line, 0, "%v", err)
+			break
+		}
+		n := len(questions) + 1
+		cells := make(map[string]string)
+		empty := true
+		for i, v := range record {
+			if i < len(columns) && columns[i] != "" && strings.TrimSpace(v) != "" {
+				cells[columns[i]] = strings.TrimSpace(v)
+				empty = false
+			}
+		}
+		if empty {
+			continue
+		}
+		q, rowProblems := questionFromRow(cells)
+		for _, p := range rowProblems {
+			add(line, n, "%s", p)
+		}
+		questions = append(questions, q)
+		lines = append(lines, line)
+	}
+	return questions, lines, problems
+}
+
+// questionFromRow builds a question from the cells of a row, keyed by column,
+// returning whatever is wrong with them
+func questionFromRow(cells map[string]string) (Question, []string) {
+	var q Question
+	var problems []string
+	fields := make(map[string]any)
+	if extra, ok := cells["extra"]; ok {
+		if err := json.Unmarshal([]byte(extra), &fields); err != nil {
+			problems = append(problems, fmt.Sprintf("extra isn't a JSON object: %v", err))
+		}
+	}
+	for _, col := range csvColumns {
+		v, ok := cells[col]
+		if !ok {
+			continue
+		}
+		switch {
+		case col == "extra" || col == "choices" || col == "correctAnswers":
+		case csvInts[col]:
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s should be a whole number not %q", col, v))
+			}
+			fields[col] = n
+		case csvFloats[col]:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s should be a number not %q", col, v))
+			}
+			fields[col] = f
+		default:
+			fields[col] = v
+		}
+	}
+	if choices, ok := cells["choices"]; ok {
+		var list []Choice
+		for i, c := range strings.Split(choices, "|") {
+			list = append(list, Choice{Choice: strings.TrimSpace(c), Answer: choiceLetter(i)})
+		}
+		fields["choices"] = list
+	}
+	var correct []string
+	if ca, ok := cells["correctAnswers"]; ok {
+		for _, c := range strings.Split(ca, "|") {
+			correct = append(correct, strings.TrimSpace(c))
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err == nil {
+		err = json.Unmarshal(data, &q)
+	}
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("%v", err))
+	}
+	if correct != nil {
+		q.CorrectAnswers = correct
+	}
+	// correct answers may be given as the text of a choice or, failing that, its letter
+	if _, ok := cells["choices"]; ok {
+		for i, ca := range q.CorrectAnswers {
+			answer := ""
+			for _, c := range q.Choices {
+				if strings.EqualFold(ca, c.Choice) {
+					answer = c.Answer
+					break
+				}
+			}
+			for _, c := range q.Choices {
+				if answer == "" && ca == c.Answer {
+					answer = c.Answer
+				}
+			}
+			if answer == "" {
+				problems = append(problems, fmt.Sprintf("correct answer %q isn't one of the choices", ca))
+			}
+			q.CorrectAnswers[i] = answer
+		}
+	}
+	if q.Question == "" {
+		problems = append(problems, "there is no question")
+	}
+	if !questionTypes[q.Type] {
+		problems = append(problems, fmt.Sprintf("unknown type %q", q.Type))
+	}
+	return q, problems
+}
+
+/**
+* Reads questions written as CSV and makes a question pack of them, after
+* checking them as a questions file would be checked
+* @param r the CSV
+* @param source the name of the CSV file, used in problems
+* @param name the name of the new pack directory
+* @param title the title of the new pack, defaults to the name
+* @return the problems, reported by line of the CSV, and an error if the pack wasn't made
+ */
+func ImportCSVPack(r io.Reader, source string, name string, title string) ([]Problem, error) {
+	questions, lines, problems := readCSV(r, source)
+	if len(problems) > 0 {
+		return problems, fmt.Errorf("%s has %d problems, the first is %s", source, len(problems), problems[0])
+	}
+	if len(questions) == 0 {
+		return nil, fmt.Errorf("there are no questions in %s", source)
+	}
+	return CreateImportedPack(Pack{Name: name, Title: title}, questions, source, lines)
+}
+
+/**
+* Makes a new pack of questions imported from another file, reporting any
+* problems found checking them by the line of the file each question came from
+* @param p the pack's name and details
+* @param questions the questions
+* @param source the name of the file the questions came from
+* @param lines the line of the file each question started on
+* @return the problems and an error if the pack wasn't made
+ */
+func CreateImportedPack(p Pack, questions []Question, source string, lines []int) ([]Problem, error) {
+	problems, err := CreatePack(p, questions)
+	for i := range problems {
+		problems[i].File = source
+		if q := problems[i].Question; q > 0 && q <= len(lines) {
+			problems[i].Line = lines[q-1]
+		}
+	}
+	if err != nil && len(problems) > 0 {
+		return problems, fmt.Errorf("%s has %d problems, the first is %s", source, len(problems), problems[0])
+	}
+	return problems, err
+}
@@ -0,0 +1,113 @@
+// internal/game/csv_test.go
+package game
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		q    Question
+	}{
+		{"freetext", Question{Id: "capital", Type: "freetext", Category: "geography", Percent: 80, Question: "What is the capital of France?",
+			CorrectAnswers: []string{"Paris", "paris, france"}, HostAnswer: "Paris", TimeLimit: 30, PointsAvailable: 2, PenalisationFactor: 1.5}},
+		{"without an id", Question{Type: "freetext", Category: "music", Percent: 50, Question: "Who sang \"Relight My Fire\", with Lulu?",
+			CorrectAnswers: []string{"Take That"}}},
+		{"multichoice", Question{Type: "multichoice", Category: "science", Percent: 60, Question: "Which is a noble gas?",
+			Choices:        []Choice{{Choice: "Nitrogen", Answer: "A"}, {Choice: "Argon", Answer: "B"}, {Choice: "Oxygen, the gas", Answer: "C"}},
+			CorrectAnswers: []string{"B"}}},
+		{"choices with pictures", Question{Type: "multichoice", Category: "art", Percent: 40, Question: "Which is a Monet?",
+			Choices:        []Choice{{Choice: "one", ImgUrl: "/static/images/a.jpg", Answer: "A"}, {Choice: "two", ImgUrl: "/static/images/b.jpg", Answer: "B"}},
+			CorrectAnswers: []string{"A"}}},
+		{"an answer with a bar", Question{Type: "freetext", Category: "computing", Percent: 10, Question: "What is the pipe character?",
+			CorrectAnswers: []string{"|", "pipe"}}},
+		{"truefalse", Question{Type: "truefalse", Category: "history", Percent: 70, Question: "True or false?", StreakBonus: 0.5,
+			Statements: []Statement{{Statement: "Rome wasn't built in a day", IsTrue: true}, {Statement: "Napoleon was short"}}}},
+		{"media", Question{Type: "freetext", Category: "film", Percent: 30, Question: "Name the film",
+			CorrectAnswers: []string{"Jaws"}, VideoUrl: "/media/jaws.mp4", MediaStart: 12.5, MediaEnd: 20}},
+		{"regions", Question{Type: "kazakhstan", Category: "faces", Percent: 20, Question: "Click on Gary", ClickImage: "/static/images/tt.jpg",
+			Regions: []HitRegion{{Name: "Gary", Cx: 10, Cy: 20, Rx: 5, Ry: 6}}, RegionDecay: 15}},
+	}
+	var questions []Question
+	for _, tt := range tests {
+		questions = append(questions, tt.q)
+	}
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, questions); err != nil {
+		t.Fatal(err)
+	}
+	read, lines, problems := readCSV(bytes.NewReader(buf.Bytes()), "test.csv")
+	if len(problems) > 0 {
+		t.Fatalf("problems reading the export back: %v\n%s", problems, buf.String())
+	}
+	if len(read) != len(tests) {
+		t.Fatalf("read %d questions back, want %d", len(read), len(tests))
+	}
+	for i, tt := range tests {
+		want := tt.q
+		want.Id = questionId(&tt.q)
+		if !reflect.DeepEqual(read[i], want) {
+			t.Errorf("%s: read back as\n%+v\nwant\n%+v", tt.name, read[i], want)
+		}
+		if i > 0 && lines[i] <= lines[i-1] {
+			t.Errorf("%s: starts on line %d, after line %d", tt.name, lines[i], lines[i-1])
+		}
+	}
+}
+
+func TestExportCSVLeavesOutRuntimeFields(t *testing.T) {
+	q := Question{Type: "freetext", Question: "q", QuestionNumber: 3, TimeLeft: 10, IsTimedOut: true, SeenBy: 2,
+		Answers: []Answer{{Username: "alice", Answer: "a"}}}
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, []Question{q}); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"alice", "questionNumber", "timeLeft", "isTimedOut", "seenBy", "timeStarted"} {
+		if strings.Contains(buf.String(), f) {
+			t.Errorf("the export has %s in it\n%s", f, buf.String())
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		csv       string
+		questions int
+		problems  []string
+	}{
+		{"headings written by hand", "\ufeffType,Correct Answers,QUESTION,time_limit\nfreetext,Paris|paris,Capital of France?,30\n", 1, nil},
+		{"choices by letter", "type,question,choices,correctAnswers\nmultichoice,Which?,red|green,B\n", 1, nil},
+		{"blank rows skipped", "type,question\n\n,\nfreetext,q\n", 1, nil},
+		{"an unknown column", "type,question,colour\nfreetext,q,red\n", 1, []string{`unknown column "colour"`}},
+		{"no header", "", 0, []string{"there is no header row: EOF"}},
+		{"not a number", "type,question,percent\nfreetext,q,lots\n", 1, []string{`percent should be a whole number not "lots"`}},
+		{"not a choice", "type,question,choices,correctAnswers\nmultichoice,Which?,red|green,blue\n", 1, []string{`correct answer "blue" isn't one of the choices`}},
+		{"no question", "type,question\nfreetext,\"  \"\n", 1, []string{"there is no question"}},
+		{"an unknown type", "type,question\nquiz,q\n", 1, []string{`unknown type "quiz"`}},
+		{"bad extra", "type,question,extra\nfreetext,q,{statements\n", 1, []string{"extra isn't a JSON object: invalid character 's' looking for beginning of object key string"}},
+		{"a bad quote", "type,question\nfreetext,\"q\n", 0, []string{`extraneous or missing " in quoted-field`}},
+	}
+	for _, tt := range tests {
+		questions, _, problems := readCSV(strings.NewReader(tt.csv), "test.csv")
+		if len(questions) != tt.questions {
+			t.Errorf("%s: %d questions, want %d", tt.name, len(questions), tt.questions)
+		}
+		var got []string
+		for _, p := range problems {
+			got = append(got, p.Message)
+		}
+		if !reflect.DeepEqual(got, tt.problems) {
+			t.Errorf("%s: problems %q, want %q", tt.name, got, tt.problems)
+		}
+	}
+
+	questions, _, _ := readCSV(strings.NewReader("type,question,choices,correctAnswers\nmultichoice,Which?,red|Green|blue,green|A\n"), "test.csv")
+	if got := questions[0].CorrectAnswers; !reflect.DeepEqual(got, []string{"B", "A"}) {
+		t.Errorf("the correct answers green and A were read as %v, want B and A", got)
+	}
+}
@@ -0,0 +1,286 @@
+// internal/game/editor.go
+package game
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// only one edit is made to a questions file at a time
+var editMu sync.Mutex
+
+// the images which can be uploaded for questions, and the types they must sniff
+// as. svg isn't one of them as it can carry scripts which would run on our origin
+var assetTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+// ErrBadEdit is returned for an edit which makes no sense, such as a
+// question number which is out of range, rather than one with problems
+var ErrBadEdit = errors.New("the edit can't be made")
+
+// editPath returns the path of the questions file of the pack, or of
+// questions.json if the pack is ""
+func editPath(pack string) (string, error) {
+	if pack == "" {
+		return questionsFile, nil
+	}
+	dir, err := packDir(pack)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrBadEdit, err)
+	}
+	return filepath.Join(dir, "questions.json"), nil
+}
+
+/**
+* Reads the questions of a pack, or of questions.json if the pack is "",
+* written as a questions file of the current version so they can be edited
+* @param pack the name of the pack
+* @return the questions file or an error if the pack couldn't be read
+ */
+func EditableQuestions(pack string) ([]byte, error) {
+	path, err := editPath(pack)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := ReadQuestionsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return marshalQuestions(questions)
+}
+
+/*
+editQuestions makes a change to the questions of a pack and saves them. The
+changed questions are written to a new file and validated, and only replace
+the old file, which is kept with .bak added to its name, if the change adds no
+problems. A file of an older version is upgraded when it's saved
+*/
+func editQuestions(pack string, edit func([]Question) ([]Question, error)) ([]Problem, error) {
+	path, err := editPath(pack)
+	if err != nil {
+		return nil, err
+	}
+	editMu.Lock()
+	defer editMu.Unlock()
+	questions, err := ReadQuestionsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// problems already in the file don't stop an edit, so that a file with
+	// several problems can be put right one question at a time
+	before := make(map[string]bool)
+	if problems, _, err := ValidateQuestions(path); err == nil {
+		for _, p := range problems {
+			before[problemKey(p, questions)] = true
+		}
+	}
+	if questions, err = edit(questions); err != nil {
+		return nil, err
+	}
+	if len(questions) == 0 {
+		return nil, fmt.Errorf("%w: there must be at least one question", ErrBadEdit)
+	}
+	data, err := marshalQuestions(questions)
+	if err != nil {
+		return nil, err
+	}
+	// the new file is in the same directory so that images relative to it are found
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return nil, err
+	}
+	problems, _, err := ValidateQuestions(tmp)
+	if err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+	var errs []Problem
+	for i := range problems {
+		problems[i].File = path
+		if !problems[i].Warning && !before[problemKey(problems[i], questions)] {
+			errs = append(errs, problems[i])
+		}
+	}
+	if len(errs) > 0 {
+		os.Remove(tmp)
+		return problems, fmt.Errorf("the change would add %d problems, the first is %s", len(errs), errs[0])
+	}
+	if err := replaceWith(path, tmp); err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("%s saved with %d questions", path, len(questions)))
+	return problems, nil
+}
+
+// problemKey identifies a problem by the question it's with rather than its
+// number, which changes as questions are added, deleted and reordered
+func problemKey(p Problem, questions []Question) string {
+	if p.Question < 1 || p.Question > len(questions) {
+		return p.Message
+	}
+	return questionId(&questions[p.Question-1]) + ": " + p.Message
+}
+
+// checkNumber returns an error unless n is the number of one of the questions
+func checkNumber(n int, questions []Question) error {
+	if n < 1 || n > len(questions) {
+		return fmt.Errorf("%w: there is no question %d, there are %d", ErrBadEdit, n, len(questions))
+	}
+	return nil
+}
+
+/**
+* Adds a question to a pack, or to questions.json if the pack is ""
+* @param pack the name of the pack
+* @param q the new question
+* @param at the number the question will have, those after it moving down, or 0 to add it at the end
+* @return the number of the new question, any problems with the questions and an error if it wasn't added
+ */
+func AddQuestion(pack string, q Question, at int) (int, []Problem, error) {
+	problems, err := editQuestions(pack, func(questions []Question) ([]Question, error) {
+		if at == 0 {
+			at = len(questions) + 1
+		}
+		if at < 1 || at > len(questions)+1 {
+			return nil, fmt.Errorf("%w: a question can't be added at %d, there are %d", ErrBadEdit, at, len(questions))
+		}
+		questions = append(questions[:at-1], append([]Question{q}, questions[at-1:]...)...)
+		return questions, nil
+	})
+	return at, problems, err
+}
+
+/**
+* Replaces a question of a pack, or of questions.json if the pack is "". If
+* the question had no id it's given the one it had before it was changed, so
+* that the history of who has seen it is kept
+* @param pack the name of the pack
+* @param n the number of the question
+* @param q the question as it should now be
+* @return any problems with the questions and an error if it wasn't changed
+ */
+func UpdateQuestion(pack string, n int, q Question) ([]Problem, error) {
+	return editQuestions(pack, func(questions []Question) ([]Question, error) {
+		if err := checkNumber(n, questions); err != nil {
+			return nil, err
+		}
+		if q.Id == "" {
+			q.Id = questionId(&questions[n-1])
+		}
+		questions[n-1] = q
+		return questions, nil
+	})
+}
+
+/**
+* Deletes a question from a pack, or from questions.json if the pack is ""
+* @param pack the name of the pack
+* @param n the number of the question
+* @return any problems with the questions left and an error if it wasn't deleted
+ */
+func DeleteQuestion(pack string, n int) ([]Problem, error) {
+	return editQuestions(pack, func(questions []Question) ([]Question, error) {
+		if err := checkNumber(n, questions); err != nil {
+			return nil, err
+		}
+		return append(questions[:n-1], questions[n:]...), nil
+	})
+}
+
+/**
+* Puts the questions of a pack, or of questions.json if the pack is "", in a
+* new order
+* @param pack the name of the pack
+* @param order the number every question had before, in the new order eg. [2 1 3] swaps the first two
+* @return any problems with the questions and an error if they weren't reordered
+ */
+func ReorderQuestions(pack string, order []int) ([]Problem, error) {
+	return editQuestions(pack, func(questions []Question) ([]Question, error) {
+		if len(order) != len(questions) {
+			return nil, fmt.Errorf("%w: the order has %d questions but there are %d", ErrBadEdit, len(order), len(questions))
+		}
+		reordered := make([]Question, 0, len(questions))
+		used := make(map[int]bool)
+		for _, n := range order {
+			if err := checkNumber(n, questions); err != nil {
+				return nil, err
+			}
+			if used[n] {
+				return nil, fmt.Errorf("%w: question %d is in the order twice", ErrBadEdit, n)
+			}
+			used[n] = true
+			reordered = append(reordered, questions[n-1])
+		}
+		return reordered, nil
+	})
+}
+
+/**
+* Saves an image for the questions of a pack in the pack's images directory,
+* or in static/images for questions.json if the pack is "". The file is named
+* from its contents, so uploading the same image twice saves it once
+* @param pack the name of the pack
+* @param filename the name of the uploaded file, only its extension is used
+* @param r the image
+* @return the url to give the question eg. images/3f2a9c0b1d4e.png and an error if it wasn't saved
+ */
+func SaveAsset(pack string, filename string, r io.Reader) (string, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	contentType, ok := assetTypes[ext]
+	if !ok {
+		return "", fmt.Errorf("%w: %s files can't be uploaded", ErrBadEdit, ext)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	// the extension must match what the file actually is
+	if http.DetectContentType(data) != contentType {
+		return "", fmt.Errorf("%w: %s isn't a %s image", ErrBadEdit, filename, ext)
+	}
+	dir, url := filepath.Join("static", "images"), "/static/images/"
+	if pack != "" {
+		pd, err := packDir(pack)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrBadEdit, err)
+		}
+		if _, err := os.Stat(pd); err != nil {
+			return "", err
+		}
+		dir, url = filepath.Join(pd, "images"), "images/"
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	sum := sha1.Sum(data)
+	name := hex.EncodeToString(sum[:6]) + ext
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return url + name, nil
+	}
+	tmp := filepath.Join(dir, "."+name+".tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	logger.Info(fmt.Sprintf("%s saved", path))
+	return url + name, nil
+}
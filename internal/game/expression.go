@@ -0,0 +1,241 @@
+// internal/game/expression.go
+package game
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// the operators allowed if the question doesn't specify
+var defaultOperators = "+-*/"
+
+// how far from the target an answer can be and still score if the question doesn't specify
+var defaultTolerance = 10
+
+// GetOperators returns the operators players may use in an 'expression' answer
+func (q *Question) GetOperators() string {
+	if q.Operators == "" {
+		return defaultOperators
+	}
+	return q.Operators
+}
+
+// GetTolerance returns how far from the target an 'expression' answer may be and still score
+func (q *Question) GetTolerance() int {
+	if q.Tolerance < 1 {
+		return defaultTolerance
+	}
+	return q.Tolerance
+}
+
+// expressionParser is a recursive descent parser which evaluates an
+// arithmetic expression as it parses it. The grammar is
+//
+//	expr   = term { ("+" | "-") term }
+//	term   = factor { ("*" | "/") factor }
+//	factor = number | "(" expr ")"
+//
+// Every intermediate value must be a whole number, as in the countdown numbers game
+type expressionParser struct {
+	input     []rune
+	pos       int
+	operators string
+	available map[int]int // how many times each number may still be used
+}
+
+/**
+* Parses and evaluates the given arithmetic expression checking that it only
+* uses the given numbers, each no more times than it appears in the list,
+* and the given operators
+* @param expression the expression typed by the player eg. (25 + 50) * 3
+* @param numbers the numbers the player may use
+* @param operators the operators the player may use eg. "+-" for only adding and subtracting
+* @return the value of the expression or an error describing why it isn't allowed
+ */
+func evaluateExpression(expression string, numbers []int, operators string) (int, error) {
+	p := &expressionParser{
+		input:     []rune(expression),
+		operators: operators,
+		available: make(map[int]int),
+	}
+	for _, n := range numbers {
+		p.available[n]++
+	}
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return 0, fmt.Errorf("no expression given")
+	}
+	value, err := p.expr()
+	if err != nil {
+		return 0, err
+	}
+	if p.pos < len(p.input) {
+		return 0, fmt.Errorf("unexpected %q", string(p.input[p.pos]))
+	}
+	return value, nil
+}
+
+func (p *expressionParser) skipSpace() {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+// peek returns the next non space character or 0 at the end of the input
+func (p *expressionParser) peek() rune {
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return normaliseOperator(p.input[p.pos])
+}
+
+// normaliseOperator allows the multiplication and division signs people type
+func normaliseOperator(r rune) rune {
+	switch r {
+	case '×', 'x', 'X':
+		return '*'
+	case '÷':
+		return '/'
+	}
+	return r
+}
+
+// operator consumes and returns the next character if it is one of the given
+// operators, returning an error if that operator isn't allowed in this question
+func (p *expressionParser) operator(ops string) (rune, bool, error) {
+	r := p.peek()
+	if r == 0 || !strings.ContainsRune(ops, r) {
+		return 0, false, nil
+	}
+	if !strings.ContainsRune(p.operators, r) {
+		return 0, false, fmt.Errorf("the %c operator isn't allowed", r)
+	}
+	p.pos++
+	return r, true, nil
+}
+
+func (p *expressionParser) expr() (int, error) {
+	value, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op, ok, err := p.operator("+-")
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return value, nil
+		}
+		right, err := p.term()
+		if err != nil {
+			return 0, err
+		}
+		if op == '+' {
+			value += right
+		} else {
+			value -= right
+		}
+	}
+}
+
+func (p *expressionParser) term() (int, error) {
+	value, err := p.factor()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op, ok, err := p.operator("*/")
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return value, nil
+		}
+		right, err := p.factor()
+		if err != nil {
+			return 0, err
+		}
+		if op == '*' {
+			value *= right
+			continue
+		}
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		if value%right != 0 {
+			return 0, fmt.Errorf("%d / %d isn't a whole number", value, right)
+		}
+		value /= right
+	}
+}
+
+func (p *expressionParser) factor() (int, error) {
+	r := p.peek()
+	if r == '(' {
+		p.pos++
+		value, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ')' {
+			return 0, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return value, nil
+	}
+	if !unicode.IsDigit(r) {
+		if r == 0 {
+			return 0, fmt.Errorf("the expression is incomplete")
+		}
+		return 0, fmt.Errorf("unexpected %q", string(p.input[p.pos]))
+	}
+	start := p.pos
+	for p.pos < len(p.input) && unicode.IsDigit(p.input[p.pos]) {
+		p.pos++
+	}
+	n, err := strconv.Atoi(string(p.input[start:p.pos]))
+	if err != nil {
+		return 0, fmt.Errorf("%s is too big", string(p.input[start:p.pos]))
+	}
+	if p.available[n] < 1 {
+		return 0, fmt.Errorf("%d isn't available", n)
+	}
+	p.available[n]--
+	return n, nil
+}
+
+/**
+* Scores an 'expression' answer by evaluating it. Hitting the target is worth
+* all of PointsAvailable falling away linearly to nothing at Tolerance away.
+* Expressions which can't be evaluated or use numbers or operators that
+* aren't allowed score nothing and the reason is put in the comment
+* @param cq the question being answered
+* @param answer the answer to score
+ */
+func scoreExpression(cq *Question, answer *Answer) {
+	answer.Points = 0
+	if answer.Answer == "..." {
+		return
+	}
+	answer.Answer = strings.TrimSpace(answer.Answer)
+	value, err := evaluateExpression(answer.Answer, cq.Numbers, cq.GetOperators())
+	if err != nil {
+		answer.Comment = err.Error()
+		return
+	}
+	distance := int(math.Abs(float64(value - cq.Target)))
+	tolerance := cq.GetTolerance()
+	if distance < tolerance {
+		answer.Points = float32(cq.PointsAvailable) * float32(tolerance-distance) / float32(tolerance)
+	}
+	if distance == 0 {
+		answer.Comment = fmt.Sprintf("= %d", value)
+	} else {
+		answer.Comment = fmt.Sprintf("= %d (%d away)", value, distance)
+	}
+}
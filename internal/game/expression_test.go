@@ -0,0 +1,89 @@
+// internal/game/expression_test.go
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvaluateExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		numbers    []int
+		operators  string
+		want       int
+		wantErr    string // part of the error, "" if there shouldn't be one
+	}{
+		{"(25 + 50) * 3", []int{25, 50, 3}, "+-*/", 225, ""},
+		{"2 + 3 * 4", []int{2, 3, 4}, "+-*/", 14, ""},
+		{"(2 + 3) * 4", []int{2, 3, 4}, "+-*/", 20, ""},
+		{"10 - 4 - 3", []int{10, 4, 3}, "+-*/", 3, ""},
+		{"100 / 10 / 2", []int{100, 10, 2}, "+-*/", 5, ""},
+		{"3 × 4 ÷ 2", []int{3, 4, 2}, "+-*/", 6, ""},
+		{"3x4", []int{3, 4}, "+-*/", 12, ""},
+		{"  7  ", []int{7}, "+-*/", 7, ""},
+		{"100 + 100", []int{100, 100}, "+-*/", 200, ""},
+		{"100 + 100", []int{100}, "+-*/", 0, "100 isn't available"},
+		{"5 + 6", []int{5}, "+-*/", 0, "6 isn't available"},
+		{"7 / 2", []int{7, 2}, "+-*/", 0, "isn't a whole number"},
+		{"7 / (3 - 3)", []int{7, 3, 3}, "+-*/", 0, "division by zero"},
+		{"2 + 3", []int{2, 3}, "*", 0, "the + operator isn't allowed"},
+		{"2 * 3", []int{2, 3}, "+-", 0, "the * operator isn't allowed"},
+		{"", []int{1}, "+-*/", 0, "no expression given"},
+		{"   ", []int{1}, "+-*/", 0, "no expression given"},
+		{"(2 + 3", []int{2, 3}, "+-*/", 0, "missing )"},
+		{"2 +", []int{2}, "+-*/", 0, "incomplete"},
+		{"2 3", []int{2, 3}, "+-*/", 0, `unexpected "3"`},
+		{"2 ^ 3", []int{2, 3}, "+-*/", 0, `unexpected "^"`},
+		{"-2 + 5", []int{2, 5}, "+-*/", 0, `unexpected "-"`},
+		{"99999999999999999999", []int{1}, "+-*/", 0, "too big"},
+	}
+	for _, tt := range tests {
+		got, err := evaluateExpression(tt.expression, tt.numbers, tt.operators)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("evaluateExpression(%q) error %v", tt.expression, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("evaluateExpression(%q) error = %v, want %q", tt.expression, err, tt.wantErr)
+		case tt.wantErr == "" && got != tt.want:
+			t.Errorf("evaluateExpression(%q) = %d, want %d", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestScoreExpression(t *testing.T) {
+	q := &Question{Type: "expression", Numbers: []int{25, 50, 75, 100, 3, 6}, Target: 100, PointsAvailable: 10}
+	tests := []struct {
+		answer      string
+		want        float32
+		wantComment string
+	}{
+		{"100", 10, "= 100"},
+		{"75 + 25", 10, "= 100"},
+		{"100 - 3 - 6 + 3", 0, "3 isn't available"},
+		{"100 - 6 + 3 - 2", 0, "2 isn't available"},
+		{"100 - 6 + 3", 7, "= 97 (3 away)"},
+		{"75 + 25 + 6", 4, "= 106 (6 away)"},
+		{"100 + 6 + 3", 1, "= 109 (9 away)"},
+		{"100 + 6 * 3", 0, "= 118 (18 away)"},
+		{"100 / 3", 0, "isn't a whole number"},
+		{"...", 0, ""},
+	}
+	for _, tt := range tests {
+		a := Answer{Answer: tt.answer, Points: 99}
+		scoreExpression(q, &a)
+		if a.Points != tt.want {
+			t.Errorf("%q scored %v, want %v", tt.answer, a.Points, tt.want)
+		}
+		if !strings.Contains(a.Comment, tt.wantComment) {
+			t.Errorf("%q has comment %q, want %q", tt.answer, a.Comment, tt.wantComment)
+		}
+	}
+	// the tolerance can be set by the question
+	q.Tolerance = 20
+	a := Answer{Answer: "100 + 6 * 3"}
+	scoreExpression(q, &a)
+	if a.Points != 1 {
+		t.Errorf("18 away with a tolerance of 20 scored %v, want 1", a.Points)
+	}
+}
@@ -0,0 +1,307 @@
+// internal/game/flagdata.go
+package game
+
+// flagCountry is what the flag generator knows about a flag in static/images/flags
+type flagCountry struct {
+	Name      string // the name shown as a choice
+	Region    string // distractors are picked from the same region
+	Obscurity int    // 1 for flags most people know, 2 for the middle and 3 for the obscure
+}
+
+// the flags the generator may ask about keyed by file name without .svg,
+// mostly ISO 3166 codes plus a few regions and organisations. Every flag in
+// static/images/flags is either here or in flagsLeftOut
+var flagCountries = map[string]flagCountry{
+	// Europe
+	"ad":     {"Andorra", "Europe", 3},
+	"al":     {"Albania", "Europe", 2},
+	"at":     {"Austria", "Europe", 2},
+	"ax":     {"Åland Islands", "Europe", 3},
+	"ba":     {"Bosnia and Herzegovina", "Europe", 2},
+	"be":     {"Belgium", "Europe", 1},
+	"bg":     {"Bulgaria", "Europe", 2},
+	"by":     {"Belarus", "Europe", 2},
+	"ch":     {"Switzerland", "Europe", 1},
+	"cy":     {"Cyprus", "Europe", 2},
+	"cz":     {"Czechia", "Europe", 2},
+	"de":     {"Germany", "Europe", 1},
+	"dk":     {"Denmark", "Europe", 1},
+	"ee":     {"Estonia", "Europe", 2},
+	"es":     {"Spain", "Europe", 1},
+	"es-ct":  {"Catalonia", "Europe", 2},
+	"es-ga":  {"Galicia", "Europe", 3},
+	"es-pv":  {"Basque Country", "Europe", 3},
+	"esg":    {"East Germany", "Europe", 3},
+	"eu":     {"European Union", "Organisations", 1},
+	"fi":     {"Finland", "Europe", 1},
+	"fo":     {"Faroe Islands", "Europe", 3},
+	"fr":     {"France", "Europe", 1},
+	"gb":     {"United Kingdom", "Europe", 1},
+	"gb-eng": {"England", "Europe", 1},
+	"gb-nir": {"Northern Ireland", "Europe", 2},
+	"gb-sct": {"Scotland", "Europe", 1},
+	"sco":    {"Scotland", "Europe", 1},
+	"spc":    {"St Patrick's Saltire", "Europe", 3},
+	"gb-wls": {"Wales", "Europe", 1},
+	"ker":    {"Cornwall", "Europe", 2},
+	"gg":     {"Guernsey", "Europe", 3},
+	"gi":     {"Gibraltar", "Europe", 2},
+	"gr":     {"Greece", "Europe", 1},
+	"hr":     {"Croatia", "Europe", 2},
+	"hu":     {"Hungary", "Europe", 2},
+	"ic":     {"Canary Islands", "Europe", 3},
+	"ie":     {"Ireland", "Europe", 1},
+	"im":     {"Isle of Man", "Europe", 2},
+	"is":     {"Iceland", "Europe", 2},
+	"it":     {"Italy", "Europe", 1},
+	"je":     {"Jersey", "Europe", 3},
+	"li":     {"Liechtenstein", "Europe", 3},
+	"lt":     {"Lithuania", "Europe", 2},
+	"lu":     {"Luxembourg", "Europe", 2},
+	"lv":     {"Latvia", "Europe", 2},
+	"mc":     {"Monaco", "Europe", 2},
+	"md":     {"Moldova", "Europe", 3},
+	"me":     {"Montenegro", "Europe", 3},
+	"mk":     {"North Macedonia", "Europe", 3},
+	"mt":     {"Malta", "Europe", 2},
+	"nl":     {"Netherlands", "Europe", 1},
+	"no":     {"Norway", "Europe", 1},
+	"pl":     {"Poland", "Europe", 1},
+	"pt":     {"Portugal", "Europe", 1},
+	"ro":     {"Romania", "Europe", 2},
+	"rs":     {"Serbia", "Europe", 2},
+	"ru":     {"Russia", "Europe", 1},
+	"se":     {"Sweden", "Europe", 1},
+	"si":     {"Slovenia", "Europe", 3},
+	"sj":     {"Svalbard and Jan Mayen", "Europe", 3},
+	"sk":     {"Slovakia", "Europe", 3},
+	"sm":     {"San Marino", "Europe", 3},
+	"ua":     {"Ukraine", "Europe", 1},
+	"va":     {"Vatican City", "Europe", 2},
+	"xk":     {"Kosovo", "Europe", 3},
+	// Middle East
+	"ae": {"United Arab Emirates", "Middle East", 2},
+	"bh": {"Bahrain", "Middle East", 3},
+	"il": {"Israel", "Middle East", 1},
+	"iq": {"Iraq", "Middle East", 2},
+	"ir": {"Iran", "Middle East", 2},
+	"jo": {"Jordan", "Middle East", 2},
+	"kw": {"Kuwait", "Middle East", 3},
+	"lb": {"Lebanon", "Middle East", 2},
+	"om": {"Oman", "Middle East", 3},
+	"ps": {"Palestine", "Middle East", 2},
+	"qa": {"Qatar", "Middle East", 2},
+	"sa": {"Saudi Arabia", "Middle East", 1},
+	"sy": {"Syria", "Middle East", 2},
+	"tr": {"Turkey", "Middle East", 1},
+	"ye": {"Yemen", "Middle East", 3},
+	// Asia
+	"af": {"Afghanistan", "Asia", 2},
+	"am": {"Armenia", "Asia", 3},
+	"az": {"Azerbaijan", "Asia", 3},
+	"bd": {"Bangladesh", "Asia", 2},
+	"bn": {"Brunei", "Asia", 3},
+	"bt": {"Bhutan", "Asia", 2},
+	"cn": {"China", "Asia", 1},
+	"ge": {"Georgia", "Asia", 2},
+	"hk": {"Hong Kong", "Asia", 2},
+	"id": {"Indonesia", "Asia", 2},
+	"in": {"India", "Asia", 1},
+	"io": {"British Indian Ocean Territory", "Asia", 3},
+	"jp": {"Japan", "Asia", 1},
+	"kg": {"Kyrgyzstan", "Asia", 3},
+	"kh": {"Cambodia", "Asia", 2},
+	"kp": {"North Korea", "Asia", 2},
+	"kr": {"South Korea", "Asia", 1},
+	"kz": {"Kazakhstan", "Asia", 2},
+	"la": {"Laos", "Asia", 3},
+	"lk": {"Sri Lanka", "Asia", 2},
+	"mm": {"Myanmar", "Asia", 3},
+	"mn": {"Mongolia", "Asia", 3},
+	"mo": {"Macau", "Asia", 3},
+	"mv": {"Maldives", "Asia", 3},
+	"my": {"Malaysia", "Asia", 2},
+	"np": {"Nepal", "Asia", 1},
+	"ph": {"Philippines", "Asia", 2},
+	"pk": {"Pakistan", "Asia", 1},
+	"sg": {"Singapore", "Asia", 2},
+	"th": {"Thailand", "Asia", 2},
+	"tj": {"Tajikistan", "Asia", 3},
+	"tl": {"Timor-Leste", "Asia", 3},
+	"tm": {"Turkmenistan", "Asia", 3},
+	"tw": {"Taiwan", "Asia", 2},
+	"uz": {"Uzbekistan", "Asia", 3},
+	"vn": {"Vietnam", "Asia", 2},
+	// Africa
+	"ao":    {"Angola", "Africa", 3},
+	"bf":    {"Burkina Faso", "Africa", 3},
+	"bi":    {"Burundi", "Africa", 3},
+	"bj":    {"Benin", "Africa", 3},
+	"bw":    {"Botswana", "Africa", 3},
+	"cd":    {"DR Congo", "Africa", 3},
+	"cf":    {"Central African Republic", "Africa", 3},
+	"cg":    {"Republic of the Congo", "Africa", 3},
+	"ci":    {"Ivory Coast", "Africa", 3},
+	"cm":    {"Cameroon", "Africa", 3},
+	"cv":    {"Cape Verde", "Africa", 3},
+	"dj":    {"Djibouti", "Africa", 3},
+	"dz":    {"Algeria", "Africa", 2},
+	"eg":    {"Egypt", "Africa", 2},
+	"eh":    {"Western Sahara", "Africa", 3},
+	"er":    {"Eritrea", "Africa", 3},
+	"et":    {"Ethiopia", "Africa", 2},
+	"ga":    {"Gabon", "Africa", 3},
+	"gh":    {"Ghana", "Africa", 2},
+	"gm":    {"Gambia", "Africa", 3},
+	"gn":    {"Guinea", "Africa", 3},
+	"gq":    {"Equatorial Guinea", "Africa", 3},
+	"gw":    {"Guinea-Bissau", "Africa", 3},
+	"ke":    {"Kenya", "Africa", 2},
+	"km":    {"Comoros", "Africa", 3},
+	"lr":    {"Liberia", "Africa", 3},
+	"ls":    {"Lesotho", "Africa", 3},
+	"ly":    {"Libya", "Africa", 3},
+	"ma":    {"Morocco", "Africa", 2},
+	"mg":    {"Madagascar", "Africa", 3},
+	"ml":    {"Mali", "Africa", 3},
+	"mr":    {"Mauritania", "Africa", 3},
+	"mu":    {"Mauritius", "Africa", 3},
+	"mw":    {"Malawi", "Africa", 3},
+	"mz":    {"Mozambique", "Africa", 3},
+	"na":    {"Namibia", "Africa", 3},
+	"ne":    {"Niger", "Africa", 3},
+	"ng":    {"Nigeria", "Africa", 2},
+	"rw":    {"Rwanda", "Africa", 3},
+	"sc":    {"Seychelles", "Africa", 3},
+	"sd":    {"Sudan", "Africa", 3},
+	"sh-ac": {"Ascension Island", "Africa", 3},
+	"sh-hl": {"Saint Helena", "Africa", 3},
+	"sh-ta": {"Tristan da Cunha", "Africa", 3},
+	"sl":    {"Sierra Leone", "Africa", 3},
+	"sn":    {"Senegal", "Africa", 3},
+	"so":    {"Somalia", "Africa", 3},
+	"ss":    {"South Sudan", "Africa", 3},
+	"st":    {"São Tomé and Príncipe", "Africa", 3},
+	"sz":    {"Eswatini", "Africa", 3},
+	"td":    {"Chad", "Africa", 3},
+	"tg":    {"Togo", "Africa", 3},
+	"tn":    {"Tunisia", "Africa", 2},
+	"tz":    {"Tanzania", "Africa", 3},
+	"ug":    {"Uganda", "Africa", 3},
+	"za":    {"South Africa", "Africa", 1},
+	"zm":    {"Zambia", "Africa", 3},
+	"zw":    {"Zimbabwe", "Africa", 2},
+	// North and Central America
+	"bz": {"Belize", "North America", 3},
+	"ca": {"Canada", "North America", 1},
+	"cr": {"Costa Rica", "North America", 2},
+	"gl": {"Greenland", "North America", 3},
+	"gt": {"Guatemala", "North America", 3},
+	"hn": {"Honduras", "North America", 3},
+	"mx": {"Mexico", "North America", 1},
+	"ni": {"Nicaragua", "North America", 3},
+	"pa": {"Panama", "North America", 2},
+	"sv": {"El Salvador", "North America", 3},
+	"us": {"United States", "North America", 1},
+	// Caribbean
+	"ag": {"Antigua and Barbuda", "Caribbean", 3},
+	"ai": {"Anguilla", "Caribbean", 3},
+	"aw": {"Aruba", "Caribbean", 3},
+	"bb": {"Barbados", "Caribbean", 2},
+	"bm": {"Bermuda", "Caribbean", 3},
+	"bq": {"Caribbean Netherlands", "Caribbean", 3},
+	"bs": {"Bahamas", "Caribbean", 2},
+	"cu": {"Cuba", "Caribbean", 2},
+	"cw": {"Curaçao", "Caribbean", 3},
+	"dm": {"Dominica", "Caribbean", 3},
+	"do": {"Dominican Republic", "Caribbean", 3},
+	"gd": {"Grenada", "Caribbean", 3},
+	"ht": {"Haiti", "Caribbean", 3},
+	"jm": {"Jamaica", "Caribbean", 1},
+	"kn": {"Saint Kitts and Nevis", "Caribbean", 3},
+	"ky": {"Cayman Islands", "Caribbean", 3},
+	"lc": {"Saint Lucia", "Caribbean", 3},
+	"mq": {"Martinique", "Caribbean", 3},
+	"ms": {"Montserrat", "Caribbean", 3},
+	"pr": {"Puerto Rico", "Caribbean", 2},
+	"sx": {"Sint Maarten", "Caribbean", 3},
+	"tc": {"Turks and Caicos Islands", "Caribbean", 3},
+	"tt": {"Trinidad and Tobago", "Caribbean", 2},
+	"vc": {"Saint Vincent and the Grenadines", "Caribbean", 3},
+	"vg": {"British Virgin Islands", "Caribbean", 3},
+	"vi": {"US Virgin Islands", "Caribbean", 3},
+	// South America
+	"ar": {"Argentina", "South America", 1},
+	"bo": {"Bolivia", "South America", 2},
+	"br": {"Brazil", "South America", 1},
+	"cl": {"Chile", "South America", 2},
+	"co": {"Colombia", "South America", 2},
+	"ec": {"Ecuador", "South America", 2},
+	"fk": {"Falkland Islands", "South America", 3},
+	"gs": {"South Georgia and the South Sandwich Islands", "South America", 3},
+	"gy": {"Guyana", "South America", 3},
+	"pe": {"Peru", "South America", 2},
+	"py": {"Paraguay", "South America", 3},
+	"sr": {"Suriname", "South America", 3},
+	"uy": {"Uruguay", "South America", 2},
+	"ve": {"Venezuela", "South America", 2},
+	// Oceania
+	"as": {"American Samoa", "Oceania", 3},
+	"au": {"Australia", "Oceania", 1},
+	"cc": {"Cocos (Keeling) Islands", "Oceania", 3},
+	"ck": {"Cook Islands", "Oceania", 3},
+	"cx": {"Christmas Island", "Oceania", 3},
+	"fj": {"Fiji", "Oceania", 2},
+	"fm": {"Micronesia", "Oceania", 3},
+	"gu": {"Guam", "Oceania", 3},
+	"ki": {"Kiribati", "Oceania", 3},
+	"mh": {"Marshall Islands", "Oceania", 3},
+	"mp": {"Northern Mariana Islands", "Oceania", 3},
+	"nc": {"New Caledonia", "Oceania", 3},
+	"nf": {"Norfolk Island", "Oceania", 3},
+	"nr": {"Nauru", "Oceania", 3},
+	"nu": {"Niue", "Oceania", 3},
+	"nz": {"New Zealand", "Oceania", 1},
+	"pf": {"French Polynesia", "Oceania", 3},
+	"pg": {"Papua New Guinea", "Oceania", 2},
+	"pn": {"Pitcairn Islands", "Oceania", 3},
+	"pw": {"Palau", "Oceania", 3},
+	"sb": {"Solomon Islands", "Oceania", 3},
+	"tk": {"Tokelau", "Oceania", 3},
+	"to": {"Tonga", "Oceania", 3},
+	"tv": {"Tuvalu", "Oceania", 3},
+	"vu": {"Vanuatu", "Oceania", 3},
+	"ws": {"Samoa", "Oceania", 3},
+	// organisations
+	"arab":  {"Arab League", "Organisations", 3},
+	"asean": {"ASEAN", "Organisations", 3},
+	"cefta": {"CEFTA", "Organisations", 3},
+	"eac":   {"East African Community", "Organisations", 3},
+	"pc":    {"Pacific Community", "Organisations", 3},
+	"un":    {"United Nations", "Organisations", 1},
+	"aq":    {"Antarctica", "Organisations", 3},
+	"tf":    {"French Southern and Antarctic Lands", "Organisations", 3},
+}
+
+// the flags in static/images/flags that are never asked about or used as a
+// wrong choice, and why. Most are territories which fly the flag of another
+// country so a question about them couldn't be answered
+var flagsLeftOut = map[string]string{
+	"bl":            "Saint Barthélemy flies the French flag",
+	"bv":            "Bouvet Island flies the Norwegian flag",
+	"cp":            "Clipperton Island flies the French flag",
+	"dg":            "Diego Garcia flies the flag of the British Indian Ocean Territory",
+	"gf":            "French Guiana flies the French flag",
+	"gp":            "Guadeloupe flies the French flag",
+	"hm":            "Heard Island and McDonald Islands fly the Australian flag",
+	"ko":            "a copy of ker, the flag of Cornwall",
+	"lols_nice_try": "a copy of the flag of Kiribati whose name doesn't give it away",
+	"mf":            "Saint Martin flies the French flag",
+	"pm":            "Saint Pierre and Miquelon flies the French flag",
+	"re":            "Réunion flies the French flag",
+	"sh":            "the Union Jack, Saint Helena's own flag is sh-hl",
+	"um":            "the US Minor Outlying Islands fly the flag of the United States",
+	"wf":            "Wallis and Futuna flies the French flag",
+	"xx":            "a blank flag for an unknown country",
+	"yt":            "Mayotte flies the French flag",
+}
@@ -0,0 +1,164 @@
+// internal/game/flags.go
+package game
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// where the flag images live and the url they are served from. Flags are
+// served by an opaque id as the file names are the answers
+var (
+	flagsDir = "static/images/flags"
+	flagsUrl = "/api/flag?id="
+)
+
+// the key flag ids are made with, new each time the server starts so that
+// the id of a flag can't be worked out from its code
+var flagKey = []byte(newTileId() + newTileId())
+
+// how many choices a generated flag question has, including the right one
+var flagChoices = 4
+
+// roughly what percentage of people get a flag right for each obscurity
+var flagPercent = map[int]int{1: 90, 2: 60, 3: 30}
+
+// flagExists reports whether there is an image for the given flag code
+func flagExists(code string) bool {
+	_, err := os.Stat(filepath.Join(flagsDir, code+".svg"))
+	return err == nil
+}
+
+/**
+* Creates multichoice questions each showing a different flag from the flags
+* directory and asking whose it is
+* @param count how many questions to create
+* @param difficulty 1 for well known flags, 2 for middling and 3 for obscure ones, 0 for any
+* @param rng the source of randomness
+* @return the questions or an error if there aren't enough flags to ask about
+ */
+func GenerateFlagQuestions(count int, difficulty int, rng *rand.Rand) ([]Question, error) {
+	if difficulty < 0 || difficulty > 3 {
+		return nil, fmt.Errorf("difficulty must be 1, 2 or 3 (or 0 for any)")
+	}
+	// sorted so that the same seed always gives the same questions
+	var codes []string
+	for code, f := range flagCountries {
+		if (difficulty == 0 || f.Obscurity == difficulty) && f.Region != "Organisations" && flagExists(code) {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) < count {
+		return nil, fmt.Errorf("there are only %d flags in %s of difficulty %d", len(codes), flagsDir, difficulty)
+	}
+	sort.Strings(codes)
+	rng.Shuffle(len(codes), func(i, j int) { codes[i], codes[j] = codes[j], codes[i] })
+	// some places have more than one flag, eg. Scotland, but are only asked about once
+	var questions []Question
+	asked := make(map[string]bool)
+	for _, code := range codes {
+		if len(questions) == count {
+			break
+		}
+		if name := flagCountries[code].Name; !asked[name] {
+			asked[name] = true
+			questions = append(questions, flagQuestion(code, rng))
+		}
+	}
+	if len(questions) < count {
+		return nil, fmt.Errorf("there are only %d flags in %s of difficulty %d", len(questions), flagsDir, difficulty)
+	}
+	return questions, nil
+}
+
+// flagId returns the opaque id the flag with the given code is served by
+func flagId(code string) string {
+	mac := hmac.New(sha256.New, flagKey)
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+/**
+* Reads the image of the flag with the given id. Many of the images name their
+* flag in the ids of their elements, eg. flag-icons-gb and gb-a, so these are
+* renamed
+* @param id the opaque id of the flag, as in the image url of a generated question
+* @return the svg of the flag or an error if there is no such flag
+ */
+func FlagImage(id string) ([]byte, error) {
+	for code := range flagCountries {
+		if flagId(code) != id {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(flagsDir, code+".svg"))
+		if err != nil {
+			return nil, err
+		}
+		ids := strings.NewReplacer("flag-icons-"+code, "flag", `"`+code+"-", `"flag-`, "#"+code+"-", "#flag-")
+		return []byte(ids.Replace(string(data))), nil
+	}
+	return nil, fmt.Errorf("there is no flag %s", id)
+}
+
+// flagQuestion creates the question for the given flag. The wrong choices
+// are taken from the same region, and of similar obscurity where possible,
+// so they are plausible
+func flagQuestion(code string, rng *rand.Rand) Question {
+	flag := flagCountries[code]
+
+	// prefer the same region and similar obscurity, then the same region,
+	// then anything at all
+	var near, region, rest []string
+	for c, f := range flagCountries {
+		if c == code || f.Name == flag.Name {
+			continue
+		}
+		d := f.Obscurity - flag.Obscurity
+		switch {
+		case f.Region == flag.Region && d >= -1 && d <= 1:
+			near = append(near, c)
+		case f.Region == flag.Region:
+			region = append(region, c)
+		default:
+			rest = append(rest, c)
+		}
+	}
+	names := []string{flag.Name}
+	for _, pool := range [][]string{near, region, rest} {
+		sort.Strings(pool)
+		rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+		for _, c := range pool {
+			if len(names) < flagChoices {
+				names = append(names, flagCountries[c].Name)
+			}
+		}
+	}
+	rng.Shuffle(len(names), func(i, j int) { names[i], names[j] = names[j], names[i] })
+
+	q := Question{
+		Question:        "Which country's flag is this?",
+		Type:            "multichoice",
+		Category:        "countries and flags",
+		Percent:         flagPercent[flag.Obscurity],
+		ImageUrl:        flagsUrl + flagId(code),
+		HostAnswer:      fmt.Sprintf("That's the flag of %s", flag.Name),
+		TimeLimit:       30,
+		PointsAvailable: 2,
+		ReadTime:        5,
+	}
+	for i, name := range names {
+		letter := string(rune('A' + i))
+		q.Choices = append(q.Choices, Choice{Choice: name, Answer: letter})
+		if name == flag.Name {
+			q.CorrectAnswers = []string{letter}
+		}
+	}
+	return q
+}
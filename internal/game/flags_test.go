@@ -0,0 +1,121 @@
+// internal/game/flags_test.go
+package game
+
+import (
+	"math/rand"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// every flag image is either asked about or left out for a reason
+func TestFlagTablesCoverFlags(t *testing.T) {
+	t.Chdir("../..")
+	entries, err := os.ReadDir(flagsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]bool)
+	for _, e := range entries {
+		if code, ok := strings.CutSuffix(e.Name(), ".svg"); ok {
+			files[code] = true
+		}
+	}
+	for code := range files {
+		_, asked := flagCountries[code]
+		_, left := flagsLeftOut[code]
+		if asked == left {
+			t.Errorf("%s.svg should be in exactly one of flagCountries and flagsLeftOut", code)
+		}
+	}
+	for code := range flagCountries {
+		if !files[code] {
+			t.Errorf("flagCountries has %s but there is no %s.svg", code, code)
+		}
+	}
+	for code := range flagsLeftOut {
+		if !files[code] {
+			t.Errorf("flagsLeftOut has %s but there is no %s.svg", code, code)
+		}
+	}
+}
+
+// the image served for a generated flag doesn't name the flag
+func TestFlagImagesDontNameTheFlag(t *testing.T) {
+	t.Chdir("../..")
+	refs := regexp.MustCompile(`(?:\bid="|#)([^"\s)]+)`)
+	for code := range flagCountries {
+		svg, err := FlagImage(flagId(code))
+		if err != nil {
+			t.Errorf("%s: %v", code, err)
+			continue
+		}
+		for _, m := range refs.FindAllStringSubmatch(string(svg), -1) {
+			if strings.Contains("-"+m[1]+"-", "-"+code+"-") {
+				t.Errorf("the image of %s has the id %q", code, m[1])
+				break
+			}
+		}
+	}
+	if _, err := FlagImage("0000000000000000"); err == nil {
+		t.Error("an unknown id found a flag")
+	}
+}
+
+func TestGenerateFlagQuestions(t *testing.T) {
+	t.Chdir("../..")
+	ids := make(map[string]string)
+	for code := range flagCountries {
+		ids[flagsUrl+flagId(code)] = code
+	}
+	tests := []struct {
+		count      int
+		difficulty int
+		wantErr    bool
+	}{
+		{10, 0, false},
+		{5, 1, false},
+		{20, 3, false},
+		{1000, 1, true},
+		{1, 4, true},
+	}
+	for _, tt := range tests {
+		questions, err := GenerateFlagQuestions(tt.count, tt.difficulty, rand.New(rand.NewSource(1)))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("GenerateFlagQuestions(%d, %d) error = %v, want error %v", tt.count, tt.difficulty, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(questions) != tt.count {
+			t.Errorf("GenerateFlagQuestions(%d, %d) made %d questions", tt.count, tt.difficulty, len(questions))
+		}
+		asked := make(map[string]bool)
+		for _, q := range questions {
+			code, exists := ids[q.ImageUrl]
+			if !exists {
+				t.Errorf("%s isn't the url of a flag", q.ImageUrl)
+				continue
+			}
+			flag := flagCountries[code]
+			if tt.difficulty != 0 && flag.Obscurity != tt.difficulty {
+				t.Errorf("%s has obscurity %d, wanted %d", code, flag.Obscurity, tt.difficulty)
+			}
+			if asked[flag.Name] {
+				t.Errorf("%s was asked about twice", flag.Name)
+			}
+			asked[flag.Name] = true
+			if len(q.Choices) != flagChoices || len(q.CorrectAnswers) != 1 {
+				t.Errorf("%s has %d choices and %d correct answers", code, len(q.Choices), len(q.CorrectAnswers))
+				continue
+			}
+			for _, c := range q.Choices {
+				if (c.Answer == q.CorrectAnswers[0]) != (c.Choice == flag.Name) {
+					t.Errorf("%s: choice %s %q is marked wrongly", code, c.Answer, c.Choice)
+				}
+			}
+		}
+	}
+}
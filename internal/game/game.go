@@ -2,7 +2,6 @@
 package game
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
@@ -29,17 +28,22 @@ type GameState struct {
 	IsShowAnswer    bool               `json:"isShowAnswer,omitempty"` // True if the current question element should be showing the answer
 	IsQuestionEnded bool               `json:"isQuestionEnded"`        // True when question has ended (for kiosk mode)
 	IsUserReading   bool               `json:"isUserReading"`          // True when users are reading the question (for kiosk mode)
+	ServerTime      int64              `json:"serverTime"`             // the server time (unix ms) when this state was sent, lets screens sync media playback
+	Packs           []string           `json:"packs,omitempty"`        // the question packs being played, none if playing questions.json
+	Composition     *Composition       `json:"composition,omitempty"`  // how the questions were picked from the packs, nil if they are played in order
 }
 
 type Player struct {
-	Username    string  `json:"username"`
-	Score       float32 `json:"score"`
-	Percent     int     `json:"percent"`
-	IsAdmin     bool    `json:"isAdmin"`
-	IsSpectator bool    `json:"isSpectator"`
-	IpAddress   string  `json:"ipaddress"`
-	Message     string  `json:"message"`
-	MessageTime int     `json:"messageTime"`
+	Username       string  `json:"username"`
+	Score          float32 `json:"score"`
+	Percent        int     `json:"percent"`
+	IsAdmin        bool    `json:"isAdmin"`
+	IsSpectator    bool    `json:"isSpectator"`
+	IpAddress      string  `json:"ipaddress"`
+	Message        string  `json:"message"`
+	MessageTime    int     `json:"messageTime"`
+	RangesAnswered int     `json:"rangesAnswered"` // how many finished range questions the player gave a range for
+	RangesHit      int     `json:"rangesHit"`      // how many of those ranges held the true value
 }
 
 type Choice struct {
@@ -52,6 +56,8 @@ type Question struct {
 	Answers            []Answer  `json:"answers"`                      // as users answer, they'll be added to this list
 	Question           string    `json:"question"`                     // the actual question text to show the users
 	QuestionNumber     int       `json:"questionNumber"`               // the question number, this should be worked out dynamically
+	Id                 string    `json:"id,omitempty"`                 // an id which stays the same from game to game, made from the question if not given
+	SeenBy             int       `json:"seenBy,omitempty"`             // how many of the current players have been shown the question in an earlier game
 	Percent            int       `json:"percent"`                      // the difficulty of the question 100% being very easy and 1% being very difficult
 	Category           string    `json:"category"`                     // the category of the question, numbers, cars, actors etc.
 	ImageUrl           string    `json:"imageUrl,omitempty"`           // if there's an image this should be the local path or remote url
@@ -59,6 +65,8 @@ type Question struct {
 	Type               string    `json:"type"`                         // "multiple_choice" or "text"
 	Choices            []Choice  `json:"choices,omitempty"`            // if this is multichoice, then these are the choices
 	Grid               []int     `json:"grid,omitempty"`               // For grid image rounds, how to divide up the image
+	Tiles              []string  `json:"tiles,omitempty"`              // For grid image rounds sliced by the server, the opaque id of the tile in each cell
+	TileSize           []int     `json:"tileSize,omitempty"`           // For grid image rounds sliced by the server, the [width, height] of each tile
 	CorrectAnswers     []string  `json:"correctAnswers"`               // An array of answers any one of which can be correct
 	PenalisationFactor float32   `json:"penalisationFactor,omitempty"` // for geoguessing, how harsh to be. The higher the number the harsher
 	HostAnswer         string    `json:"hostAnswer,omitempty"`         // Only included for admin
@@ -67,21 +75,68 @@ type Question struct {
 	TimeLimit          int       `json:"timeLimit,omitempty"`          // how long do the users have to answer?
 	TimeLeft           int       `json:"timeLeft"`                     // how long has the user left to answer this question
 	TimeStarted        time.Time `json:"timeStarted"`                  // when did this question start
-	//IsPaused           bool      `json:"isPaused,omitempty"`       // New field to track pause state
-	//PausedAt           time.Time `json:"pausedAt,omitempty"`       // When the question was paused
-	//TimeElapsed        float64   `json:"timeElapsed,omitempty"`    // Total time elapsed before pausing
 	IsTimedOut  bool   `json:"isTimedOut"`            // has the question been run and finished?
 	ClickImage  string `json:"clickImage,omitempty"`  // if this is a click question then the local path to the image we're clicking on
 	AnswerImage string `json:"answerImage,omitempty"` // For kazakhstan style games, this image is shown to demonstrate the actual answer to the players
 	StreetView  string `json:"streetView,omitempty"`  // if this is a geoguesser then the specific info required for streetview
+	Panorama    string `json:"panorama,omitempty"`    // if this is a geoguesser, a local equirectangular panorama image shown instead of streetview
+	// truefalse
+	Statements         []Statement                    `json:"statements,omitempty"`         // for rapid fire true or false rounds, the statements answered in sequence
+	StreakBonus        float32                        `json:"streakBonus,omitempty"`        // extra points for each correct statement that extends a run of correct statements
+	StatementResponses map[string][]StatementResponse `json:"statementResponses,omitempty"` // each players responses to the statements so far, keyed by username
+	// reveal
+	RevealStages int    `json:"revealStages,omitempty"` // for image reveal rounds, how many stages the image goes through before it is fully revealed
+	RevealMode   string `json:"revealMode,omitempty"`   // "pixelate" (the default) or "crop"
+	RevealStage  int    `json:"revealStage,omitempty"`  // the stage the image is currently at, worked out from the time remaining
+	// audio and video clips
+	AudioUrl      string  `json:"audioUrl,omitempty"`      // an audio clip played on the observer screens, local clips live in static/media and are served at /media/...
+	VideoUrl      string  `json:"videoUrl,omitempty"`      // a video clip played on the observer screens
+	MediaStart    float64 `json:"mediaStart,omitempty"`    // the offset in seconds into the clip at which playback starts
+	MediaEnd      float64 `json:"mediaEnd,omitempty"`      // the offset in seconds into the clip at which playback stops, zero plays to the end
+	MediaStartsAt int64   `json:"mediaStartsAt,omitempty"` // the server time (unix ms) at which playback should be at MediaStart, zero if not playing
+	// wordle
+	MaxGuesses int                      `json:"maxGuesses,omitempty"` // for wordle rounds, how many guesses each player gets
+	Dictionary string                   `json:"dictionary,omitempty"` // for wordle rounds, the path of a one word per line file of acceptable guesses
+	WordLength int                      `json:"wordLength,omitempty"` // for wordle rounds, the length of the target word
+	Guesses    map[string][]WordleGuess `json:"guesses,omitempty"`    // each players guesses so far, keyed by username
+	// crossword
+	CrosswordGrid []string        `json:"crosswordGrid,omitempty"` // for crossword rounds, one string per row with # for unused cells and any other character for cells to fill
+	Clues         []CrosswordClue `json:"clues,omitempty"`         // for crossword rounds, the numbered clues and their answers
+	// expression
+	Numbers   []int  `json:"numbers,omitempty"`   // for countdown style rounds, the numbers the players may use, each once
+	Target    int    `json:"target,omitempty"`    // for countdown style rounds, the number the players are trying to make
+	Operators string `json:"operators,omitempty"` // for countdown style rounds, the operators the players may use, defaults to +-*/
+	Tolerance int    `json:"tolerance,omitempty"` // for countdown style rounds, how far from the target an answer can be and still score
+	// matching
+	Matches []Choice `json:"matches,omitempty"` // for matching rounds, the right hand list, the Choices are the left hand list and each Choice.Answer names its match
+	// sketch
+	Featured string `json:"featured,omitempty"` // for sketch rounds, the player whose drawing the host has chosen to show on the observer screens
+	// hit regions
+	Regions     []HitRegion `json:"regions,omitempty"`     // for kazakhstan style rounds, the areas of the click image which are correct, one per target
+	RegionDecay float64     `json:"regionDecay,omitempty"` // for kazakhstan style rounds, how quickly (in pixels) credit falls away outside a region, zero for none
+	Targets     int         `json:"targets,omitempty"`     // for kazakhstan style rounds, how many targets the players must click, worked out from the regions
+	// geolocation
+	Location   []float64 `json:"location,omitempty"`   // for geolocation rounds, the true [latitude, longitude], taken from the streetview string if not given
+	Projection string    `json:"projection,omitempty"` // for geolocation rounds, the projection of the click image if the server is scoring by real distance
 }
 
 type Answer struct {
-	QuestionNumber int     `json:"questionNumber"`
-	Username       string  `json:"username"`
-	Answer         string  `json:"answer"`
-	Comment        string  `json:"comment"`
-	Points         float32 `json:"points"`
+	QuestionNumber int                 `json:"questionNumber"`
+	Username       string              `json:"username"`
+	Answer         string              `json:"answer"`
+	Comment        string              `json:"comment"`
+	Points         float32             `json:"points"`
+	Responses      []StatementResponse `json:"responses,omitempty"` // for truefalse questions, the individual statement responses
+	Cells          []string            `json:"cells,omitempty"`     // for crossword questions, the rows of letters the player filled in
+	Words          []string            `json:"words,omitempty"`     // for crossword questions, the clues the player answered correctly eg. 1A, 3D
+	Links          []int               `json:"links,omitempty"`     // for matching questions, the index of the match linked to each choice or -1
+	Lower          *float64            `json:"lower,omitempty"`     // for range questions, the lower bound given by the player
+	Upper          *float64            `json:"upper,omitempty"`     // for range questions, the upper bound given by the player
+	InRange        bool                `json:"inRange,omitempty"`   // for range questions, true if the true value was inside the bounds
+	Strokes        [][]int             `json:"strokes,omitempty"`   // for sketch questions, the drawing as a list of strokes each of which is a flat list of x,y points
+	Clicks         [][]float64         `json:"clicks,omitempty"`    // for click questions scored by the server, the [x,y] points the player clicked
+	Location       []float64           `json:"location,omitempty"`  // for geolocation questions scored by the server, the [latitude, longitude] clicked
+	Country        string              `json:"country,omitempty"`   // for country questions, the id of the shape the player clicked in
 }
 
 var (
@@ -96,20 +151,20 @@ func GetGame() *GameState {
 		logger.Info("Creating Gamestate Singleton")
 		instance = NewGameState()
 		// load in the questions
-		file, err := os.ReadFile("./questions.json")
-		if err != nil {
-			logger.Error("Failed to read questions file")
-			os.Exit(1)
+		// the questions must pass the same checks as when they're reloaded
+		questions, problems, err := loadQuestionsFile(questionsFile)
+		for _, p := range problems {
+			logger.Error(p.String())
 		}
-		var questions []Question
-		if err := json.Unmarshal(file, &questions); err != nil {
-			logger.Error("Failed to unmarshal questions file. Lint the JSON file for errors")
+		if err != nil {
+			logger.Error("Failed to load questions file, run '1pcc validate' for details", err)
 			os.Exit(1)
 		}
 
 		// Assign question numbers sequentially, 1-based
 		for i := range questions {
 			questions[i].QuestionNumber = i + 1
+			questions[i].prepare()
 		}
 
 		if len(questions) > 0 {
@@ -120,6 +175,7 @@ func GetGame() *GameState {
 		}
 		instance.AllQuestions = questions
 		instance.TotalQuestions = len(instance.AllQuestions)
+		markSeen(instance)
 
 		// Iterate through all questions and sum the points available
 		var totalPoints float32 = 0.0
@@ -133,6 +189,26 @@ func GetGame() *GameState {
 	return instance
 }
 
+// prepare works out anything the server needs to know before a question
+// of the given type can be asked, the question must already be numbered
+func (q *Question) prepare() {
+	switch q.Type {
+	case "gridimage":
+		q.prepareGridImage()
+	case "crossword":
+		q.layoutCrossword()
+	case "matching":
+		q.prepareMatching()
+	case "kazakhstan":
+		q.prepareRegions()
+	case "geolocation":
+		q.prepareGeolocation()
+	case "country":
+		q.prepareCountries()
+	}
+	q.Id = questionId(q)
+}
+
 func curatePlayers(gs *GameState) {
 	// deal with message duration
 	for _, player := range gs.Players {
@@ -175,6 +251,7 @@ func decorateGameState(gs *GameState) {
 		cq.TimeLeft = 0
 		cq.IsTimedOut = true
 		cq.IsTimedOut = true
+		cq.scheduleMedia()
 		logger.Info("Timed out in have all players answered")
 		onQuestionEnded(gs)
 		return
@@ -191,6 +268,7 @@ func decorateGameState(gs *GameState) {
 			cq.TimeLeft = 0
 			cq.IsTimedOut = true
 			cq.IsTimedOut = true
+			cq.scheduleMedia()
 			//logger.Info("Timed out in loop")
 			onQuestionEnded(gs)
 		} else {
@@ -199,6 +277,12 @@ func decorateGameState(gs *GameState) {
 			//logger.Info(fmt.Sprintf("countdown : %d", cq.TimeLeft))
 		}
 	}
+	switch cq.Type {
+	case "reveal":
+		cq.RevealStage = cq.currentRevealStage()
+	case "wordle":
+		cq.WordLength = len([]rune(cq.wordleTarget()))
+	}
 	if !config.GetKioskMode().Enabled {
 		return
 	}
@@ -216,6 +300,14 @@ func onQuestionEnded(gs *GameState) {
 	// Ensure all current players have answers to all current questions
 	// ie if a new player has joined, given them zeros for answers that have already been given.
 	logger.Info("Question timed out.. doQuestionTimeout")
+	if cq := gs.CurrentQuestion; cq != nil {
+		switch cq.Type {
+		case "truefalse":
+			finaliseTrueFalse(cq)
+		case "wordle":
+			finaliseWordle(cq)
+		}
+	}
 
 	// Set kiosk mode flags
 	kioskConfig := config.GetKioskMode()
@@ -238,6 +330,7 @@ func (gs *GameState) AddPlayer(username string, isAdmin bool, isObserver bool, i
 			IsSpectator: isAdmin,
 			IpAddress:   ipAddress,
 		}
+		markSeen(gs)
 	}
 }
 
@@ -292,6 +385,7 @@ func (gs *GameState) RemovePlayer(username string) {
 	mu.Lock()
 	defer mu.Unlock()
 	delete(gs.Players, username)
+	markSeen(gs)
 }
 
 func (gs *GameState) SetPlayerAdmin(username string) {
@@ -299,6 +393,7 @@ func (gs *GameState) SetPlayerAdmin(username string) {
 	defer mu.Unlock()
 	if player, exists := gs.Players[username]; exists {
 		player.IsAdmin = true
+		markSeen(gs)
 	}
 }
 
@@ -307,6 +402,7 @@ func (gs *GameState) SetPlayerSpectator(username string) {
 	defer mu.Unlock()
 	if player, exists := gs.Players[username]; exists {
 		player.IsSpectator = true
+		markSeen(gs)
 	}
 }
 
@@ -384,6 +480,9 @@ func (gs *GameState) GetLeaderboard() []*Player {
 		}
 	}
 
+	// how often each players ranges held the truth
+	gs.updateCalibration()
+
 	pa := gs.getCurrentMaxPoints()
 	//logger.Info("current points available are " + fmt.Sprintf("%d", pa))
 
@@ -479,15 +578,19 @@ func CreateAnswer(username string) Answer {
 		logger.Warn("Player doesn't exist in game state")
 		return Answer{}
 	}
-	ret := Answer{
+	return forfeit(username, cq)
+}
+
+// forfeit returns an answer to the given question from the given player
+// which is left to the server to score
+func forfeit(username string, cq *Question) Answer {
+	return Answer{
 		Username:       username,
 		Answer:         "...",
 		QuestionNumber: cq.QuestionNumber,
 		Comment:        "forfeit",
 		Points:         0,
 	}
-
-	return ret
 }
 
 // Allows for the host to time out a particular user
@@ -503,25 +606,70 @@ func Surrender(username string) {
 }
 
 func (gs *GameState) SubmitAnswer(answer Answer) {
+	mu.Lock()
+	message := gs.addAnswer(answer)
+	mu.Unlock()
+	// MessagePlayer gets the game, which takes the lock
+	if message != "" {
+		MessagePlayer(answer.Username, message, 20)
+	}
+}
+
+// addAnswer scores the answer and adds it to the current question, unless the
+// player has already answered, returning anything the player should be told.
+// mu must be held
+func (gs *GameState) addAnswer(answer Answer) string {
 	cq := gs.GetCurrentQuestion()
 	// dont add another answer if one already exists
 	for _, a := range cq.Answers {
 		if a.Username == answer.Username {
-			return
+			return ""
 		}
 	}
+	// some question types are scored by the server rather than the client
+	scoreAnswer(cq, &answer)
 	// add the answer to the question.Answers array
 	cq.Answers = append(cq.Answers, answer)
-	if answer.Answer != "..." {
-		tl := cq.TimeLeft
-
-		timeIndication := fmt.Sprintf("answered with %d seconds remaining", tl)
-		MessagePlayer(answer.Username, timeIndication, 20)
-	}
 	// order the anwers by score
 	sort.Slice(cq.Answers, func(i, j int) bool {
 		return cq.Answers[i].Points > cq.Answers[j].Points
 	})
+	if answer.Answer != "..." {
+		return fmt.Sprintf("answered with %d seconds remaining", cq.TimeLeft)
+	}
+	return ""
+}
+
+// scoreAnswer gives the server the final say on the points awarded for
+// question types that are scored server side
+func scoreAnswer(cq *Question, answer *Answer) {
+	switch cq.Type {
+	case "truefalse":
+		scoreTrueFalse(cq, answer)
+	case "reveal":
+		scoreReveal(cq, answer)
+	case "wordle":
+		scoreWordle(cq, answer)
+	case "crossword":
+		scoreCrossword(cq, answer)
+	case "expression":
+		scoreExpression(cq, answer)
+	case "matching":
+		scoreMatching(cq, answer)
+	case "range":
+		scoreRange(cq, answer)
+	case "sketch":
+		scoreSketch(cq, answer)
+	case "kazakhstan":
+		// questions with a single answer point are still scored by the client
+		if len(cq.Regions) > 0 {
+			scoreRegions(cq, answer)
+		}
+	case "geolocation":
+		scoreGeolocation(cq, answer)
+	case "country":
+		scoreCountry(cq, answer)
+	}
 }
 
 /**
@@ -579,6 +727,9 @@ func (gs *GameState) StartQuestion() {
 		cq.TimeStarted = time.Now()
 		cq.TimeLeft = cq.TimeLimit
 		cq.IsTimedOut = false // Reset the flag when starting a question
+		cq.scheduleMedia()
+		recordShown(cq, gs.Players)
+		markSeen(gs)
 	}
 }
 
@@ -591,6 +742,7 @@ func (gs *GameState) PauseQuestion() {
 		elapsed := time.Since(cq.TimeStarted).Seconds()
 		cq.TimeLeft = int(float64(cq.TimeLimit) - elapsed)
 		cq.TimeStarted = time.Time{} // Setting to zero time effectively pauses
+		cq.scheduleMedia()
 		logger.Info("Question paused with time left: ", cq.TimeLeft)
 	}
 }
@@ -602,6 +754,7 @@ func (gs *GameState) UnPauseQuestion() {
 	if cq != nil && cq.TimeStarted.IsZero() && cq.TimeLeft > 0 {
 		// Set new start time based on remaining TimeLeft
 		cq.TimeStarted = time.Now().Add(-time.Duration((cq.TimeLimit - cq.TimeLeft)) * time.Second)
+		cq.scheduleMedia()
 		logger.Info("Question unpaused with time left: ", cq.TimeLeft)
 	}
 }
@@ -613,6 +766,7 @@ func (gs *GameState) StopQuestion() {
 	if cq != nil {
 		cq.TimeStarted = time.Time{}
 		cq.TimeLeft = 0
+		cq.scheduleMedia()
 		onQuestionEnded(gs)
 	}
 }
@@ -631,35 +785,12 @@ func (gs *GameState) ShowAnswer() {
 	logger.Info("showing answer...")
 }
 
-// NewGameState creates a new GameState with initialized maps and questions
+// NewGameState creates a new GameState with initialized maps, the questions
+// are loaded by GetGame
 func NewGameState() *GameState {
-	gs := &GameState{
+	return &GameState{
 		Players: make(map[string]*Player),
 	}
-	// Load questions from JSON file
-	file, err := os.ReadFile("questions.json")
-	if err != nil {
-		// In a production environment, you might want to handle this error differently
-		return gs
-	}
-	var questions []Question
-	if err := json.Unmarshal(file, &questions); err != nil {
-		return gs
-	}
-
-	gs.AllQuestions = questions
-	gs.TotalQuestions = len(questions)
-
-	// Set the first question as current if available
-	if len(questions) > 0 {
-		logger.Info("Loaded first question into gamestate")
-		gs.CurrentQuestion = &questions[0]
-		gs.CurrentQuestion.IsTimedOut = false
-		gs.CurrentQuestion.TimeLeft = 0
-		gs.CurrentQuestion.TimeStarted = time.Time{}
-	}
-
-	return gs
 }
 
 // Reset resets the game state for a new game while keeping players
@@ -688,6 +819,34 @@ func (gs *GameState) Reset() {
 		gs.CurrentQuestion.TimeLeft = 0
 		gs.CurrentQuestion.TimeStarted = time.Time{}
 	}
+	markSeen(gs)
 
 	logger.Info("Game state reset for new round")
 }
+
+/**
+* Adds questions to the end of the game, numbering and preparing them just
+* like the questions loaded from the questions file
+* @param questions the questions to add
+ */
+func (gs *GameState) AddQuestions(questions []Question) {
+	mu.Lock()
+	defer mu.Unlock()
+	current := 0
+	if gs.CurrentQuestion != nil {
+		current = gs.CurrentQuestion.QuestionNumber
+	}
+	for _, q := range questions {
+		q.QuestionNumber = len(gs.AllQuestions) + 1
+		q.prepare()
+		gs.AllQuestions = append(gs.AllQuestions, q)
+		gs.TotalPoints += float32(q.PointsAvailable)
+	}
+	gs.TotalQuestions = len(gs.AllQuestions)
+	markSeen(gs)
+	// appending may have moved the questions
+	if current > 0 {
+		gs.CurrentQuestion = &gs.AllQuestions[current-1]
+	}
+	logger.Info(fmt.Sprintf("%d questions added, there are now %d", len(questions), gs.TotalQuestions))
+}
@@ -0,0 +1,154 @@
+// internal/game/geo.go
+package game
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/config"
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// the mean radius of the earth in miles
+const earthRadiusMiles = 3958.8
+
+// how harsh geolocation scoring is if the question doesn't give a penalisationFactor
+var defaultPenalisationFactor = 5.0
+
+// points fall to about a third at geoDecayMiles / (MAP_SCALE x penalisationFactor) miles
+var geoDecayMiles = 100000.0
+
+// finds the latitude and longitude in a google streetview embed string eg. ...!1d53.73!2d-1.65!...
+var streetViewLocation = regexp.MustCompile(`!1d(-?[\d.]+)!2d(-?[\d.]+)`)
+
+// mercatorY returns the unscaled mercator y coordinate of the given latitude
+func mercatorY(lat float64) float64 {
+	lat = math.Max(-85, math.Min(85, lat))
+	return math.Log(math.Tan(math.Pi/4 + lat*math.Pi/360))
+}
+
+/**
+* Converts a latitude and longitude into a point on the map image
+* @param mp the projection of the map
+* @param lat the latitude in degrees
+* @param lng the longitude in degrees
+* @return the x and y coordinates of the point on the image
+ */
+func project(mp config.MapProjection, lat float64, lng float64) (float64, float64) {
+	// maps which wrap past 180 degrees carry on counting
+	for lng < mp.West {
+		lng += 360
+	}
+	x := (lng - mp.West) / (mp.East - mp.West) * mp.Width
+	if mp.Projection == "mercator" {
+		top := mercatorY(mp.North)
+		return x, (top - mercatorY(lat)) / (top - mercatorY(mp.South)) * mp.Height
+	}
+	return x, (mp.North - lat) / (mp.North - mp.South) * mp.Height
+}
+
+/**
+* Converts a point on the map image into a latitude and longitude
+* @param mp the projection of the map
+* @param x the x coordinate of the point on the image
+* @param y the y coordinate of the point on the image
+* @return the latitude and longitude in degrees
+ */
+func unproject(mp config.MapProjection, x float64, y float64) (float64, float64) {
+	lng := mp.West + x/mp.Width*(mp.East-mp.West)
+	lng = math.Mod(lng+540, 360) - 180
+	if mp.Projection == "mercator" {
+		top := mercatorY(mp.North)
+		my := top - y/mp.Height*(top-mercatorY(mp.South))
+		return math.Atan(math.Sinh(my)) * 180 / math.Pi, lng
+	}
+	return mp.North - y/mp.Height*(mp.North-mp.South), lng
+}
+
+// greatCircleMiles returns the distance in miles between two latitude and longitude points
+func greatCircleMiles(lat1 float64, lng1 float64, lat2 float64, lng2 float64) float64 {
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLng := (lng2 - lng1) * toRad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMiles * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+/**
+* Gets a 'geolocation' question ready to be scored by the server. This only
+* happens if the map image has a projection declared in the config. The true
+* location is taken from the question location, or the streetview string, or
+* failing those the map coordinates in CorrectAnswers. The map coordinates
+* are then worked out from the true location so the two always agree
+ */
+func (q *Question) prepareGeolocation() {
+	mp, exists := config.GetMapProjection(q.ClickImage)
+	if !exists {
+		return
+	}
+	if len(q.Location) != 2 {
+		q.Location = nil
+		if m := streetViewLocation.FindStringSubmatch(q.StreetView); m != nil {
+			lat, _ := strconv.ParseFloat(m[1], 64)
+			lng, _ := strconv.ParseFloat(m[2], 64)
+			q.Location = []float64{lat, lng}
+		} else if len(q.CorrectAnswers) > 0 {
+			parts := strings.Split(q.CorrectAnswers[0], ",")
+			if len(parts) == 2 {
+				x, xerr := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+				y, yerr := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+				if xerr == nil && yerr == nil {
+					lat, lng := unproject(mp, x, y)
+					q.Location = []float64{lat, lng}
+				}
+			}
+		}
+	}
+	if q.Location == nil {
+		logger.Warn(fmt.Sprintf("Question %d: geolocation has no location, it will be scored by the client", q.QuestionNumber))
+		return
+	}
+	x, y := project(mp, q.Location[0], q.Location[1])
+	q.CorrectAnswers = []string{fmt.Sprintf("%.1f,%.1f", x, y)}
+	q.Projection = mp.Projection
+}
+
+/**
+* Scores a 'geolocation' answer by the great circle distance between the
+* location clicked on the map and the true location. Points decay
+* exponentially with distance, how quickly depends on MAP_SCALE in the config
+* and the question penalisationFactor.
+* Questions whose map has no declared projection are still scored by the client
+* @param cq the question being answered
+* @param answer the answer to score
+ */
+func scoreGeolocation(cq *Question, answer *Answer) {
+	mp, exists := config.GetMapProjection(cq.ClickImage)
+	if cq.Projection == "" || !exists {
+		return
+	}
+	answer.Points = 0
+	if len(answer.Clicks) == 0 || len(answer.Clicks[0]) != 2 {
+		answer.Clicks = nil
+		return
+	}
+	answer.Clicks = answer.Clicks[:1]
+	lat, lng := unproject(mp, answer.Clicks[0][0], answer.Clicks[0][1])
+	answer.Location = []float64{lat, lng}
+	miles := greatCircleMiles(lat, lng, cq.Location[0], cq.Location[1])
+	scale := float64(config.GetMapScale())
+	if scale <= 0 {
+		scale = 1
+	}
+	pf := float64(cq.PenalisationFactor)
+	if pf <= 0 {
+		pf = defaultPenalisationFactor
+	}
+	answer.Points = float32(float64(cq.PointsAvailable) * math.Exp(-miles*scale*pf/geoDecayMiles))
+	answer.Answer = fmt.Sprintf("%.2f, %.2f", lat, lng)
+	answer.Comment = fmt.Sprintf("%d miles off", int(math.Round(miles)))
+}
@@ -0,0 +1,121 @@
+// internal/game/geo_test.go
+package game
+
+import (
+	"math"
+	"testing"
+
+	"github.com/richard-senior/1pcc/internal/config"
+)
+
+func TestProjectUnproject(t *testing.T) {
+	world := config.MapProjection{Projection: "equirectangular", Width: 360, Height: 180, West: -180, East: 180, North: 90, South: -90}
+	mercator := config.MapProjection{Projection: "mercator", Width: 1000, Height: 1000, West: -180, East: 180, North: 85, South: -85}
+	// a map which starts east of -180 and so carries on past 180
+	wrapped := config.MapProjection{Projection: "equirectangular", Width: 372, Height: 160, West: -172, East: 200, North: 80, South: -80}
+	tests := []struct {
+		name     string
+		mp       config.MapProjection
+		lat, lng float64
+		x, y     float64
+	}{
+		{"the origin", world, 0, 0, 180, 90},
+		{"London", world, 51.5, -0.1, 179.9, 38.5},
+		{"the top left", world, 90, -180, 0, 0},
+		{"the equator on mercator", mercator, 0, 0, 500, 500},
+		{"the top of mercator", mercator, 85, 90, 750, 0},
+		{"past 180", wrapped, 0, -175, 357, 80},
+	}
+	for _, tt := range tests {
+		x, y := project(tt.mp, tt.lat, tt.lng)
+		if math.Abs(x-tt.x) > 1e-6 || math.Abs(y-tt.y) > 1e-6 {
+			t.Errorf("%s: projected to %v,%v want %v,%v", tt.name, x, y, tt.x, tt.y)
+		}
+		lat, lng := unproject(tt.mp, x, y)
+		if math.Abs(lat-tt.lat) > 1e-6 || math.Abs(lng-tt.lng) > 1e-6 {
+			t.Errorf("%s: unprojected to %v,%v want %v,%v", tt.name, lat, lng, tt.lat, tt.lng)
+		}
+	}
+	// a mercator map stretches towards the poles
+	_, y60 := project(mercator, 60, 0)
+	_, y30 := project(mercator, 30, 0)
+	if 500-y60 <= 2*(500-y30) {
+		t.Errorf("60N is %v from the equator and 30N %v, mercator should stretch the higher latitude", 500-y60, 500-y30)
+	}
+}
+
+func TestGreatCircleMiles(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lng1, lat2, lng2 float64
+		want                   float64
+	}{
+		{"the same place", 53.73, -1.65, 53.73, -1.65, 0},
+		{"London to Paris", 51.5074, -0.1278, 48.8566, 2.3522, 213.5},
+		{"a degree along the equator", 0, 0, 0, 1, 69.1},
+		{"across the date line", 0, 179.5, 0, -179.5, 69.1},
+		{"to the other side", 0, 0, 0, 180, 12436.9},
+	}
+	for _, tt := range tests {
+		if got := greatCircleMiles(tt.lat1, tt.lng1, tt.lat2, tt.lng2); math.Abs(got-tt.want) > 0.5 {
+			t.Errorf("%s: %v miles, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScoreGeolocation(t *testing.T) {
+	// the projection of the world map is declared in config.json
+	t.Chdir("../..")
+	worldmap := "/static/images/worldmap.svg"
+	mp, exists := config.GetMapProjection(worldmap)
+	if !exists {
+		t.Fatalf("config.json has no projection for %s", worldmap)
+	}
+	q := &Question{Type: "geolocation", ClickImage: worldmap, PointsAvailable: 10, Location: []float64{51.5074, -0.1278}}
+	q.prepareGeolocation()
+	if q.Projection != mp.Projection {
+		t.Fatalf("the question has projection %q, want %q", q.Projection, mp.Projection)
+	}
+	decay := func(miles float64) float32 {
+		scale := float64(config.GetMapScale())
+		if scale <= 0 {
+			scale = 1
+		}
+		return float32(10 * math.Exp(-miles*scale*defaultPenalisationFactor/geoDecayMiles))
+	}
+	click := func(lat, lng float64) [][]float64 {
+		x, y := project(mp, lat, lng)
+		return [][]float64{{x, y}}
+	}
+	tests := []struct {
+		name    string
+		clicks  [][]float64
+		points  float32
+		comment string
+	}{
+		{"on the spot", click(51.5074, -0.1278), 10, "0 miles off"},
+		{"in Paris", click(48.8566, 2.3522), decay(greatCircleMiles(51.5074, -0.1278, 48.8566, 2.3522)), "213 miles off"},
+		{"only the first click counts", append(click(48.8566, 2.3522), click(51.5074, -0.1278)...), decay(greatCircleMiles(51.5074, -0.1278, 48.8566, 2.3522)), "213 miles off"},
+		{"a bad click", [][]float64{{1}}, 0, ""},
+		{"no click", nil, 0, ""},
+	}
+	for _, tt := range tests {
+		a := Answer{Clicks: tt.clicks, Points: 99}
+		scoreGeolocation(q, &a)
+		if math.Abs(float64(a.Points-tt.points)) > 1e-3 {
+			t.Errorf("%s: scored %v, want %v", tt.name, a.Points, tt.points)
+		}
+		if a.Comment != tt.comment {
+			t.Errorf("%s: comment %q, want %q", tt.name, a.Comment, tt.comment)
+		}
+	}
+
+	// without a declared projection the client scores the answer
+	q = &Question{Type: "geolocation", ClickImage: "/static/images/unknown.svg", PointsAvailable: 10}
+	q.prepareGeolocation()
+	a := Answer{Clicks: click(0, 0), Points: 7}
+	scoreGeolocation(q, &a)
+	if a.Points != 7 {
+		t.Errorf("a map without a projection was scored %v by the server", a.Points)
+	}
+}
@@ -0,0 +1,124 @@
+// internal/game/gridimage.go
+package game
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"image"
+
+	"github.com/richard-senior/1pcc/internal/imaging"
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// the size in pixels of each cell of a grid built from the choice images
+var compositeCellSize = 300
+
+// the grid used when a question doesn't give one, as GridImage.js does
+var defaultGrid = []int{5, 4}
+
+// newTileId returns a random hex id
+func newTileId() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// getGrid returns the number of rows and columns of a gridimage question
+func (q *Question) getGrid() (int, int) {
+	if len(q.Grid) == 2 && q.Grid[0] > 0 && q.Grid[1] > 0 {
+		return q.Grid[0], q.Grid[1]
+	}
+	return defaultGrid[0], defaultGrid[1]
+}
+
+// compositeImages returns the local paths of the choice images in the order
+// of the cells they belong in, or nil if the choices don't all have a local image
+func (q *Question) compositeImages() []string {
+	if len(q.Choices) == 0 {
+		return nil
+	}
+	images := make(map[string]string)
+	for _, c := range q.Choices {
+		path := imaging.LocalPath(c.ImgUrl)
+		if path == "" {
+			return nil
+		}
+		images[c.Answer] = path
+	}
+	rows, cols := q.getGrid()
+	paths := make([]string, rows*cols)
+	for i := range paths {
+		if i < len(q.CorrectAnswers) {
+			paths[i] = images[q.CorrectAnswers[i]]
+		}
+	}
+	return paths
+}
+
+/**
+* Gets a 'gridimage' question ready to be served as tiles sliced by the
+* server. The tiles come from the local ImageUrl or, if there isn't one, from
+* a composite of the choice images with each in the cell of its correct answer.
+* Each tile is given an opaque id so that its position can't be read from its url,
+* the ids are random so they change every time the questions are loaded.
+* Questions with a remote image are still sliced by the client
+ */
+func (q *Question) prepareGridImage() {
+	rows, cols := q.getGrid()
+	var tileWidth, tileHeight int
+	if path := imaging.LocalPath(q.ImageUrl); path != "" {
+		w, h, err := imaging.Size(path)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Question %d: cannot read %s: %s", q.QuestionNumber, q.ImageUrl, err.Error()))
+			return
+		}
+		tileWidth, tileHeight = w/cols, h/rows
+	} else if q.ImageUrl == "" && q.compositeImages() != nil {
+		tileWidth, tileHeight = compositeCellSize, compositeCellSize
+	} else {
+		return
+	}
+	q.Tiles = make([]string, rows*cols)
+	for i := range q.Tiles {
+		q.Tiles[i] = newTileId()
+	}
+	q.TileSize = []int{tileWidth, tileHeight}
+}
+
+/**
+* Finds and cuts out the tile with the given id
+* @param id the opaque id of the tile
+* @return the image of the tile or an error if there is no such tile
+ */
+func (gs *GameState) GetGridTile(id string) (image.Image, error) {
+	mu.RLock()
+	// the tiles are looked up through the questions being played so that
+	// those of questions which have since been reloaded are gone with them
+	var q Question
+	index := -1
+	for i := 0; i < len(gs.AllQuestions) && index < 0; i++ {
+		for j, t := range gs.AllQuestions[i].Tiles {
+			if t == id {
+				q, index = gs.AllQuestions[i], j
+				break
+			}
+		}
+	}
+	mu.RUnlock()
+	if index < 0 {
+		return nil, fmt.Errorf("there is no tile %s", id)
+	}
+	rows, cols := q.getGrid()
+	var src image.Image
+	var err error
+	if path := imaging.LocalPath(q.ImageUrl); path != "" {
+		src, err = imaging.Load(path)
+	} else {
+		src, err = imaging.Composite(q.compositeImages(), cols, rows, q.TileSize[0], q.TileSize[1])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return imaging.Cell(src, cols, rows, index), nil
+}
@@ -0,0 +1,132 @@
+// internal/game/history.go
+package game
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// the file recording which questions each player has been shown, across games
+var historyFile = "./history.json"
+
+var (
+	// when each player was last shown each question, keyed by username then question id
+	history     map[string]map[string]time.Time
+	historyOnce sync.Once
+	historyMu   sync.Mutex
+)
+
+// questionId returns the stable id of the question, which is its id if it
+// has one or else made from its type, text and image so that the same
+// question has the same id in every game
+func questionId(q *Question) string {
+	if q.Id != "" {
+		return q.Id
+	}
+	sum := sha1.Sum([]byte(q.Type + "\n" + q.Question + "\n" + q.ImageUrl))
+	return hex.EncodeToString(sum[:6])
+}
+
+// loadHistory reads the history file the first time it's needed, historyMu must be held
+func loadHistory() {
+	historyOnce.Do(func() {
+		history = make(map[string]map[string]time.Time)
+		file, err := os.ReadFile(historyFile)
+		if errors.Is(err, os.ErrNotExist) {
+			return
+		}
+		if err != nil {
+			logger.Warn("Failed to read the question history", err)
+			return
+		}
+		if err := json.Unmarshal(file, &history); err != nil {
+			logger.Warn("Failed to unmarshal the question history, starting a new one", err)
+			history = make(map[string]map[string]time.Time)
+		}
+	})
+}
+
+// saveHistory writes the history to a new file which then replaces the old
+// one, so a crash part way through never loses the whole history
+func saveHistory() {
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	data, err := json.MarshalIndent(history, "", "    ")
+	if err != nil {
+		logger.Warn("Failed to marshal the question history", err)
+		return
+	}
+	tmp := historyFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		logger.Warn("Failed to write the question history", err)
+		return
+	}
+	if err := os.Rename(tmp, historyFile); err != nil {
+		logger.Warn("Failed to replace the question history", err)
+	}
+}
+
+// isPlaying reports whether the player is answering questions, rather than hosting or watching
+func (p *Player) isPlaying() bool {
+	return !p.IsAdmin && !p.IsSpectator
+}
+
+// recordShown remembers that the players have been shown the question and
+// saves the history in the background
+func recordShown(q *Question, players map[string]*Player) {
+	historyMu.Lock()
+	loadHistory()
+	id := questionId(q)
+	now := time.Now()
+	for name, p := range players {
+		if !p.isPlaying() {
+			continue
+		}
+		if history[name] == nil {
+			history[name] = make(map[string]time.Time)
+		}
+		history[name][id] = now
+	}
+	historyMu.Unlock()
+	go saveHistory()
+}
+
+// seenCounts returns how many of the given players have been shown each
+// of the questions before, keyed by question id and leaving out those nobody has seen
+func seenCounts(questions []Question, players map[string]*Player) map[string]int {
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	loadHistory()
+	counts := make(map[string]int)
+	for i := range questions {
+		id := questionId(&questions[i])
+		for name, p := range players {
+			if _, seen := history[name][id]; seen && p.isPlaying() {
+				counts[id]++
+			}
+		}
+	}
+	return counts
+}
+
+// markSeen sets SeenBy on the questions yet to be played, so the host can
+// be warned before asking a question the players have had before. It's called
+// whenever the questions, the players or what's been played change, with mu held
+func markSeen(gs *GameState) {
+	counts := seenCounts(gs.AllQuestions, gs.Players)
+	for i := range gs.AllQuestions {
+		q := &gs.AllQuestions[i]
+		if q.hasBeenPlayed() {
+			q.SeenBy = 0
+		} else {
+			q.SeenBy = counts[questionId(q)]
+		}
+	}
+}
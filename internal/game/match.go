@@ -0,0 +1,55 @@
+// internal/game/match.go
+package game
+
+import (
+	"strings"
+)
+
+/**
+* Determines whether the given free text answer matches any of the correct
+* answers, ignoring case and surrounding whitespace, and allowing up to
+* 'tolerance' typos as measured by Levenshtein distance
+* @param given the answer the player gave
+* @param correct the list of acceptable answers
+* @param tolerance the maximum number of single character edits allowed
+* @return true if the answer is close enough to any correct answer
+ */
+func isCorrectText(given string, correct []string, tolerance int) bool {
+	given = strings.ToLower(strings.TrimSpace(given))
+	if given == "" {
+		return false
+	}
+	for _, c := range correct {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" {
+			continue
+		}
+		if levenshtein(given, c) <= tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// levenshtein returns the number of single character edits
+// required to turn one string into the other
+func levenshtein(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
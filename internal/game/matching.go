@@ -0,0 +1,89 @@
+// internal/game/matching.go
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+/**
+* Gets a 'matching' question ready to be played. The left hand list is the
+* question Choices, each of which names the right hand item it matches in
+* its Answer field. The right hand list is the question Matches which are
+* shuffled here, once, so that every player sees the same order and the
+* order in the questions file doesn't give the pairs away
+ */
+func (q *Question) prepareMatching() {
+	rand.Shuffle(len(q.Matches), func(i, j int) {
+		q.Matches[i], q.Matches[j] = q.Matches[j], q.Matches[i]
+	})
+	for _, p := range q.matchingProblems() {
+		logger.Warn(fmt.Sprintf("Question %d: %s", q.QuestionNumber, p))
+	}
+}
+
+// matchingProblems describes each left hand item that can't be matched
+func (q *Question) matchingProblems() []string {
+	var problems []string
+	if len(q.Choices) == 0 || len(q.Matches) == 0 {
+		problems = append(problems, "there must be choices and matches to link them to")
+	}
+	for _, c := range q.Choices {
+		if q.matchIndex(c.Answer) < 0 {
+			problems = append(problems, fmt.Sprintf("matching choice %q has answer %q which isn't one of the matches", c.Choice, c.Answer))
+		}
+	}
+	return problems
+}
+
+// matchIndex returns the index of the right hand item with the given name or -1
+func (q *Question) matchIndex(name string) int {
+	for i, m := range q.Matches {
+		if strings.EqualFold(strings.TrimSpace(m.Choice), strings.TrimSpace(name)) {
+			return i
+		}
+	}
+	return -1
+}
+
+/**
+* Scores a 'matching' answer. Links holds, for each left hand item in turn,
+* the index of the right hand item the player linked it to or -1 if they
+* didn't link it. Each right hand item may only be used once, any later
+* link to the same item is ignored. Each correct pair earns an equal share of
+* PointsAvailable
+* @param cq the question being answered
+* @param answer the answer to score
+ */
+func scoreMatching(cq *Question, answer *Answer) {
+	answer.Points = 0
+	if len(answer.Links) == 0 || len(cq.Choices) == 0 {
+		answer.Links = nil
+		return
+	}
+	links := make([]int, len(cq.Choices))
+	used := make(map[int]bool)
+	correct := 0
+	for i, c := range cq.Choices {
+		links[i] = -1
+		if i >= len(answer.Links) {
+			continue
+		}
+		l := answer.Links[i]
+		if l < 0 || l >= len(cq.Matches) || used[l] {
+			continue
+		}
+		used[l] = true
+		links[i] = l
+		if l == cq.matchIndex(c.Answer) {
+			correct++
+		}
+	}
+	answer.Links = links
+	answer.Points = float32(cq.PointsAvailable) * float32(correct) / float32(len(cq.Choices))
+	answer.Answer = fmt.Sprintf("%d / %d pairs", correct, len(cq.Choices))
+	answer.Comment = ""
+}
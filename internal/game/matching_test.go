@@ -0,0 +1,73 @@
+// internal/game/matching_test.go
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScoreMatching(t *testing.T) {
+	q := &Question{
+		Type:            "matching",
+		PointsAvailable: 6,
+		Choices: []Choice{
+			{Choice: "France", Answer: "Paris"},
+			{Choice: "Italy", Answer: "rome "},
+			{Choice: "Spain", Answer: "Madrid"},
+		},
+		Matches: []Choice{{Choice: "Madrid"}, {Choice: "Paris"}, {Choice: "Rome"}},
+	}
+	tests := []struct {
+		name   string
+		links  []int
+		points float32
+		kept   []int
+		answer string
+	}{
+		{"all right", []int{1, 2, 0}, 6, []int{1, 2, 0}, "3 / 3 pairs"},
+		{"one right", []int{1, 0, 2}, 2, []int{1, 0, 2}, "1 / 3 pairs"},
+		{"none right", []int{0, 1, 2}, 0, []int{0, 1, 2}, "0 / 3 pairs"},
+		{"some not linked", []int{1, -1}, 2, []int{1, -1, -1}, "1 / 3 pairs"},
+		{"a match used twice", []int{1, 1, 0}, 4, []int{1, -1, 0}, "2 / 3 pairs"},
+		{"a match that isn't there", []int{1, 7, -3}, 2, []int{1, -1, -1}, "1 / 3 pairs"},
+		{"too many links", []int{1, 2, 0, 0}, 6, []int{1, 2, 0}, "3 / 3 pairs"},
+	}
+	for _, tt := range tests {
+		a := Answer{Links: tt.links, Points: 99}
+		scoreMatching(q, &a)
+		if a.Points != tt.points {
+			t.Errorf("%s: scored %v, want %v", tt.name, a.Points, tt.points)
+		}
+		if !reflect.DeepEqual(a.Links, tt.kept) {
+			t.Errorf("%s: links kept as %v, want %v", tt.name, a.Links, tt.kept)
+		}
+		if a.Answer != tt.answer {
+			t.Errorf("%s: answer %q, want %q", tt.name, a.Answer, tt.answer)
+		}
+	}
+	a := Answer{Points: 99}
+	scoreMatching(q, &a)
+	if a.Points != 0 || a.Links != nil {
+		t.Errorf("no links scored %v with links %v", a.Points, a.Links)
+	}
+}
+
+func TestMatchingProblems(t *testing.T) {
+	tests := []struct {
+		name     string
+		choices  []Choice
+		matches  []Choice
+		problems int
+	}{
+		{"all matched", []Choice{{Choice: "a", Answer: "1"}, {Choice: "b", Answer: " 2"}}, []Choice{{Choice: "1"}, {Choice: "2"}}, 0},
+		{"one unmatched", []Choice{{Choice: "a", Answer: "1"}, {Choice: "b", Answer: "3"}}, []Choice{{Choice: "1"}, {Choice: "2"}}, 1},
+		{"no matches", []Choice{{Choice: "a", Answer: "1"}}, nil, 2},
+		{"no choices", nil, []Choice{{Choice: "1"}}, 1},
+	}
+	for _, tt := range tests {
+		q := &Question{Choices: tt.choices, Matches: tt.matches}
+		if problems := q.matchingProblems(); len(problems) != tt.problems {
+			t.Errorf("%s: %q, want %d problems", tt.name, problems, tt.problems)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// internal/game/media.go
+package game
+
+import (
+	"time"
+)
+
+// how long every screen is given to buffer a clip before playback starts
+var mediaLeadTime = 1500 * time.Millisecond
+
+// HasMedia returns true if the question has an audio or video clip to play
+func (q *Question) HasMedia() bool {
+	return q.AudioUrl != "" || q.VideoUrl != ""
+}
+
+/**
+* Works out the server time (unix milliseconds) at which the question's clip
+* should be at MediaStart, based on when the question started. Screens use
+* this along with GameState.ServerTime to play the clip in sync.
+* Should be called whenever TimeStarted changes
+ */
+func (q *Question) scheduleMedia() {
+	if !q.HasMedia() || q.TimeStarted.IsZero() {
+		q.MediaStartsAt = 0
+		return
+	}
+	q.MediaStartsAt = q.TimeStarted.Add(mediaLeadTime).UnixMilli()
+}
@@ -0,0 +1,300 @@
+// internal/game/packs.go
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// the directory holding question packs, one directory per pack
+var packsDir = "./packs"
+
+/*
+Pack describes a set of questions which can be played instead of questions.json.
+Each pack is a directory of packs holding a questions.json in the usual format,
+a pack.json describing it and any images or clips its questions use. Questions
+refer to their own assets relative to the pack directory (eg. images/cat.jpg)
+and to shared assets as usual (eg. /static/images/worldmap.svg)
+*/
+type Pack struct {
+	Name       string   `json:"name"`               // the name of the pack directory, used to select it
+	Title      string   `json:"title"`              // the name shown to the host, defaults to the directory name
+	Author     string   `json:"author,omitempty"`   // who wrote the questions
+	Difficulty int      `json:"difficulty"`         // 1 easy, 2 medium or 3 hard, zero if not given
+	Tags       []string `json:"tags,omitempty"`     // eg. music, geography
+	Duration   int      `json:"duration,omitempty"` // roughly how many minutes the pack takes to play
+	Questions  int      `json:"questions"`          // how many questions are in the pack
+}
+
+// packDir returns the directory of the named pack, refusing names which
+// would reach outside the packs directory
+func packDir(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return "", fmt.Errorf("invalid pack name %q", name)
+	}
+	return filepath.Join(packsDir, name), nil
+}
+
+/**
+* Lists the question packs in the packs directory
+* @return the packs in order of title, or an error if the packs directory can't be read
+ */
+func ListPacks() ([]Pack, error) {
+	entries, err := os.ReadDir(packsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Pack{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	packs := []Pack{}
+	for _, e := range entries {
+		// packs being made are hidden until they're ready
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		dir := filepath.Join(packsDir, e.Name())
+		file, err := os.ReadFile(filepath.Join(dir, "questions.json"))
+		if err != nil {
+			continue
+		}
+		p := Pack{Name: e.Name(), Title: e.Name()}
+		if meta, err := os.ReadFile(filepath.Join(dir, "pack.json")); err == nil {
+			if err := json.Unmarshal(meta, &p); err != nil {
+				logger.Warn("Failed to unmarshal pack.json of pack "+e.Name(), err)
+			}
+			p.Name = e.Name()
+		}
+		var questions []json.RawMessage
+		if _, list, _, err := splitQuestions(file); err == nil && json.Unmarshal(list, &questions) == nil {
+			p.Questions = len(questions)
+		}
+		packs = append(packs, p)
+	}
+	sort.Slice(packs, func(i, j int) bool {
+		return packs[i].Title < packs[j].Title
+	})
+	return packs, nil
+}
+
+/**
+* Reads the questions of a pack as they were written, without checking them
+* or pointing their urls at the pack's assets, eg. to export them
+* @param name the name of the pack, or "" for questions.json
+* @return the questions or an error if they couldn't be read
+ */
+func PackQuestions(name string) ([]Question, error) {
+	path := questionsFile
+	if name != "" {
+		dir, err := packDir(name)
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(dir, "questions.json")
+	}
+	return ReadQuestionsFile(path)
+}
+
+/**
+* Makes a new pack of the given questions, which are checked as a questions
+* file would be. The pack is written to a hidden directory first so that it
+* only appears once it's complete
+* @param p the pack's name and details
+* @param questions the questions
+* @return the problems with the questions and an error if the pack wasn't made
+ */
+func CreatePack(p Pack, questions []Question) ([]Problem, error) {
+	dir, err := packDir(p.Name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("there is already a pack called %s", p.Name)
+	}
+	if p.Title == "" {
+		p.Title = p.Name
+	}
+	p.Questions = len(questions)
+	tmp := filepath.Join(packsDir, "."+p.Name)
+	os.RemoveAll(tmp)
+	if err := os.MkdirAll(tmp, 0755); err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	all, err := marshalQuestions(questions)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := json.MarshalIndent(p, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	for file, data := range map[string][]byte{"questions.json": all, "pack.json": meta} {
+		if err := os.WriteFile(filepath.Join(tmp, file), data, 0644); err != nil {
+			return nil, err
+		}
+	}
+	problems, _, err := ValidateQuestions(filepath.Join(tmp, "questions.json"))
+	if err != nil {
+		return nil, err
+	}
+	var errs []Problem
+	for _, problem := range problems {
+		if !problem.Warning {
+			errs = append(errs, problem)
+		}
+	}
+	if len(errs) > 0 {
+		return problems, fmt.Errorf("the questions have %d problems, the first is %s", len(errs), errs[0])
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("pack %s made with %d questions", p.Name, len(questions)))
+	return problems, nil
+}
+
+// packUrl points a url relative to a pack directory at the pack's assets,
+// which are served at /packs/<name>/...
+func packUrl(name string, url string) string {
+	if url == "" || strings.HasPrefix(url, "/") || strings.Contains(url, "://") {
+		return url
+	}
+	return "/packs/" + name + "/" + url
+}
+
+/**
+* Loads and validates the questions of the given pack, pointing any urls
+* relative to the pack directory at the pack's assets
+* @param name the name of the pack directory
+* @return the questions, any problems with them and an error if they can't be used
+ */
+func loadPack(name string) ([]Question, []Problem, error) {
+	dir, err := packDir(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	questions, problems, err := loadQuestionsFile(filepath.Join(dir, "questions.json"))
+	if err != nil {
+		return nil, problems, fmt.Errorf("pack %s: %w", name, err)
+	}
+	for i := range questions {
+		q := &questions[i]
+		for _, url := range []*string{&q.ImageUrl, &q.ClickImage, &q.AnswerImage, &q.Panorama, &q.AudioUrl, &q.VideoUrl} {
+			*url = packUrl(name, *url)
+		}
+		for j := range q.Choices {
+			q.Choices[j].ImgUrl = packUrl(name, q.Choices[j].ImgUrl)
+		}
+		for j := range q.Matches {
+			q.Matches[j].ImgUrl = packUrl(name, q.Matches[j].ImgUrl)
+		}
+	}
+	return questions, nil, nil
+}
+
+// loadQuestionsFile validates then loads a file of questions, refusing
+// it if there are any problems which aren't just warnings
+func loadQuestionsFile(path string) ([]Question, []Problem, error) {
+	problems, _, err := ValidateQuestions(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var errs []Problem
+	for _, p := range problems {
+		if p.Warning {
+			logger.Warn(p.String())
+		} else {
+			errs = append(errs, p)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs, fmt.Errorf("%s has %d problems, the first is %s", path, len(errs), errs[0])
+	}
+	questions, err := ReadQuestionsFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return questions, nil, nil
+}
+
+// loadQuestions loads the questions of the given packs one after the
+// other, or questions.json if there are none
+func loadQuestions(packs []string) ([]Question, []Problem, error) {
+	if len(packs) == 0 {
+		return loadQuestionsFile(questionsFile)
+	}
+	var all []Question
+	for _, name := range packs {
+		questions, problems, err := loadPack(name)
+		if err != nil {
+			return nil, problems, err
+		}
+		all = append(all, questions...)
+	}
+	return all, nil, nil
+}
+
+/**
+* Gets the packs the game is being played from
+* @return a copy of the names of the packs, none if questions.json is being played
+ */
+func (gs *GameState) GetPacks() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]string{}, gs.Packs...)
+}
+
+/**
+* Chooses the packs the game is played from, replacing all of the questions.
+* This can only be done in the lobby, before any question has been played
+* @param packs the names of the packs, played in the order given, or none to go back to questions.json
+* @return the problems with the packs and an error if they weren't used
+ */
+func (gs *GameState) SelectPacks(packs []string) ([]Problem, error) {
+	questions, problems, err := loadQuestions(packs)
+	if err != nil {
+		return problems, err
+	}
+	if len(questions) == 0 {
+		return nil, errors.New("there are no questions in the chosen packs")
+	}
+	if err := gs.startWith(questions, packs, nil); err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("%d questions loaded from packs %s", len(questions), strings.Join(packs, ", ")))
+	return nil, nil
+}
+
+// startWith replaces all of the questions with the given questions, which
+// came from the given packs and composition, as long as none have been played
+func (gs *GameState) startWith(questions []Question, packs []string, composition *Composition) error {
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range gs.AllQuestions {
+		if gs.AllQuestions[i].hasBeenPlayed() {
+			return errors.New("the game has started, the questions can only be chosen before the first question")
+		}
+	}
+	gs.TotalPoints = 0
+	for i := range questions {
+		questions[i].QuestionNumber = i + 1
+		questions[i].prepare()
+		gs.TotalPoints += float32(questions[i].PointsAvailable)
+	}
+	gs.AllQuestions = questions
+	gs.TotalQuestions = len(questions)
+	markSeen(gs)
+	gs.CurrentQuestion = &gs.AllQuestions[0]
+	gs.IsShowAnswer = false
+	gs.Packs = packs
+	gs.Composition = composition
+	return nil
+}
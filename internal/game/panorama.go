@@ -0,0 +1,33 @@
+// internal/game/panorama.go
+package game
+
+import (
+	"fmt"
+
+	"github.com/richard-senior/1pcc/internal/imaging"
+)
+
+/**
+* Finds the local panorama image of the given question.
+* Players may only see the panorama of the current question or of questions
+* already asked, admins may see any
+* @param questionNumber the number of the question
+* @param p the player asking for the panorama, nil for observer screens
+* @return the path of the image on the local filesystem or an error
+ */
+func (gs *GameState) GetPanorama(questionNumber int, p *Player) (string, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if questionNumber < 1 || questionNumber > len(gs.AllQuestions) {
+		return "", fmt.Errorf("there is no question %d", questionNumber)
+	}
+	q := &gs.AllQuestions[questionNumber-1]
+	path := imaging.LocalPath(q.Panorama)
+	if path == "" {
+		return "", fmt.Errorf("question %d has no local panorama", questionNumber)
+	}
+	if (p == nil || !p.IsAdmin) && gs.CurrentQuestion != nil && questionNumber > gs.CurrentQuestion.QuestionNumber {
+		return "", fmt.Errorf("question %d hasn't been asked yet", questionNumber)
+	}
+	return path, nil
+}
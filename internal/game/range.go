@@ -0,0 +1,111 @@
+// internal/game/range.go
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// rangeTruth returns the true value of a 'range' question from its first correct answer
+func (q *Question) rangeTruth() (float64, error) {
+	if len(q.CorrectAnswers) == 0 {
+		return 0, errors.New("there is no correct answer")
+	}
+	s := strings.ReplaceAll(strings.TrimSpace(q.CorrectAnswers[0]), ",", "")
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("correct answer %q isn't a number", q.CorrectAnswers[0])
+	}
+	return v, nil
+}
+
+/**
+* Scores a 'range' answer in which the player gives a lower and upper bound
+* that they think the true value lies between. Nothing is scored unless the
+* true value is inside the range. Inside the range the points fall away
+* as the range widens, a range as wide as the true value itself scores nothing.
+* Whether the range held the truth is recorded for the calibration figures
+* on the leaderboard
+* @param cq the question being answered
+* @param answer the answer to score
+ */
+func scoreRange(cq *Question, answer *Answer) {
+	answer.Points = 0
+	answer.InRange = false
+	if answer.Lower == nil && answer.Upper == nil {
+		return
+	}
+	if answer.Lower == nil || answer.Upper == nil {
+		answer.Comment = "both bounds are needed"
+		answer.Lower, answer.Upper = nil, nil
+		return
+	}
+	lower, upper := *answer.Lower, *answer.Upper
+	if math.IsNaN(lower) || math.IsInf(lower, 0) || math.IsNaN(upper) || math.IsInf(upper, 0) {
+		answer.Comment = "the bounds must be numbers"
+		answer.Lower, answer.Upper = nil, nil
+		return
+	}
+	if lower > upper {
+		lower, upper = upper, lower
+		answer.Lower, answer.Upper = &lower, &upper
+	}
+	answer.Answer = fmt.Sprintf("%s to %s", formatBound(lower), formatBound(upper))
+	truth, err := cq.rangeTruth()
+	if err != nil {
+		answer.Comment = err.Error()
+		return
+	}
+	if truth < lower || truth > upper {
+		answer.Comment = "missed"
+		return
+	}
+	answer.InRange = true
+	scale := math.Abs(truth)
+	if scale == 0 {
+		scale = 1
+	}
+	factor := math.Max(0, 1-(upper-lower)/scale)
+	answer.Points = float32(float64(cq.PointsAvailable) * factor)
+	answer.Comment = fmt.Sprintf("hit, %d%% narrow", int(factor*100))
+}
+
+// formatBound formats a bound without any unnecessary decimal places
+func formatBound(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+/**
+* Works out how often each player's ranges held the true value across the
+* 'range' questions that have finished. Questions still being played are
+* skipped so as not to give their answer away
+ */
+func (gs *GameState) updateCalibration() {
+	answered := make(map[string]int)
+	hit := make(map[string]int)
+	cn := 0
+	if gs.CurrentQuestion != nil {
+		cn = gs.CurrentQuestion.QuestionNumber
+	}
+	for _, q := range gs.AllQuestions {
+		if q.Type != "range" || (q.QuestionNumber >= cn && !q.IsTimedOut) {
+			continue
+		}
+		for _, a := range q.Answers {
+			if a.Lower == nil || a.Upper == nil {
+				continue
+			}
+			answered[a.Username]++
+			if a.InRange {
+				hit[a.Username]++
+			}
+		}
+	}
+	for username, p := range gs.Players {
+		p.RangesAnswered = answered[username]
+		p.RangesHit = hit[username]
+	}
+}
@@ -0,0 +1,81 @@
+// internal/game/range_test.go
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScoreRange(t *testing.T) {
+	bound := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name    string
+		truth   string
+		lower   *float64
+		upper   *float64
+		points  float32
+		inRange bool
+		comment string
+	}{
+		{"exact", "1000", bound(1000), bound(1000), 10, true, "hit, 100% narrow"},
+		{"narrow", "1000", bound(900), bound(1100), 8, true, "hit, 80% narrow"},
+		{"the wrong way round", "1000", bound(1100), bound(900), 8, true, "hit, 80% narrow"},
+		{"on the edge", "1000", bound(1000), bound(1500), 5, true, "hit, 50% narrow"},
+		{"as wide as the truth", "1000", bound(500), bound(1500), 0, true, "hit, 0% narrow"},
+		{"wider than the truth", "1000", bound(0), bound(5000), 0, true, "hit, 0% narrow"},
+		{"missed", "1000", bound(0), bound(500), 0, false, "missed"},
+		{"with commas", "1,000", bound(900), bound(1100), 8, true, "hit, 80% narrow"},
+		{"a true value of zero", "0", bound(-0.25), bound(0.25), 5, true, "hit, 50% narrow"},
+		{"negative", "-200", bound(-220), bound(-180), 8, true, "hit, 80% narrow"},
+		{"one bound", "1000", bound(900), nil, 0, false, "both bounds are needed"},
+		{"not a number", "1000", bound(math.NaN()), bound(1100), 0, false, "the bounds must be numbers"},
+		{"infinite", "1000", bound(900), bound(math.Inf(1)), 0, false, "the bounds must be numbers"},
+		{"no truth", "lots", bound(900), bound(1100), 0, false, `correct answer "lots" isn't a number`},
+		{"no bounds", "1000", nil, nil, 0, false, ""},
+	}
+	for _, tt := range tests {
+		q := &Question{Type: "range", PointsAvailable: 10, CorrectAnswers: []string{tt.truth}}
+		a := Answer{Lower: tt.lower, Upper: tt.upper, Points: 99}
+		scoreRange(q, &a)
+		if math.Abs(float64(a.Points-tt.points)) > 1e-4 {
+			t.Errorf("%s: scored %v, want %v", tt.name, a.Points, tt.points)
+		}
+		if a.InRange != tt.inRange {
+			t.Errorf("%s: in range %v, want %v", tt.name, a.InRange, tt.inRange)
+		}
+		if a.Comment != tt.comment {
+			t.Errorf("%s: comment %q, want %q", tt.name, a.Comment, tt.comment)
+		}
+	}
+}
+
+func TestUpdateCalibration(t *testing.T) {
+	bound := func(v float64) *float64 { return &v }
+	answer := func(username string, inRange bool) Answer {
+		return Answer{Username: username, Lower: bound(1), Upper: bound(2), InRange: inRange}
+	}
+	gs := NewGameState()
+	gs.Players["alice"] = &Player{Username: "alice"}
+	gs.Players["bob"] = &Player{Username: "bob"}
+	gs.AllQuestions = []Question{
+		{QuestionNumber: 1, Type: "range", IsTimedOut: true, Answers: []Answer{answer("alice", true), answer("bob", false)}},
+		{QuestionNumber: 2, Type: "freetext", IsTimedOut: true, Answers: []Answer{answer("alice", true)}},
+		{QuestionNumber: 3, Type: "range", IsTimedOut: true, Answers: []Answer{answer("alice", true), {Username: "bob"}}},
+		// still being played so it doesn't count yet
+		{QuestionNumber: 4, Type: "range", Answers: []Answer{answer("alice", false), answer("bob", true)}},
+	}
+	gs.CurrentQuestion = &gs.AllQuestions[3]
+	gs.updateCalibration()
+	if p := gs.Players["alice"]; p.RangesAnswered != 2 || p.RangesHit != 2 {
+		t.Errorf("alice hit %d of %d, want 2 of 2", p.RangesHit, p.RangesAnswered)
+	}
+	if p := gs.Players["bob"]; p.RangesAnswered != 1 || p.RangesHit != 0 {
+		t.Errorf("bob hit %d of %d, want 0 of 1", p.RangesHit, p.RangesAnswered)
+	}
+
+	gs.AllQuestions[3].IsTimedOut = true
+	gs.updateCalibration()
+	if p := gs.Players["bob"]; p.RangesAnswered != 2 || p.RangesHit != 1 {
+		t.Errorf("once finished bob hit %d of %d, want 1 of 2", p.RangesHit, p.RangesAnswered)
+	}
+}
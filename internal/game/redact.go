@@ -0,0 +1,208 @@
+// internal/game/redact.go
+package game
+
+import (
+	"strings"
+	"time"
+)
+
+/**
+* Returns a copy of the game state which is safe to send to the given player.
+* Some question types are scored by the server and the client has no need of
+* their answers, or of content which would give the answer away, until the
+* question has ended. Admins get everything.
+* @param p the player the state is being sent to (may be nil)
+* @return a shallow copy of the game state with sensitive question data removed
+ */
+func (gs *GameState) Redacted(p *Player) *GameState {
+	mu.RLock()
+	defer mu.RUnlock()
+	ret := *gs
+	ret.CurrentUser = p
+	ret.ServerTime = time.Now().UnixMilli()
+	if p != nil && p.IsAdmin {
+		return &ret
+	}
+	ret.AllQuestions = make([]Question, len(gs.AllQuestions))
+	for i := range gs.AllQuestions {
+		ret.AllQuestions[i] = gs.AllQuestions[i]
+		redactQuestion(&ret.AllQuestions[i], p)
+	}
+	if gs.CurrentQuestion != nil {
+		cq := *gs.CurrentQuestion
+		redactQuestion(&cq, p)
+		ret.CurrentQuestion = &cq
+	}
+	return &ret
+}
+
+// redactQuestion removes anything from the given (copied) question which
+// the given player should not see before the question has ended
+func redactQuestion(q *Question, p *Player) {
+	// drawings are too big to send on every poll, they are fetched
+	// one at a time as svg through /api/sketch
+	if q.Type == "sketch" {
+		hideOthersAnswers(q, nil, func(a *Answer) {
+			a.Strokes = nil
+		})
+	}
+	if q.IsTimedOut {
+		return
+	}
+	switch q.Type {
+	case "gridimage":
+		// tiles are fetched through /api/grid-tile, the whole image and
+		// the choice images it was built from would give the order away
+		if len(q.Tiles) > 0 && q.ImageUrl != "" {
+			q.ImageUrl = ""
+		} else if len(q.Tiles) > 0 {
+			choices := make([]Choice, len(q.Choices))
+			for i, c := range q.Choices {
+				c.ImgUrl = ""
+				choices[i] = c
+			}
+			q.Choices = choices
+		}
+	case "truefalse":
+		// whether each statement is true, and other players marked responses, are the answers,
+		// as are the responses scored into the answers of players who have finished
+		hideAnswer(q)
+		statements := make([]Statement, len(q.Statements))
+		for i, s := range q.Statements {
+			s.IsTrue = false
+			statements[i] = s
+		}
+		q.Statements = statements
+		responses := make(map[string][]StatementResponse)
+		if p != nil {
+			if r, exists := q.StatementResponses[p.Username]; exists {
+				responses[p.Username] = r
+			}
+		}
+		q.StatementResponses = responses
+		hideOthersAnswers(q, p, func(a *Answer) {
+			a.Responses = nil
+			a.Answer = "..."
+			a.Comment = ""
+			a.Points = 0
+		})
+	case "reveal":
+		// the image is only available in stages through /api/reveal-image
+		q.ImageUrl = ""
+		hideAnswer(q)
+	case "wordle":
+		// the target word and other players guesses would give the game away
+		hideAnswer(q)
+		guesses := make(map[string][]WordleGuess)
+		if p != nil {
+			if g, exists := q.Guesses[p.Username]; exists {
+				guesses[p.Username] = g
+			}
+		}
+		q.Guesses = guesses
+	case "crossword":
+		// the grid may have been written with the solution in it
+		hideAnswer(q)
+		grid := make([]string, len(q.CrosswordGrid))
+		for i, row := range q.CrosswordGrid {
+			grid[i] = strings.Map(func(r rune) rune {
+				if r == crosswordBlack {
+					return r
+				}
+				return '.'
+			}, row)
+		}
+		q.CrosswordGrid = grid
+		clues := make([]CrosswordClue, len(q.Clues))
+		for i, c := range q.Clues {
+			c.Answer = ""
+			clues[i] = c
+		}
+		q.Clues = clues
+		// other players filled in grids can't be copied
+		hideOthersAnswers(q, p, func(a *Answer) {
+			a.Cells = nil
+		})
+	case "expression":
+		// the example solution and other players workings can't be copied
+		hideAnswer(q)
+		hideOthersAnswers(q, p, func(a *Answer) {
+			a.Answer = "..."
+			a.Comment = ""
+		})
+	case "matching":
+		// each choice names its match and other players links can be copied
+		hideAnswer(q)
+		choices := make([]Choice, len(q.Choices))
+		for i, c := range q.Choices {
+			c.Answer = ""
+			choices[i] = c
+		}
+		q.Choices = choices
+		hideOthersAnswers(q, p, func(a *Answer) {
+			a.Links = nil
+		})
+	case "kazakhstan":
+		// the regions are the answer, the number of targets is still sent
+		if len(q.Regions) > 0 {
+			hideAnswer(q)
+			q.Regions = nil
+			hideOthersAnswers(q, p, func(a *Answer) {
+				a.Clicks = nil
+				a.Answer = "..."
+			})
+		}
+	case "geolocation":
+		// only questions scored by the server can keep their answer secret
+		if q.Projection != "" {
+			hideAnswer(q)
+			q.Location = nil
+			hideOthersAnswers(q, p, func(a *Answer) {
+				a.Clicks = nil
+				a.Location = nil
+				a.Answer = "..."
+			})
+		}
+	case "country":
+		// the ids of the correct shapes are the answer
+		hideAnswer(q)
+		hideOthersAnswers(q, p, func(a *Answer) {
+			a.Clicks = nil
+			a.Country = ""
+			a.Answer = "..."
+			a.Comment = ""
+			a.Points = 0
+		})
+	case "range":
+		// whether another player hit or missed narrows down the truth
+		hideAnswer(q)
+		hideOthersAnswers(q, p, func(a *Answer) {
+			a.Answer = "..."
+			a.Comment = ""
+			a.Points = 0
+			a.Lower, a.Upper = nil, nil
+			a.InRange = false
+		})
+	}
+}
+
+// hideOthersAnswers replaces the answers of the given (copied) question with
+// copies in which the given function has removed whatever would help the
+// given player from every answer but their own
+func hideOthersAnswers(q *Question, p *Player, hide func(a *Answer)) {
+	answers := make([]Answer, len(q.Answers))
+	for i, a := range q.Answers {
+		if p == nil || a.Username != p.Username {
+			hide(&a)
+		}
+		answers[i] = a
+	}
+	q.Answers = answers
+}
+
+// hideAnswer removes the fields which state the answer to a question
+func hideAnswer(q *Question) {
+	q.CorrectAnswers = nil
+	q.HostAnswer = ""
+	q.Link = ""
+}
@@ -0,0 +1,190 @@
+// internal/game/regions.go
+package game
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// HitRegion is an area of a click image which counts as a correct click.
+// A region is either a polygon, given as a list of x,y points in the
+// coordinates of the click image, or an ellipse given by its centre and radii
+type HitRegion struct {
+	Name    string      `json:"name,omitempty"`    // what the region is, eg. Howard Donald
+	Polygon [][]float64 `json:"polygon,omitempty"` // the corners of the polygon as [x,y] pairs
+	Cx      float64     `json:"cx,omitempty"`      // the centre of the ellipse
+	Cy      float64     `json:"cy,omitempty"`
+	Rx      float64     `json:"rx,omitempty"` // the horizontal and vertical radii of the ellipse
+	Ry      float64     `json:"ry,omitempty"`
+}
+
+/**
+* Checks the hit regions of a click question and records how many targets
+* the players must find. Regions which are neither a polygon nor an ellipse
+* are logged and dropped
+ */
+func (q *Question) prepareRegions() {
+	regions := q.Regions[:0]
+	for i, r := range q.Regions {
+		if !r.isPolygon() && (r.Rx <= 0 || r.Ry <= 0) {
+			logger.Warn(fmt.Sprintf("Question %d: hit region %d is neither a polygon of 3 or more [x,y] points nor an ellipse", q.QuestionNumber, i+1))
+			continue
+		}
+		// a region with bad points but a good ellipse is kept as the ellipse
+		if !r.isPolygon() {
+			r.Polygon = nil
+		}
+		regions = append(regions, r)
+	}
+	q.Regions = regions
+	q.Targets = len(q.Regions)
+}
+
+// isPolygon reports whether the region is a polygon of 3 or more [x,y]
+// points rather than an ellipse
+func (r HitRegion) isPolygon() bool {
+	for _, p := range r.Polygon {
+		if len(p) != 2 {
+			return false
+		}
+	}
+	return len(r.Polygon) >= 3
+}
+
+/**
+* Works out how far the given point is from the region
+* @param x the x coordinate of the point
+* @param y the y coordinate of the point
+* @return zero if the point is inside the region otherwise the distance to its edge
+ */
+func (r HitRegion) distance(x float64, y float64) float64 {
+	if !r.isPolygon() {
+		dx, dy := x-r.Cx, y-r.Cy
+		k := math.Hypot(dx/r.Rx, dy/r.Ry)
+		if k <= 1 {
+			return 0
+		}
+		// the distance to the edge measured along the line to the centre
+		return math.Hypot(dx, dy) * (1 - 1/k)
+	}
+	inside := false
+	best := math.Inf(1)
+	n := len(r.Polygon)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		xi, yi := r.Polygon[i][0], r.Polygon[i][1]
+		xj, yj := r.Polygon[j][0], r.Polygon[j][1]
+		// ray casting to find out if we're inside
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+		best = math.Min(best, segmentDistance(x, y, xi, yi, xj, yj))
+	}
+	if inside {
+		return 0
+	}
+	return best
+}
+
+// segmentDistance returns the distance from point p to the line segment a-b
+func segmentDistance(px, py, ax, ay, bx, by float64) float64 {
+	dx, dy := bx-ax, by-ay
+	l := dx*dx + dy*dy
+	t := 0.0
+	if l > 0 {
+		t = math.Max(0, math.Min(1, ((px-ax)*dx+(py-ay)*dy)/l))
+	}
+	return math.Hypot(px-(ax+t*dx), py-(ay+t*dy))
+}
+
+// regionCredit returns the share of a targets points a click at the given
+// distance from the target region earns, decaying if the question allows it
+func (q *Question) regionCredit(distance float64) float64 {
+	if distance == 0 {
+		return 1
+	}
+	if q.RegionDecay <= 0 {
+		return 0
+	}
+	return math.Exp(-distance / q.RegionDecay)
+}
+
+/**
+* Scores a click answer against the hit regions of the question. Clicks
+* inside a region earn full credit for that region and, if RegionDecay is set,
+* clicks outside earn credit falling away with distance. Each click can only
+* count towards one region and each region only once, the best pairs being
+* taken first, so with several targets each must be found separately.
+* Every region is worth an equal share of PointsAvailable
+* @param cq the question being answered
+* @param answer the answer to score
+ */
+func scoreRegions(cq *Question, answer *Answer) {
+	answer.Points = 0
+	if len(answer.Clicks) == 0 {
+		answer.Clicks = nil
+		return
+	}
+	// only as many clicks as there are targets count
+	clicks := make([][]float64, 0, cq.Targets)
+	for _, c := range answer.Clicks {
+		if len(c) == 2 && len(clicks) < cq.Targets {
+			clicks = append(clicks, c)
+		}
+	}
+	answer.Clicks = clicks
+	type pair struct {
+		click, region int
+		credit        float64
+	}
+	var pairs []pair
+	for ci, c := range clicks {
+		for ri, r := range cq.Regions {
+			if credit := cq.regionCredit(r.distance(c[0], c[1])); credit > 0 {
+				pairs = append(pairs, pair{ci, ri, credit})
+			}
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].credit > pairs[j].credit
+	})
+	usedClick := make(map[int]bool)
+	usedRegion := make(map[int]bool)
+	total := 0.0
+	var found []string
+	for _, p := range pairs {
+		if usedClick[p.click] || usedRegion[p.region] {
+			continue
+		}
+		usedClick[p.click], usedRegion[p.region] = true, true
+		total += p.credit
+		if p.credit == 1 {
+			name := cq.Regions[p.region].Name
+			if name == "" {
+				name = fmt.Sprintf("target %d", p.region+1)
+			}
+			found = append(found, name)
+		}
+	}
+	answer.Points = float32(float64(cq.PointsAvailable) * total / float64(len(cq.Regions)))
+	coords := make([]string, len(clicks))
+	for i, c := range clicks {
+		coords[i] = fmt.Sprintf("%.0f - %.0f", c[0], c[1])
+	}
+	answer.Answer = strings.Join(coords, ", ")
+	if len(cq.Regions) == 1 {
+		switch {
+		case len(found) == 1:
+			answer.Comment = "hit"
+		case total > 0:
+			answer.Comment = "close"
+		default:
+			answer.Comment = "missed"
+		}
+		return
+	}
+	answer.Comment = fmt.Sprintf("found %d of %d", len(found), len(cq.Regions))
+}
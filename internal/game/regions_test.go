@@ -0,0 +1,87 @@
+// internal/game/regions_test.go
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// a 10 x 10 square with its corner at the origin and an ellipse centred on 100,100
+var (
+	squareRegion  = HitRegion{Name: "square", Polygon: [][]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	ellipseRegion = HitRegion{Name: "ellipse", Cx: 100, Cy: 100, Rx: 20, Ry: 10}
+)
+
+func TestRegionDistance(t *testing.T) {
+	tests := []struct {
+		region HitRegion
+		x, y   float64
+		want   float64
+	}{
+		{squareRegion, 5, 5, 0},
+		{squareRegion, 0.1, 9.9, 0},
+		{squareRegion, 15, 5, 5},
+		{squareRegion, -2, 5, 2},
+		{squareRegion, 13, 14, 5},
+		{ellipseRegion, 100, 100, 0},
+		{ellipseRegion, 119, 100, 0},
+		{ellipseRegion, 100, 109, 0},
+		{ellipseRegion, 140, 100, 20},
+		{ellipseRegion, 100, 80, 10},
+	}
+	for _, tt := range tests {
+		if got := tt.region.distance(tt.x, tt.y); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s distance to %v,%v = %v, want %v", tt.region.Name, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestPrepareRegions(t *testing.T) {
+	q := &Question{Regions: []HitRegion{
+		squareRegion,
+		ellipseRegion,
+		{Name: "two points", Polygon: [][]float64{{0, 0}, {1, 1}}},
+		{Name: "bad points but an ellipse", Polygon: [][]float64{{0}, {1, 1}, {2, 2}}, Rx: 1, Ry: 1},
+		{Name: "flat ellipse", Rx: 5},
+	}}
+	q.prepareRegions()
+	if q.Targets != 3 || len(q.Regions) != 3 {
+		t.Fatalf("kept %d regions with %d targets, want 3", len(q.Regions), q.Targets)
+	}
+	if r := q.Regions[2]; r.Name != "bad points but an ellipse" || r.Polygon != nil {
+		t.Errorf("the third region kept is %+v, want the ellipse without its points", r)
+	}
+}
+
+func TestScoreRegions(t *testing.T) {
+	tests := []struct {
+		name    string
+		regions []HitRegion
+		decay   float64
+		clicks  [][]float64
+		points  float32
+		comment string
+	}{
+		{"hit", []HitRegion{squareRegion}, 0, [][]float64{{5, 5}}, 10, "hit"},
+		{"missed", []HitRegion{squareRegion}, 0, [][]float64{{15, 5}}, 0, "missed"},
+		{"close", []HitRegion{squareRegion}, 5, [][]float64{{15, 5}}, float32(10 * math.Exp(-1)), "close"},
+		{"extra clicks ignored", []HitRegion{squareRegion}, 0, [][]float64{{15, 5}, {5, 5}}, 0, "missed"},
+		{"bad clicks ignored", []HitRegion{squareRegion}, 0, [][]float64{{5}, {5, 5}}, 10, "hit"},
+		{"both found", []HitRegion{squareRegion, ellipseRegion}, 0, [][]float64{{100, 100}, {5, 5}}, 10, "found 2 of 2"},
+		{"one found twice", []HitRegion{squareRegion, ellipseRegion}, 0, [][]float64{{5, 5}, {6, 6}}, 5, "found 1 of 2"},
+		{"best pairs first", []HitRegion{squareRegion, ellipseRegion}, 5, [][]float64{{15, 5}, {5, 5}}, 5, "found 1 of 2"},
+		{"no clicks", []HitRegion{squareRegion}, 0, nil, 0, ""},
+	}
+	for _, tt := range tests {
+		q := &Question{PointsAvailable: 10, Regions: tt.regions, RegionDecay: tt.decay}
+		q.prepareRegions()
+		a := Answer{Clicks: tt.clicks, Points: 99}
+		scoreRegions(q, &a)
+		if math.Abs(float64(a.Points-tt.points)) > 1e-4 {
+			t.Errorf("%s: scored %v, want %v", tt.name, a.Points, tt.points)
+		}
+		if a.Comment != tt.comment {
+			t.Errorf("%s: comment %q, want %q", tt.name, a.Comment, tt.comment)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// internal/game/reload.go
+package game
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// the file the questions are loaded from and reloaded from when it changes
+var questionsFile = "./questions.json"
+
+// hasBeenPlayed reports whether a question has been started, so that its
+// answers must be kept when the questions are reloaded
+func (q *Question) hasBeenPlayed() bool {
+	return len(q.Answers) > 0 || q.IsTimedOut || !q.TimeStarted.IsZero()
+}
+
+/**
+* Reloads the questions file, or the chosen packs, during a game. The
+* questions are validated first and rejected if there are any problems.
+* Questions which have already been played keep their answers and everything
+* after them is replaced by the questions from the file, including any which
+* were generated during the game. A composed game reads the questions it was
+* composed from again, by their ids, rather than composing a new game
+* @return the problems which stopped the reload, if any, and an error describing why it failed
+ */
+func (gs *GameState) ReloadQuestions() ([]Problem, error) {
+	mu.RLock()
+	packs, composition := gs.Packs, gs.Composition
+	mu.RUnlock()
+	questions, problems, err := loadQuestions(packs)
+	if err != nil {
+		return problems, err
+	}
+	// a composed game reads the questions it was composed from again
+	var recomposed *Composition
+	if composition != nil {
+		c := *composition
+		poolSize := len(questions)
+		if questions, c.Positions, err = composed(questions, c); err != nil {
+			return nil, err
+		}
+		c.PoolSize = poolSize
+		// the next reload looks for the questions as they are now
+		c.Questions = make([]string, len(questions))
+		for i := range questions {
+			c.Questions[i] = questionId(&questions[i])
+		}
+		recomposed = &c
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	current := 0
+	if gs.CurrentQuestion != nil {
+		current = gs.CurrentQuestion.QuestionNumber
+	}
+	// keep everything before the current question, and that too once it has started
+	kept := max(0, current-1)
+	if current > 0 && gs.AllQuestions[current-1].hasBeenPlayed() {
+		kept = current
+	}
+	// when every question has been played there's nothing left to replace, but
+	// the file can't have fewer questions than have been played
+	if kept > len(questions) {
+		return nil, fmt.Errorf("questions file has %d questions but %d have already been played", len(questions), kept)
+	}
+	all := make([]Question, 0, max(kept, len(questions)))
+	all = append(all, gs.AllQuestions[:kept]...)
+	for _, q := range questions[kept:] {
+		q.QuestionNumber = len(all) + 1
+		q.prepare()
+		all = append(all, q)
+	}
+	if len(all) == 0 {
+		return nil, errors.New("there are no questions to play")
+	}
+	gs.AllQuestions = all
+	gs.TotalQuestions = len(all)
+	markSeen(gs)
+	if recomposed != nil {
+		gs.Composition = recomposed
+	}
+	gs.TotalPoints = 0
+	for _, q := range all {
+		gs.TotalPoints += float32(q.PointsAvailable)
+	}
+	gs.CurrentQuestion = &gs.AllQuestions[max(1, current)-1]
+	logger.Info(fmt.Sprintf("questions reloaded, kept %d played questions and there are now %d", kept, gs.TotalQuestions))
+	return nil, nil
+}
+
+// tellHosts messages every admin player, eg. to say why the questions weren't reloaded
+func (gs *GameState) tellHosts(message string) {
+	var hosts []string
+	mu.RLock()
+	for _, p := range gs.Players {
+		if p.IsAdmin {
+			hosts = append(hosts, p.Username)
+		}
+	}
+	mu.RUnlock()
+	// MessagePlayer gets the game, which takes the lock
+	for _, host := range hosts {
+		MessagePlayer(host, message, 15)
+	}
+}
+
+/**
+* Watches the questions file, reloading it whenever it changes. If the new
+* file can't be used the host is told why and the game carries on with the
+* questions it has. Never returns so should be called as a goroutine
+* @param interval how often to look at the file
+ */
+func (gs *GameState) WatchQuestions(interval time.Duration) {
+	var modified time.Time
+	if fi, err := os.Stat(questionsFile); err == nil {
+		modified = fi.ModTime()
+	}
+	for range time.Tick(interval) {
+		fi, err := os.Stat(questionsFile)
+		if err != nil || fi.ModTime().Equal(modified) {
+			continue
+		}
+		modified = fi.ModTime()
+		problems, err := gs.ReloadQuestions()
+		for _, p := range problems {
+			logger.Warn(p.String())
+		}
+		if err != nil {
+			logger.Warn("Questions file changed but was not reloaded", err)
+			gs.tellHosts(fmt.Sprintf("Questions not reloaded: %v", err))
+		} else {
+			gs.tellHosts("Questions reloaded")
+		}
+	}
+}
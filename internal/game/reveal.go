@@ -0,0 +1,66 @@
+// internal/game/reveal.go
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// the number of reveal stages used if the question doesn't specify any
+var defaultRevealStages = 6
+
+// GetRevealStages returns the number of stages a 'reveal' question's image
+// goes through from most obscured to fully revealed
+func (q *Question) GetRevealStages() int {
+	if q.RevealStages < 2 {
+		return defaultRevealStages
+	}
+	return q.RevealStages
+}
+
+/**
+* Works out which reveal stage the image of a 'reveal' question should be at
+* based on how much of the time limit has been used. Stage 0 is the most
+* obscured, the last stage is the full image and is only reached once the
+* question has ended or the final slice of time has begun
+* @return the zero based reveal stage
+ */
+func (q *Question) currentRevealStage() int {
+	n := q.GetRevealStages()
+	if q.IsTimedOut || q.TimeLimit <= 0 {
+		return n - 1
+	}
+	var elapsed float64
+	if !q.TimeStarted.IsZero() {
+		elapsed = time.Since(q.TimeStarted).Seconds()
+	} else if q.TimeLeft > 0 {
+		// paused
+		elapsed = float64(q.TimeLimit - q.TimeLeft)
+	} else {
+		return 0
+	}
+	stage := int(elapsed / float64(q.TimeLimit) * float64(n))
+	return max(0, min(stage, n-1))
+}
+
+/**
+* Scores a 'reveal' answer. Correct answers are worth less the more of the
+* image had been revealed when they were submitted, answering at the first
+* stage earns all of PointsAvailable and at the last stage earns 1/N of it
+* @param cq the question being answered
+* @param answer the answer to score
+ */
+func scoreReveal(cq *Question, answer *Answer) {
+	if answer.Answer == "..." {
+		return
+	}
+	n := cq.GetRevealStages()
+	stage := cq.currentRevealStage()
+	if !isCorrectText(answer.Answer, cq.CorrectAnswers, int(cq.PenalisationFactor)) {
+		answer.Points = 0
+		answer.Comment = fmt.Sprintf("wrong at stage %d of %d", stage+1, n)
+		return
+	}
+	answer.Points = float32(cq.PointsAvailable) * float32(n-stage) / float32(n)
+	answer.Comment = fmt.Sprintf("right at stage %d of %d", stage+1, n)
+}
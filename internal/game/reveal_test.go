@@ -0,0 +1,66 @@
+// internal/game/reveal_test.go
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCurrentRevealStage(t *testing.T) {
+	started := func(seconds int) time.Time { return time.Now().Add(-time.Duration(seconds) * time.Second) }
+	tests := []struct {
+		name     string
+		q        Question
+		want     int
+		wantDone bool
+	}{
+		{"not started", Question{TimeLimit: 60, RevealStages: 4}, 0, false},
+		{"just started", Question{TimeLimit: 60, RevealStages: 4, TimeStarted: started(1)}, 0, false},
+		{"half way", Question{TimeLimit: 60, RevealStages: 4, TimeStarted: started(31)}, 2, false},
+		{"last slice", Question{TimeLimit: 60, RevealStages: 4, TimeStarted: started(50)}, 3, true},
+		{"over time", Question{TimeLimit: 60, RevealStages: 4, TimeStarted: started(90)}, 3, true},
+		{"paused", Question{TimeLimit: 60, RevealStages: 4, TimeLeft: 40}, 1, false},
+		{"timed out", Question{TimeLimit: 60, RevealStages: 4, IsTimedOut: true}, 3, true},
+		{"untimed", Question{RevealStages: 4}, 3, true},
+		{"default stages", Question{TimeLimit: 60, RevealStages: 1, TimeStarted: started(1)}, 0, false},
+	}
+	for _, tt := range tests {
+		got := tt.q.currentRevealStage()
+		if got != tt.want {
+			t.Errorf("%s: stage %d, want %d", tt.name, got, tt.want)
+		}
+		if done := got == tt.q.GetRevealStages()-1; done != tt.wantDone {
+			t.Errorf("%s: fully revealed %v, want %v", tt.name, done, tt.wantDone)
+		}
+	}
+}
+
+func TestScoreReveal(t *testing.T) {
+	started := func(seconds int) time.Time { return time.Now().Add(-time.Duration(seconds) * time.Second) }
+	tests := []struct {
+		name    string
+		answer  string
+		started time.Time
+		points  float32
+		comment string
+	}{
+		{"right at the start", "Eiffel Tower", started(1), 8, "right at stage 1 of 4"},
+		{"right half way", "eiffel tower", started(31), 4, "right at stage 3 of 4"},
+		{"right at the end", "Eiffel Tower", started(55), 2, "right at stage 4 of 4"},
+		{"wrong", "Big Ben", started(1), 0, "wrong at stage 1 of 4"},
+		{"no answer", "...", started(1), 99, ""},
+	}
+	for _, tt := range tests {
+		q := &Question{Type: "reveal", PointsAvailable: 8, TimeLimit: 60, RevealStages: 4, TimeStarted: tt.started,
+			CorrectAnswers: []string{"Eiffel Tower"}}
+		a := Answer{Answer: tt.answer, Points: 99}
+		scoreReveal(q, &a)
+		if math.Abs(float64(a.Points-tt.points)) > 1e-4 {
+			t.Errorf("%s: scored %v, want %v", tt.name, a.Points, tt.points)
+		}
+		if a.Comment != tt.comment {
+			t.Errorf("%s: comment %q, want %q", tt.name, a.Comment, tt.comment)
+		}
+	}
+}
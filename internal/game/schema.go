@@ -0,0 +1,244 @@
+// internal/game/schema.go
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+/*
+SchemaVersion is the version of the questions file this server writes, and the
+newest it can read. Questions files are written as
+
+	{
+	    "schemaVersion": 2,
+	    "questions": [...]
+	}
+
+Version 1 files, written before there was a version, are just the list of
+questions. They can still be played but 1pcc migrate should be used to upgrade
+them, after which they can't be read by older servers
+*/
+const SchemaVersion = 2
+
+// questionsWrapper is how a questions file is written from version 2 on
+type questionsWrapper struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Questions     json.RawMessage `json:"questions"`
+}
+
+// errNewerSchema is returned for a questions file written for a newer server
+var errNewerSchema = errors.New("the questions file is newer than this server")
+
+/*
+migrations upgrade the questions of a file of the version they're keyed by to
+the next version, one step at a time. They work on the fields of each question
+as written so that they can rename fields the Question struct no longer has
+*/
+var migrations = map[int]func(q map[string]json.RawMessage, note func(format string, a ...any)){
+	// version 1 files may have fields in the wrong case, eg. streetview, and
+	// fields which are only worked out during a game such as answers or the
+	// pause state of a question from before pausing was removed
+	1: func(q map[string]json.RawMessage, note func(format string, a ...any)) {
+		tags := questionTagsByLower()
+		for _, f := range append(runtimeFields, "isPaused", "pausedAt", "timeElapsed") {
+			delete(q, f)
+		}
+		for field, v := range q {
+			tag, ok := tags[strings.ToLower(field)]
+			switch {
+			case !ok:
+				note("field %q isn't known and has been left out", field)
+				delete(q, field)
+			case tag != field:
+				delete(q, field)
+				q[tag] = v
+			}
+		}
+	},
+}
+
+// questionTags returns the names of the fields of a question, in the order
+// they're written in the Question struct
+func questionTags() []string {
+	var tags []string
+	t := reflect.TypeOf(Question{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			tags = append(tags, name)
+		}
+	}
+	return tags
+}
+
+// questionTagsByLower returns the names of the fields of a question keyed by
+// the name in lowercase, to find fields written in the wrong case
+func questionTagsByLower() map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range questionTags() {
+		tags[strings.ToLower(tag)] = tag
+	}
+	return tags
+}
+
+/*
+splitQuestions finds the list of questions in a questions file of any version
+returning the version, the list and the offset of the list in the file. It
+returns errNewerSchema if the file is newer than SchemaVersion
+*/
+func splitQuestions(data []byte) (int, []byte, int64, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return 1, data, 0, nil
+	}
+	var f questionsWrapper
+	if err := json.Unmarshal(data, &f); err != nil {
+		return 0, nil, 0, err
+	}
+	switch {
+	case f.SchemaVersion <= 0:
+		return 0, nil, 0, errors.New("there is no schemaVersion")
+	case f.SchemaVersion > SchemaVersion:
+		return f.SchemaVersion, nil, 0, fmt.Errorf("%w, it is schema version %d but only versions up to %d can be read, upgrade 1pcc",
+			errNewerSchema, f.SchemaVersion, SchemaVersion)
+	case f.Questions == nil:
+		return f.SchemaVersion, nil, 0, errors.New("there is no list of questions")
+	}
+	return f.SchemaVersion, f.Questions, int64(bytes.Index(data, f.Questions)), nil
+}
+
+/**
+* Reads the questions of a questions file of any version up to SchemaVersion,
+* as they were written, without checking them
+* @param path the path of the questions file
+* @return the questions or an error if they couldn't be read
+ */
+func ReadQuestionsFile(path string) ([]Question, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	_, list, _, err := splitQuestions(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	var questions []Question
+	if err := json.Unmarshal(list, &questions); err != nil {
+		return nil, fmt.Errorf("%s isn't a list of questions: %w", path, err)
+	}
+	return questions, nil
+}
+
+// marshalQuestions writes the questions as a questions file of the current
+// version, with fields in the order of the Question struct and
+// leaving out those worked out during a game and any which are empty
+func marshalQuestions(questions []Question) ([]byte, error) {
+	list := make([]json.RawMessage, len(questions))
+	for i := range questions {
+		fields, err := questionFields(&questions[i])
+		if err != nil {
+			return nil, err
+		}
+		// the values come from the struct, so choices etc. keep their order too
+		v := reflect.ValueOf(questions[i])
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for j := 0; j < v.NumField(); j++ {
+			tag, _, _ := strings.Cut(v.Type().Field(j).Tag.Get("json"), ",")
+			if _, ok := fields[tag]; !ok {
+				continue
+			}
+			value, err := json.Marshal(v.Field(j).Interface())
+			if err != nil {
+				return nil, err
+			}
+			if buf.Len() > 1 {
+				buf.WriteByte(',')
+			}
+			fmt.Fprintf(&buf, "%q:%s", tag, value)
+		}
+		buf.WriteByte('}')
+		list[i] = buf.Bytes()
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(questionsWrapper{SchemaVersion, data}, "", "    ")
+}
+
+// replaceFile writes data to a new file which then replaces the one at path,
+// so a crash part way through never leaves half a file. The file being
+// replaced is kept with .bak added to its name
+func replaceFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return replaceWith(path, tmp)
+}
+
+// replaceWith replaces the file at path with the new file tmp, keeping the
+// file being replaced with .bak added to its name
+func replaceWith(path string, tmp string) error {
+	if old, err := os.ReadFile(path); err == nil {
+		if err := os.WriteFile(path+".bak", old, 0644); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+	}
+	return os.Rename(tmp, path)
+}
+
+/**
+* Upgrades a questions file written for an older server to SchemaVersion,
+* keeping the old file with .bak added to its name. Files which are already
+* up to date are left alone
+* @param path the path of the questions file
+* @return the version the file was, notes on anything which couldn't be kept and an error if it couldn't be upgraded
+ */
+func MigrateQuestionsFile(path string) (int, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	version, list, _, err := splitQuestions(data)
+	if err != nil {
+		return version, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if version == SchemaVersion {
+		return version, nil, nil
+	}
+	var raws []map[string]json.RawMessage
+	if err := json.Unmarshal(list, &raws); err != nil {
+		return version, nil, fmt.Errorf("%s isn't a list of questions, run '1pcc validate %s' for details", path, path)
+	}
+	var notes []string
+	questions := make([]Question, len(raws))
+	for i, q := range raws {
+		note := func(format string, a ...any) {
+			notes = append(notes, fmt.Sprintf("question %d: ", i+1)+fmt.Sprintf(format, a...))
+		}
+		for v := version; v < SchemaVersion; v++ {
+			migrations[v](q, note)
+		}
+		data, err := json.Marshal(q)
+		if err == nil {
+			err = json.Unmarshal(data, &questions[i])
+		}
+		if err != nil {
+			return version, notes, fmt.Errorf("%s: question %d: %w", path, i+1, err)
+		}
+	}
+	data, err = marshalQuestions(questions)
+	if err != nil {
+		return version, notes, err
+	}
+	return version, notes, replaceFile(path, data)
+}
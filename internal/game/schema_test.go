@@ -0,0 +1,144 @@
+// internal/game/schema_test.go
+package game
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitQuestions(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		version int
+		list    string
+		wantErr string
+	}{
+		{"version 1", ` [{"question": "q"}]`, 1, ` [{"question": "q"}]`, ""},
+		{"version 2", `{"schemaVersion": 2, "questions": [{"question": "q"}]}`, 2, `[{"question": "q"}]`, ""},
+		{"questions first", `{"questions": [], "schemaVersion": 2}`, 2, `[]`, ""},
+		{"newer", `{"schemaVersion": 3, "questions": []}`, 3, "", "the questions file is newer than this server, it is schema version 3 but only versions up to 2 can be read, upgrade 1pcc"},
+		{"no version", `{"questions": []}`, 0, "", "there is no schemaVersion"},
+		{"no questions", `{"schemaVersion": 2}`, 2, "", "there is no list of questions"},
+		{"not json", `{"schemaVersion": 2,`, 0, "", "unexpected end of JSON input"},
+	}
+	for _, tt := range tests {
+		version, list, offset, err := splitQuestions([]byte(tt.file))
+		if version != tt.version {
+			t.Errorf("%s: version %d, want %d", tt.name, version, tt.version)
+		}
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("%s: error %v, want %s", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if string(list) != tt.list || tt.file[offset:offset+int64(len(list))] != tt.list {
+			t.Errorf("%s: list %q at %d, want %q", tt.name, list, offset, tt.list)
+		}
+	}
+	if _, _, _, err := splitQuestions([]byte(`{"schemaVersion": 9, "questions": []}`)); !errors.Is(err, errNewerSchema) {
+		t.Errorf("a newer file gave %v, want errNewerSchema", err)
+	}
+}
+
+func TestMigrateQuestionsFile(t *testing.T) {
+	v1 := `[
+		{"question": "Where is this?", "type": "geolocation", "streetview": "pb=abc", "correctanswers": ["here"], "percent": 50,
+			"answers": [{"username": "alice"}], "isPaused": true, "pausedAt": 10, "timeLeft": 20, "colour": "red"},
+		{"question": "Capital of France?", "type": "multichoice", "category": "geography",
+			"choices": [{"choice": "Paris", "answer": "A"}, {"choice": "Rome", "answer": "B"}], "correctAnswers": ["A"]}
+	]`
+	tests := []struct {
+		name    string
+		file    string
+		version int
+		notes   []string
+		wantErr string
+		changed bool
+	}{
+		{"version 1", v1, 1, []string{`question 1: field "colour" isn't known and has been left out`}, "", true},
+		{"up to date", `{"schemaVersion": 2, "questions": []}`, 2, nil, "", false},
+		{"newer", `{"schemaVersion": 3, "questions": []}`, 3, nil, "the questions file is newer than this server", false},
+		{"not questions", `[{"question": 7}]`, 1, nil, "questions.json: question 1: json: cannot unmarshal number into Go struct field Question.question of type string", false},
+		{"not a list", `[7]`, 1, nil, "questions.json isn't a list of questions, run '1pcc validate questions.json' for details", false},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		t.Chdir(dir)
+		if err := os.WriteFile("questions.json", []byte(tt.file), 0644); err != nil {
+			t.Fatal(err)
+		}
+		version, notes, err := MigrateQuestionsFile("questions.json")
+		if version != tt.version {
+			t.Errorf("%s: version %d, want %d", tt.name, version, tt.version)
+		}
+		if !reflect.DeepEqual(notes, tt.notes) {
+			t.Errorf("%s: notes %q, want %q", tt.name, notes, tt.notes)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) || tt.wantErr == "" && err != nil {
+			t.Errorf("%s: error %v, want %q", tt.name, err, tt.wantErr)
+		}
+		data, _ := os.ReadFile("questions.json")
+		if changed := string(data) != tt.file; changed != tt.changed {
+			t.Errorf("%s: file changed %v, want %v", tt.name, changed, tt.changed)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "questions.json.bak")); (err == nil) != tt.changed {
+			t.Errorf("%s: backup kept %v, want %v", tt.name, err == nil, tt.changed)
+		}
+	}
+}
+
+func TestMigratedQuestions(t *testing.T) {
+	t.Chdir(t.TempDir())
+	v1 := `[{"question": "Where is this?", "type": "geolocation", "streetview": "pb=abc", "correctanswers": ["here"], "percent": 50,
+		"answers": [{"username": "alice"}], "isPaused": true, "timeLeft": 20, "isTimedOut": true}]`
+	if err := os.WriteFile("questions.json", []byte(v1), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := MigrateQuestionsFile("questions.json"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile("questions.json")
+	version, _, _, err := splitQuestions(data)
+	if err != nil || version != SchemaVersion {
+		t.Fatalf("migrated to version %d with %v, want %d", version, err, SchemaVersion)
+	}
+	for _, f := range []string{"streetview", "correctanswers", "answers", "isPaused", "timeLeft", "isTimedOut"} {
+		if strings.Contains(string(data), `"`+f+`"`) {
+			t.Errorf("the migrated file still has %s\n%s", f, data)
+		}
+	}
+	questions, err := ReadQuestionsFile("questions.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Question{{Question: "Where is this?", Type: "geolocation", StreetView: "pb=abc", CorrectAnswers: []string{"here"}, Percent: 50}}
+	if !reflect.DeepEqual(questions, want) {
+		t.Errorf("migrated to\n%+v\nwant\n%+v", questions, want)
+	}
+	// fields are written in the order of the Question struct
+	if strings.Index(string(data), `"question"`) > strings.Index(string(data), `"percent"`) ||
+		strings.Index(string(data), `"percent"`) > strings.Index(string(data), `"streetView"`) {
+		t.Errorf("the fields are out of order\n%s", data)
+	}
+
+	// migrating again leaves the file alone
+	if version, notes, err := MigrateQuestionsFile("questions.json"); version != SchemaVersion || notes != nil || err != nil {
+		t.Errorf("migrating again gave version %d, notes %q and %v", version, notes, err)
+	}
+	if again, _ := os.ReadFile("questions.json"); string(again) != string(data) {
+		t.Errorf("migrating again changed the file")
+	}
+	if bak, _ := os.ReadFile("questions.json.bak"); string(bak) != v1 {
+		t.Errorf("the backup is\n%s\nwant the version 1 file", bak)
+	}
+}
@@ -0,0 +1,189 @@
+// internal/game/sketch.go
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// the width and height of the space drawings are made in, every point of
+// every stroke must lie between 0 and this
+const sketchSize = 1000
+
+// limits on the size of a drawing so that no player can flood the server or screens
+var (
+	maxSketchStrokes = 200
+	maxSketchPoints  = 5000
+)
+
+/**
+* Checks that a drawing sent by a player is within the limits. A drawing is
+* a list of strokes, each stroke being a flat list of x,y coordinates in a
+* sketchSize square
+* @param strokes the drawing
+* @return nil or an error describing why the drawing was rejected
+ */
+func validateSketch(strokes [][]int) error {
+	if len(strokes) == 0 {
+		return fmt.Errorf("the drawing is empty")
+	}
+	if len(strokes) > maxSketchStrokes {
+		return fmt.Errorf("the drawing has %d strokes, the most allowed is %d", len(strokes), maxSketchStrokes)
+	}
+	points := 0
+	for _, s := range strokes {
+		if len(s) < 2 || len(s)%2 != 0 {
+			return fmt.Errorf("each stroke must be a list of x,y pairs")
+		}
+		for _, v := range s {
+			if v < 0 || v > sketchSize {
+				return fmt.Errorf("the drawing goes outside the canvas")
+			}
+		}
+		points += len(s) / 2
+	}
+	if points > maxSketchPoints {
+		return fmt.Errorf("the drawing has %d points, the most allowed is %d", points, maxSketchPoints)
+	}
+	return nil
+}
+
+/**
+* 'sketch' answers are judged by the host so the server only checks the
+* drawing is within the limits, anything else is thrown away, and the points
+* start at zero until the host awards some through JudgeSketch
+* @param cq the question being answered
+* @param answer the answer to score
+ */
+func scoreSketch(cq *Question, answer *Answer) {
+	answer.Points = 0
+	if answer.Strokes == nil {
+		return
+	}
+	if err := validateSketch(answer.Strokes); err != nil {
+		answer.Strokes = nil
+		answer.Answer = "..."
+		answer.Comment = err.Error()
+		return
+	}
+	answer.Answer = "drawing"
+	answer.Comment = "waiting to be judged"
+}
+
+/**
+* Renders a drawing as an SVG document. Each stroke becomes a single path
+* @param strokes the drawing
+* @return the SVG document
+ */
+func SketchSVG(strokes [][]int) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d">`, sketchSize, sketchSize)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="white"/>`, sketchSize, sketchSize)
+	for _, s := range strokes {
+		sb.WriteString(`<path fill="none" stroke="black" stroke-width="8" stroke-linecap="round" stroke-linejoin="round" d="`)
+		for i := 0; i+1 < len(s); i += 2 {
+			cmd := "L"
+			if i == 0 {
+				cmd = "M"
+			}
+			fmt.Fprintf(&sb, "%s%d %d", cmd, s[i], s[i+1])
+		}
+		// a single point is drawn as a dot
+		if len(s) == 2 {
+			fmt.Fprintf(&sb, "L%d %d", s[0], s[1])
+		}
+		sb.WriteString(`"/>`)
+	}
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+/**
+* Finds the drawing a player made for the given question.
+* Players may see their own drawing at any time and anyone else's once the
+* question has finished, admins may see any drawing
+* @param questionNumber the number of the question
+* @param username the player whose drawing is wanted
+* @param p the player asking for the drawing
+* @return the drawing or an error if it doesn't exist or may not be seen
+ */
+func (gs *GameState) GetSketch(questionNumber int, username string, p *Player) ([][]int, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if questionNumber < 1 || questionNumber > len(gs.AllQuestions) {
+		return nil, fmt.Errorf("there is no question %d", questionNumber)
+	}
+	q := &gs.AllQuestions[questionNumber-1]
+	if q.Type != "sketch" {
+		return nil, fmt.Errorf("question %d is not a sketch question", questionNumber)
+	}
+	isCurrent := gs.CurrentQuestion != nil && gs.CurrentQuestion.QuestionNumber == questionNumber
+	if !p.IsAdmin && p.Username != username && isCurrent && !q.IsTimedOut {
+		return nil, fmt.Errorf("drawings can't be seen until the question has finished")
+	}
+	for _, a := range q.Answers {
+		if a.Username == username && a.Strokes != nil {
+			return a.Strokes, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no drawing for question %d", username, questionNumber)
+}
+
+/**
+* Lets the host award points for a drawing on the current 'sketch' question.
+* Judging again replaces the points previously awarded
+* @param username the player whose drawing is being judged
+* @param points the points to award, between zero and the points available
+* @return nil or an error if the drawing couldn't be judged
+ */
+func (gs *GameState) JudgeSketch(username string, points float32) error {
+	mu.Lock()
+	defer mu.Unlock()
+	cq := gs.CurrentQuestion
+	if cq == nil || cq.Type != "sketch" {
+		return fmt.Errorf("the current question is not a sketch question")
+	}
+	if points < 0 || points > float32(cq.PointsAvailable) {
+		return fmt.Errorf("points must be between 0 and %d", cq.PointsAvailable)
+	}
+	for i := range cq.Answers {
+		a := &cq.Answers[i]
+		if a.Username != username || a.Strokes == nil {
+			continue
+		}
+		a.Points = points
+		a.Comment = "judged"
+		sort.Slice(cq.Answers, func(i, j int) bool {
+			return cq.Answers[i].Points > cq.Answers[j].Points
+		})
+		return nil
+	}
+	return fmt.Errorf("%s has no drawing to judge", username)
+}
+
+/**
+* Chooses the drawing shown on the observer screens for the current
+* 'sketch' question, an empty username shows none
+* @param username the player whose drawing should be shown
+* @return nil or an error if the player has no drawing
+ */
+func (gs *GameState) FeatureSketch(username string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	cq := gs.CurrentQuestion
+	if cq == nil || cq.Type != "sketch" {
+		return fmt.Errorf("the current question is not a sketch question")
+	}
+	if username == "" {
+		cq.Featured = ""
+		return nil
+	}
+	for _, a := range cq.Answers {
+		if a.Username == username && a.Strokes != nil {
+			cq.Featured = username
+			return nil
+		}
+	}
+	return fmt.Errorf("%s has no drawing to show", username)
+}
@@ -0,0 +1,90 @@
+// internal/game/sketch_test.go
+package game
+
+import (
+	"testing"
+)
+
+func TestScoreSketch(t *testing.T) {
+	long := make([]int, 2*(maxSketchPoints+1))
+	many := make([][]int, maxSketchStrokes+1)
+	for i := range many {
+		many[i] = []int{1, 1}
+	}
+	tests := []struct {
+		name    string
+		strokes [][]int
+		kept    bool
+		answer  string
+		comment string
+	}{
+		{"a drawing", [][]int{{0, 0, 1000, 1000}, {500, 500}}, true, "drawing", "waiting to be judged"},
+		{"no drawing", nil, false, "", ""},
+		{"empty", [][]int{}, false, "...", "the drawing is empty"},
+		{"an odd stroke", [][]int{{0, 0, 10}}, false, "...", "each stroke must be a list of x,y pairs"},
+		{"an empty stroke", [][]int{{}}, false, "...", "each stroke must be a list of x,y pairs"},
+		{"off the canvas", [][]int{{0, 0, 1001, 10}}, false, "...", "the drawing goes outside the canvas"},
+		{"negative", [][]int{{-1, 0}}, false, "...", "the drawing goes outside the canvas"},
+		{"too many strokes", many, false, "...", "the drawing has 201 strokes, the most allowed is 200"},
+		{"too many points", [][]int{long}, false, "...", "the drawing has 5001 points, the most allowed is 5000"},
+	}
+	for _, tt := range tests {
+		q := &Question{Type: "sketch", PointsAvailable: 10}
+		a := Answer{Strokes: tt.strokes, Points: 99}
+		scoreSketch(q, &a)
+		if a.Points != 0 {
+			t.Errorf("%s: scored %v before being judged", tt.name, a.Points)
+		}
+		if (a.Strokes != nil) != tt.kept {
+			t.Errorf("%s: kept the drawing %v, want %v", tt.name, a.Strokes != nil, tt.kept)
+		}
+		if a.Answer != tt.answer || a.Comment != tt.comment {
+			t.Errorf("%s: %q %q, want %q %q", tt.name, a.Answer, a.Comment, tt.answer, tt.comment)
+		}
+	}
+}
+
+func TestSketchSVG(t *testing.T) {
+	want := `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 1000 1000"><rect width="1000" height="1000" fill="white"/>` +
+		`<path fill="none" stroke="black" stroke-width="8" stroke-linecap="round" stroke-linejoin="round" d="M0 0L10 20L30 40"/>` +
+		`<path fill="none" stroke="black" stroke-width="8" stroke-linecap="round" stroke-linejoin="round" d="M5 5L5 5"/></svg>`
+	if got := SketchSVG([][]int{{0, 0, 10, 20, 30, 40}, {5, 5}}); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestJudgeSketch(t *testing.T) {
+	gs := NewGameState()
+	gs.AllQuestions = []Question{{QuestionNumber: 1, Type: "sketch", PointsAvailable: 10, Answers: []Answer{
+		{Username: "alice", Strokes: [][]int{{1, 1}}},
+		{Username: "bob", Strokes: [][]int{{2, 2}}},
+		{Username: "carol", Answer: "..."},
+	}}}
+	gs.CurrentQuestion = &gs.AllQuestions[0]
+	tests := []struct {
+		name     string
+		username string
+		points   float32
+		wantErr  bool
+	}{
+		{"judged", "bob", 7, false},
+		{"judged again", "bob", 6, false},
+		{"too many points", "alice", 11, true},
+		{"negative points", "alice", -1, true},
+		{"no drawing", "carol", 5, true},
+		{"not playing", "dave", 5, true},
+	}
+	for _, tt := range tests {
+		if err := gs.JudgeSketch(tt.username, tt.points); (err != nil) != tt.wantErr {
+			t.Errorf("%s: error %v, want an error %v", tt.name, err, tt.wantErr)
+		}
+	}
+	if a := gs.CurrentQuestion.Answers[0]; a.Username != "bob" || a.Points != 6 || a.Comment != "judged" {
+		t.Errorf("the top answer is %s with %v %q, want bob with 6 judged", a.Username, a.Points, a.Comment)
+	}
+
+	gs.CurrentQuestion.Type = "freetext"
+	if err := gs.JudgeSketch("alice", 5); err == nil {
+		t.Errorf("judged a drawing on a freetext question")
+	}
+}
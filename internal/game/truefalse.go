@@ -0,0 +1,161 @@
+// internal/game/truefalse.go
+package game
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// Statement is a single assertion in a rapid fire 'truefalse' question.
+// All statements in the question are answered in sequence under the
+// question's single TimeLimit
+type Statement struct {
+	Statement string `json:"statement"` // the text shown to the players
+	IsTrue    bool   `json:"isTrue"`    // whether the statement is actually true
+	Misses    int    `json:"misses"`    // how many players got this statement wrong, populated when the question ends
+}
+
+// StatementResponse records a players response to one Statement along with
+// how far into the question (in seconds) the response was received
+type StatementResponse struct {
+	Index    int     `json:"index"`    // the index of the statement in Question.Statements
+	Response bool    `json:"response"` // true if the player said the statement was true
+	Correct  bool    `json:"correct"`  // true if the response matched Statement.IsTrue
+	Seconds  float64 `json:"seconds"`  // seconds elapsed since the question started
+}
+
+/**
+* Records the given players response to the statement at the given index of
+* the current 'truefalse' question. Statements must be answered in order.
+* Once the player has responded to every statement their Answer is scored and
+* submitted on their behalf.
+* @param username the player responding
+* @param index the index of the statement being responded to
+* @param response true if the player thinks the statement is true
+* @return an error if the response could not be recorded
+ */
+func (gs *GameState) SubmitStatement(username string, index int, response bool) error {
+	mu.Lock()
+	cq := gs.CurrentQuestion
+	if cq == nil || cq.Type != "truefalse" {
+		mu.Unlock()
+		return fmt.Errorf("the current question is not a true or false question")
+	}
+	if cq.TimeStarted.IsZero() || cq.IsTimedOut {
+		mu.Unlock()
+		return fmt.Errorf("the question is not running")
+	}
+	if _, exists := gs.Players[username]; !exists {
+		mu.Unlock()
+		return fmt.Errorf("player %s doesn't exist in game state", username)
+	}
+	for _, a := range cq.Answers {
+		if a.Username == username {
+			mu.Unlock()
+			return fmt.Errorf("player %s has already answered", username)
+		}
+	}
+	if cq.StatementResponses == nil {
+		cq.StatementResponses = make(map[string][]StatementResponse)
+	}
+	responses := cq.StatementResponses[username]
+	if index != len(responses) || index >= len(cq.Statements) {
+		mu.Unlock()
+		return fmt.Errorf("expected a response to statement %d", len(responses))
+	}
+	responses = append(responses, StatementResponse{
+		Index:    index,
+		Response: response,
+		Correct:  response == cq.Statements[index].IsTrue,
+		Seconds:  time.Since(cq.TimeStarted).Seconds(),
+	})
+	cq.StatementResponses[username] = responses
+	var message string
+	if len(responses) == len(cq.Statements) {
+		message = gs.addAnswer(forfeit(username, cq))
+	}
+	mu.Unlock()
+	if message != "" {
+		MessagePlayer(username, message, 20)
+	}
+	return nil
+}
+
+/**
+* Scores a 'truefalse' answer using the responses recorded by the server
+* rather than anything the client sent. Each correct statement is worth an
+* equal share of PointsAvailable and every correct response which extends a
+* run of correct responses earns an additional StreakBonus
+* @param cq the question being answered
+* @param answer the answer to score
+ */
+func scoreTrueFalse(cq *Question, answer *Answer) {
+	responses := cq.StatementResponses[answer.Username]
+	answer.Responses = responses
+	if len(cq.Statements) == 0 {
+		return
+	}
+	perStatement := float32(cq.PointsAvailable) / float32(len(cq.Statements))
+	var points float32 = 0.0
+	correct, streak, bestStreak := 0, 0, 0
+	for _, r := range responses {
+		if !r.Correct {
+			streak = 0
+			continue
+		}
+		correct++
+		streak++
+		points += perStatement
+		if streak > 1 {
+			points += cq.StreakBonus
+		}
+		bestStreak = max(bestStreak, streak)
+	}
+	answer.Points = points
+	answer.Answer = fmt.Sprintf("%d / %d", correct, len(cq.Statements))
+	answer.Comment = fmt.Sprintf("best streak %d", bestStreak)
+}
+
+/**
+* Called when a 'truefalse' question ends. Any player who ran out of time
+* part way through the statements is given an answer for the statements they
+* did respond to, then Statement.Misses is tallied so that the reveal can show
+* which statements caught most people out.
+* Expects the caller to hold the game state lock
+* @param cq the question which has ended
+ */
+func finaliseTrueFalse(cq *Question) {
+	answered := make(map[string]bool)
+	for _, a := range cq.Answers {
+		answered[a.Username] = true
+	}
+	for username, responses := range cq.StatementResponses {
+		if answered[username] || len(responses) == 0 {
+			continue
+		}
+		answer := Answer{
+			Username:       username,
+			QuestionNumber: cq.QuestionNumber,
+		}
+		scoreTrueFalse(cq, &answer)
+		cq.Answers = append(cq.Answers, answer)
+		logger.Info("Submitted partial true or false answer for", username)
+	}
+	sort.Slice(cq.Answers, func(i, j int) bool {
+		return cq.Answers[i].Points > cq.Answers[j].Points
+	})
+
+	for i := range cq.Statements {
+		cq.Statements[i].Misses = 0
+	}
+	for _, responses := range cq.StatementResponses {
+		for _, r := range responses {
+			if !r.Correct && r.Index < len(cq.Statements) {
+				cq.Statements[r.Index].Misses++
+			}
+		}
+	}
+}
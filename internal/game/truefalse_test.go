@@ -0,0 +1,73 @@
+// internal/game/truefalse_test.go
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// responses turns a list of right and wrong into the responses a player gave
+func responses(correct ...bool) []StatementResponse {
+	var rs []StatementResponse
+	for i, c := range correct {
+		rs = append(rs, StatementResponse{Index: i, Correct: c})
+	}
+	return rs
+}
+
+func TestScoreTrueFalse(t *testing.T) {
+	statements := []Statement{{Statement: "a", IsTrue: true}, {Statement: "b"}, {Statement: "c", IsTrue: true}, {Statement: "d"}}
+	tests := []struct {
+		name      string
+		responses []StatementResponse
+		bonus     float32
+		points    float32
+		answer    string
+		comment   string
+	}{
+		{"all right", responses(true, true, true, true), 0, 8, "4 / 4", "best streak 4"},
+		{"all right with a bonus", responses(true, true, true, true), 0.5, 9.5, "4 / 4", "best streak 4"},
+		{"a streak broken", responses(true, false, true, true), 0.5, 6.5, "3 / 4", "best streak 2"},
+		{"no streak", responses(true, false, true, false), 0.5, 4, "2 / 4", "best streak 1"},
+		{"all wrong", responses(false, false, false, false), 0.5, 0, "0 / 4", "best streak 0"},
+		{"ran out of time", responses(true, true), 0.5, 4.5, "2 / 4", "best streak 2"},
+		{"no responses", nil, 0.5, 0, "0 / 4", "best streak 0"},
+	}
+	for _, tt := range tests {
+		q := &Question{Type: "truefalse", PointsAvailable: 8, StreakBonus: tt.bonus, Statements: statements,
+			StatementResponses: map[string][]StatementResponse{"alice": tt.responses}}
+		a := Answer{Username: "alice", Points: 99}
+		scoreTrueFalse(q, &a)
+		if math.Abs(float64(a.Points-tt.points)) > 1e-4 {
+			t.Errorf("%s: scored %v, want %v", tt.name, a.Points, tt.points)
+		}
+		if a.Answer != tt.answer || a.Comment != tt.comment {
+			t.Errorf("%s: %q %q, want %q %q", tt.name, a.Answer, a.Comment, tt.answer, tt.comment)
+		}
+		if len(a.Responses) != len(tt.responses) {
+			t.Errorf("%s: kept %d responses, want %d", tt.name, len(a.Responses), len(tt.responses))
+		}
+	}
+}
+
+func TestFinaliseTrueFalse(t *testing.T) {
+	q := &Question{Type: "truefalse", QuestionNumber: 3, PointsAvailable: 2,
+		Statements: []Statement{{Statement: "a", IsTrue: true}, {Statement: "b"}},
+		StatementResponses: map[string][]StatementResponse{
+			"alice": responses(true, false),
+			"bob":   responses(false),
+			"carol": nil,
+		},
+		Answers: []Answer{{Username: "alice", Points: 1}},
+	}
+	finaliseTrueFalse(q)
+	if len(q.Answers) != 2 {
+		t.Fatalf("%d answers, want alice's and a partial one for bob", len(q.Answers))
+	}
+	if a := q.Answers[1]; a.Username != "bob" || a.QuestionNumber != 3 || a.Answer != "0 / 2" {
+		t.Errorf("bob's partial answer is %+v", a)
+	}
+	if q.Statements[0].Misses != 1 || q.Statements[1].Misses != 1 {
+		t.Errorf("misses are %d and %d, want 1 and 1", q.Statements[0].Misses, q.Statements[1].Misses)
+	}
+}
@@ -0,0 +1,270 @@
+// internal/game/validate.go
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/imaging"
+)
+
+// the question types the server and client know how to ask
+var questionTypes = map[string]bool{
+	"multichoice": true,
+	"freetext":    true,
+	"gridimage":   true,
+	"kazakhstan":  true,
+	"geolocation": true,
+	"country":     true,
+	"truefalse":   true,
+	"reveal":      true,
+	"wordle":      true,
+	"crossword":   true,
+	"expression":  true,
+	"matching":    true,
+	"range":       true,
+	"sketch":      true,
+}
+
+// the field of each question type that its own problems are reported against
+var typeFields = map[string]string{
+	"crossword": "clues",
+	"matching":  "choices",
+	"range":     "correctAnswers",
+	"country":   "correctAnswers",
+}
+
+// Problem is something wrong with a questions file
+type Problem struct {
+	File     string
+	Line     int
+	Question int // the number of the question, zero if the problem isn't with one question
+	Message  string
+	Warning  bool // true if the questions can still be played, eg. a field that isn't known
+}
+
+func (p Problem) String() string {
+	msg := p.Message
+	if p.Warning {
+		msg = "warning: " + msg
+	}
+	if p.Question == 0 {
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, msg)
+	}
+	return fmt.Sprintf("%s:%d: question %d: %s", p.File, p.Line, p.Question, msg)
+}
+
+// lineAt returns the 1-based line number of the given offset into data
+func lineAt(data []byte, offset int64) int {
+	offset = max(0, min(offset, int64(len(data))))
+	return 1 + bytes.Count(data[:offset], []byte("\n"))
+}
+
+/**
+* Checks a questions file for the mistakes which would otherwise only be
+* found on the night: JSON errors, old or unknown schema versions, misspelt
+* fields, unknown types, correct answers which aren't one of the choices,
+* missing images, grids which don't fit the choices, crossword clues which
+* don't fit the grid, unmatched pairs, range answers which aren't numbers,
+* countries which aren't on the map, no time limit and percentages which
+* don't fall through a round
+* @param path the path of the questions file
+* @return the problems found, the number of questions and an error if the file couldn't be read
+ */
+func ValidateQuestions(path string) ([]Problem, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	dir := filepath.Dir(path)
+	var problems []Problem
+	add := func(line int, question int, format string, a ...any) {
+		problems = append(problems, Problem{path, line, question, fmt.Sprintf(format, a...), false})
+	}
+	warn := func(line int, question int, format string, a ...any) {
+		problems = append(problems, Problem{path, line, question, fmt.Sprintf(format, a...), true})
+	}
+
+	// find the list of questions, which is the whole of a version 1 file
+	version, list, listOffset, err := splitQuestions(data)
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			add(lineAt(data, syntaxErr.Offset), 0, "%s", err.Error())
+		case errors.As(err, &typeErr):
+			add(lineAt(data, typeErr.Offset), 0, "the file must be a list of questions or have schemaVersion and questions")
+		case errors.Is(err, errNewerSchema):
+			add(lineAt(data, int64(bytes.Index(data, []byte(`"schemaVersion"`)))), 0, "%s", err.Error())
+		default:
+			add(1, 0, "%s", err.Error())
+		}
+		return problems, 0, nil
+	}
+	if version < SchemaVersion {
+		warn(1, 0, "the file is schema version %d, run '1pcc migrate %s' to upgrade it to version %d", version, path, SchemaVersion)
+	}
+
+	// find where each question starts so problems can be reported by line
+	var raws []json.RawMessage
+	if err := json.Unmarshal(list, &raws); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			add(lineAt(data, listOffset+syntaxErr.Offset), 0, "%s", err.Error())
+		case errors.As(err, &typeErr):
+			add(lineAt(data, listOffset+typeErr.Offset), 0, "questions must be a list of questions")
+		default:
+			add(1, 0, "%s", err.Error())
+		}
+		return problems, 0, nil
+	}
+	starts := make([]int64, len(raws))
+	dec := json.NewDecoder(bytes.NewReader(list))
+	dec.Token()
+	for i := 0; dec.More(); i++ {
+		start := dec.InputOffset()
+		var raw json.RawMessage
+		if dec.Decode(&raw) != nil {
+			break
+		}
+		starts[i] = listOffset + start + int64(bytes.Index(list[start:], raw))
+	}
+	tags := questionTagsByLower()
+
+	// the number and percent of the last question in each category, which the
+	// percent of the next question in the category shouldn't be higher than
+	type lastQuestion struct{ number, percent int }
+	last := make(map[string]lastQuestion)
+	for i, raw := range raws {
+		n := i + 1
+		line := lineAt(data, starts[i])
+		// the line of the given field of this question, or of the question itself
+		fieldLine := func(field string) int {
+			if at := bytes.Index(raw, []byte(`"`+field+`"`)); at >= 0 {
+				return lineAt(data, starts[i]+int64(at))
+			}
+			return line
+		}
+		var q Question
+		if err := json.Unmarshal(raw, &q); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				add(lineAt(data, starts[i]+typeErr.Offset), n, "%s should be %s not %s", typeErr.Field, typeErr.Type, typeErr.Value)
+			} else {
+				add(line, n, "%s", err.Error())
+			}
+			continue
+		}
+
+		// unknown fields are most likely misspelt. Fields in the wrong case are
+		// still read, and are put right by migrating a file of an older version
+		var fields map[string]json.RawMessage
+		json.Unmarshal(raw, &fields)
+		names := make([]string, 0, len(fields))
+		for field := range fields {
+			names = append(names, field)
+		}
+		sort.Strings(names)
+		for _, field := range names {
+			if tag, ok := tags[strings.ToLower(field)]; !ok {
+				warn(fieldLine(field), n, "field %q isn't known and will be ignored", field)
+			} else if tag != field && version == SchemaVersion {
+				warn(fieldLine(field), n, "field %q should be written %q", field, tag)
+			}
+		}
+		if !questionTypes[q.Type] {
+			add(fieldLine("type"), n, "unknown type %q", q.Type)
+		}
+		if q.Type == "multichoice" || q.Type == "gridimage" {
+			answers := make(map[string]bool)
+			for _, c := range q.Choices {
+				answers[c.Answer] = true
+			}
+			if len(q.CorrectAnswers) == 0 {
+				add(fieldLine("correctAnswers"), n, "there are no correctAnswers")
+			}
+			for _, ca := range q.CorrectAnswers {
+				if !answers[ca] {
+					add(fieldLine("correctAnswers"), n, "correct answer %q isn't the answer of any choice", ca)
+				}
+			}
+		}
+		if q.Type == "gridimage" {
+			rows, cols := q.getGrid()
+			if rows*cols != len(q.Choices) {
+				add(fieldLine("grid"), n, "the %d x %d grid has %d cells but there are %d choices", rows, cols, rows*cols, len(q.Choices))
+			}
+		}
+		// the same checks that are logged when the questions are loaded
+		var typeProblems []string
+		switch q.Type {
+		case "crossword":
+			typeProblems = q.placeClues()
+		case "matching":
+			typeProblems = q.matchingProblems()
+		case "range":
+			if _, err := q.rangeTruth(); err != nil {
+				typeProblems = []string{err.Error()}
+			}
+		case "country":
+			if local := localFile(dir, q.ClickImage); local == "" {
+				typeProblems = []string{"there is no clickImage holding the countries"}
+			} else if _, err := os.Stat(local); err == nil {
+				typeProblems = q.countryProblems(local)
+			}
+		}
+		for _, p := range typeProblems {
+			add(fieldLine(typeFields[q.Type]), n, "%s", p)
+		}
+		files := []struct{ field, url string }{
+			{"imageUrl", q.ImageUrl},
+			{"clickImage", q.ClickImage},
+			{"answerImage", q.AnswerImage},
+			{"panorama", q.Panorama},
+		}
+		for _, c := range q.Choices {
+			files = append(files, struct{ field, url string }{"imgUrl", c.ImgUrl})
+		}
+		for _, f := range files {
+			local := localFile(dir, f.url)
+			if local == "" {
+				continue
+			}
+			if _, err := os.Stat(local); err != nil {
+				add(fieldLine(f.field), n, "%s %s doesn't exist", f.field, f.url)
+			}
+		}
+		if q.TimeLimit <= 0 {
+			add(fieldLine("timeLimit"), n, "there is no timeLimit")
+		}
+		if q.Percent < 0 || q.Percent > 100 {
+			add(fieldLine("percent"), n, "percent %d isn't between 0 and 100", q.Percent)
+		} else if before, exists := last[q.Category]; exists && q.Percent > before.percent {
+			add(fieldLine("percent"), n, "percent %d is higher than the %d of question %d, the one before in the %q round",
+				q.Percent, before.percent, before.number, q.Category)
+		}
+		last[q.Category] = lastQuestion{n, q.Percent}
+	}
+	return problems, len(raws), nil
+}
+
+// localFile returns the path of the file a url in a questions file in the
+// given directory refers to, or "" if it isn't a local file. Urls starting
+// with / are relative to the directory the server runs in, others (eg. in
+// packs) to the directory holding the questions
+func localFile(dir string, url string) string {
+	local := imaging.LocalPath(url)
+	if local != "" && !strings.HasPrefix(url, "/") {
+		local = filepath.Join(dir, local)
+	}
+	return local
+}
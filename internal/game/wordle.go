@@ -0,0 +1,257 @@
+// internal/game/wordle.go
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// the number of guesses allowed if the question doesn't specify
+var defaultMaxGuesses = 6
+
+// WordleGuess is a single guess at the target word of a 'wordle' question
+// along with the feedback the server gave for it
+type WordleGuess struct {
+	Guess    string   `json:"guess"`    // the word guessed, lower case
+	Feedback []string `json:"feedback"` // "green", "yellow" or "grey" for each letter of the guess
+	Seconds  float64  `json:"seconds"`  // seconds elapsed since the question started
+}
+
+// cache of loaded dictionaries keyed by file path
+var (
+	dictionaries   = make(map[string]map[string]bool)
+	dictionariesMu sync.Mutex
+)
+
+// GetMaxGuesses returns the number of guesses each player is allowed
+func (q *Question) GetMaxGuesses() int {
+	if q.MaxGuesses < 1 {
+		return defaultMaxGuesses
+	}
+	return q.MaxGuesses
+}
+
+// wordleTarget returns the lower case target word of a 'wordle' question
+func (q *Question) wordleTarget() string {
+	if len(q.CorrectAnswers) == 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(q.CorrectAnswers[0]))
+}
+
+/**
+* Checks the given guess at the target word of the current 'wordle' question
+* and records it against the player. When the player either finds the word
+* or runs out of guesses their Answer is scored and submitted on their behalf.
+* @param username the player guessing
+* @param guess the guessed word
+* @return the guess with its per letter feedback or an error if the guess was rejected
+ */
+func (gs *GameState) SubmitGuess(username string, guess string) (WordleGuess, error) {
+	guess = strings.ToLower(strings.TrimSpace(guess))
+	mu.Lock()
+	cq := gs.CurrentQuestion
+	if cq == nil || cq.Type != "wordle" {
+		mu.Unlock()
+		return WordleGuess{}, fmt.Errorf("the current question is not a wordle question")
+	}
+	if cq.TimeStarted.IsZero() || cq.IsTimedOut {
+		mu.Unlock()
+		return WordleGuess{}, fmt.Errorf("the question is not running")
+	}
+	if _, exists := gs.Players[username]; !exists {
+		mu.Unlock()
+		return WordleGuess{}, fmt.Errorf("player %s doesn't exist in game state", username)
+	}
+	for _, a := range cq.Answers {
+		if a.Username == username {
+			mu.Unlock()
+			return WordleGuess{}, fmt.Errorf("you have already finished")
+		}
+	}
+	target := cq.wordleTarget()
+	if len([]rune(guess)) != len([]rune(target)) {
+		mu.Unlock()
+		return WordleGuess{}, fmt.Errorf("guesses must have %d letters", len([]rune(target)))
+	}
+	for _, r := range guess {
+		if !unicode.IsLetter(r) {
+			mu.Unlock()
+			return WordleGuess{}, fmt.Errorf("guesses may only contain letters")
+		}
+	}
+	if !isInDictionary(cq.Dictionary, guess) && guess != target {
+		mu.Unlock()
+		return WordleGuess{}, fmt.Errorf("%s is not in the word list", guess)
+	}
+	if cq.Guesses == nil {
+		cq.Guesses = make(map[string][]WordleGuess)
+	}
+	guesses := cq.Guesses[username]
+	if len(guesses) >= cq.GetMaxGuesses() {
+		mu.Unlock()
+		return WordleGuess{}, fmt.Errorf("you have no guesses left")
+	}
+	ret := WordleGuess{
+		Guess:    guess,
+		Feedback: wordleFeedback(target, guess),
+		Seconds:  time.Since(cq.TimeStarted).Seconds(),
+	}
+	guesses = append(guesses, ret)
+	cq.Guesses[username] = guesses
+	var message string
+	if guess == target || len(guesses) >= cq.GetMaxGuesses() {
+		message = gs.addAnswer(forfeit(username, cq))
+	}
+	mu.Unlock()
+	if message != "" {
+		MessagePlayer(username, message, 20)
+	}
+	return ret, nil
+}
+
+/**
+* Works out the wordle style feedback for a guess. Letters in the right place
+* are green, letters elsewhere in the word are yellow (but only as many times
+* as they appear in the target) and everything else is grey
+* @param target the target word
+* @param guess the guessed word, the same length as the target
+* @return the colour of each letter of the guess
+ */
+func wordleFeedback(target string, guess string) []string {
+	t, g := []rune(target), []rune(guess)
+	ret := make([]string, len(g))
+	remaining := make(map[rune]int)
+	for i := range g {
+		if g[i] == t[i] {
+			ret[i] = "green"
+		} else {
+			remaining[t[i]]++
+		}
+	}
+	for i := range g {
+		if ret[i] != "" {
+			continue
+		}
+		if remaining[g[i]] > 0 {
+			ret[i] = "yellow"
+			remaining[g[i]]--
+		} else {
+			ret[i] = "grey"
+		}
+	}
+	return ret
+}
+
+/**
+* Determines whether the given word is in the dictionary file at the given path.
+* Dictionary files hold one word per line and are loaded once then cached.
+* If no dictionary is given, or it can't be read, any word is accepted
+* @param path the path of the dictionary file
+* @param word the lower case word to look up
+* @return true if the word is acceptable
+ */
+func isInDictionary(path string, word string) bool {
+	if path == "" {
+		return true
+	}
+	dictionariesMu.Lock()
+	defer dictionariesMu.Unlock()
+	words, exists := dictionaries[path]
+	if !exists {
+		words = loadDictionary(path)
+		dictionaries[path] = words
+	}
+	if words == nil {
+		return true
+	}
+	return words[word]
+}
+
+// loadDictionary reads a one word per line dictionary file
+// returning nil if the file can't be read
+func loadDictionary(path string) map[string]bool {
+	f, err := os.Open(path)
+	if err != nil {
+		logger.Warn("Failed to open dictionary, accepting any word", path, err)
+		return nil
+	}
+	defer f.Close()
+	words := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		w := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if w != "" {
+			words[w] = true
+		}
+	}
+	logger.Info(fmt.Sprintf("Loaded %d words from %s", len(words), path))
+	return words
+}
+
+/**
+* Scores a 'wordle' answer from the guesses recorded by the server.
+* Finding the word with the first guess is worth all of PointsAvailable with
+* each further guess worth proportionally less. That is then scaled between
+* 100% and 50% depending on how much of the time limit had been used
+* @param cq the question being answered
+* @param answer the answer to score
+ */
+func scoreWordle(cq *Question, answer *Answer) {
+	guesses := cq.Guesses[answer.Username]
+	answer.Points = 0
+	if len(guesses) == 0 {
+		return
+	}
+	last := guesses[len(guesses)-1]
+	n := cq.GetMaxGuesses()
+	if last.Guess != cq.wordleTarget() {
+		answer.Answer = "not found"
+		answer.Comment = fmt.Sprintf("%d guesses", len(guesses))
+		return
+	}
+	guessFactor := float32(n-len(guesses)+1) / float32(n)
+	timeFactor := float32(1.0)
+	if cq.TimeLimit > 0 {
+		used := min(1.0, last.Seconds/float64(cq.TimeLimit))
+		timeFactor = float32(1.0 - 0.5*used)
+	}
+	answer.Points = float32(cq.PointsAvailable) * guessFactor * timeFactor
+	answer.Answer = fmt.Sprintf("solved in %d", len(guesses))
+	answer.Comment = fmt.Sprintf("%d seconds", int(last.Seconds))
+}
+
+/**
+* Called when a 'wordle' question ends, gives anyone who made guesses but
+* didn't finish an answer so that they show up in the results.
+* Expects the caller to hold the game state lock
+* @param cq the question which has ended
+ */
+func finaliseWordle(cq *Question) {
+	answered := make(map[string]bool)
+	for _, a := range cq.Answers {
+		answered[a.Username] = true
+	}
+	for username, guesses := range cq.Guesses {
+		if answered[username] || len(guesses) == 0 {
+			continue
+		}
+		answer := Answer{
+			Username:       username,
+			QuestionNumber: cq.QuestionNumber,
+		}
+		scoreWordle(cq, &answer)
+		cq.Answers = append(cq.Answers, answer)
+	}
+	sort.Slice(cq.Answers, func(i, j int) bool {
+		return cq.Answers[i].Points > cq.Answers[j].Points
+	})
+}
@@ -0,0 +1,92 @@
+// internal/game/wordle_test.go
+package game
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWordleFeedback(t *testing.T) {
+	tests := []struct {
+		target, guess string
+		want          string
+	}{
+		{"crane", "crane", "green green green green green"},
+		{"crane", "nacre", "yellow yellow yellow yellow green"},
+		{"crane", "split", "grey grey grey grey grey"},
+		// only as many yellows as the target has of that letter
+		{"crane", "eerie", "grey grey yellow grey green"},
+		{"abbey", "bobby", "yellow grey green grey green"},
+		// a green takes the letter before any yellow can
+		{"world", "lolly", "grey green grey green grey"},
+	}
+	for _, tt := range tests {
+		if got := wordleFeedback(tt.target, tt.guess); !reflect.DeepEqual(got, strings.Fields(tt.want)) {
+			t.Errorf("%s guessing %s: %v, want %v", tt.target, tt.guess, got, tt.want)
+		}
+	}
+}
+
+func TestScoreWordle(t *testing.T) {
+	guesses := func(seconds float64, words ...string) []WordleGuess {
+		var gs []WordleGuess
+		for _, w := range words {
+			gs = append(gs, WordleGuess{Guess: w, Seconds: seconds})
+		}
+		return gs
+	}
+	tests := []struct {
+		name      string
+		timeLimit int
+		guesses   []WordleGuess
+		points    float32
+		answer    string
+		comment   string
+	}{
+		{"first guess", 0, guesses(10, "crane"), 12, "solved in 1", "10 seconds"},
+		{"third guess", 0, guesses(10, "split", "eerie", "crane"), 8, "solved in 3", "10 seconds"},
+		{"last guess", 0, guesses(10, "a", "b", "c", "d", "e", "crane"), 2, "solved in 6", "10 seconds"},
+		{"half the time used", 60, guesses(30, "crane"), 9, "solved in 1", "30 seconds"},
+		{"all the time used", 60, guesses(90, "crane"), 6, "solved in 1", "90 seconds"},
+		{"not found", 60, guesses(30, "split", "eerie"), 0, "not found", "2 guesses"},
+		{"no guesses", 60, nil, 0, "", ""},
+	}
+	for _, tt := range tests {
+		q := &Question{Type: "wordle", PointsAvailable: 12, TimeLimit: tt.timeLimit, CorrectAnswers: []string{" Crane"},
+			Guesses: map[string][]WordleGuess{"alice": tt.guesses}}
+		a := Answer{Username: "alice", Points: 99}
+		scoreWordle(q, &a)
+		if math.Abs(float64(a.Points-tt.points)) > 1e-4 {
+			t.Errorf("%s: scored %v, want %v", tt.name, a.Points, tt.points)
+		}
+		if a.Answer != tt.answer || a.Comment != tt.comment {
+			t.Errorf("%s: %q %q, want %q %q", tt.name, a.Answer, a.Comment, tt.answer, tt.comment)
+		}
+	}
+}
+
+func TestIsInDictionary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte("Crane\n  split \n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path, word string
+		want       bool
+	}{
+		{path, "crane", true},
+		{path, "split", true},
+		{path, "eerie", false},
+		{"", "eerie", true},
+		{filepath.Join(t.TempDir(), "missing.txt"), "eerie", true},
+	}
+	for _, tt := range tests {
+		if got := isInDictionary(tt.path, tt.word); got != tt.want {
+			t.Errorf("%s in %q: %v, want %v", tt.word, tt.path, got, tt.want)
+		}
+	}
+}
@@ -18,6 +18,9 @@ import (
 	"github.com/richard-senior/1pcc/internal/session"
 )
 
+// the largest answer body accepted from a player
+const maxAnswerBytes = 256 << 10
+
 /*
 api.go is given a path of /api/... such that it handles
 all requests to /api/... and all sub paths
@@ -45,6 +48,44 @@ func HandleAPI(w http.ResponseWriter, r *http.Request) {
 		handleGetLeaderboard(w, r)
 	case "/api/submit-answer":
 		handleSubmitAnswer(w, r)
+	case "/api/submit-statement":
+		handleSubmitStatement(w, r)
+	case "/api/reveal-image":
+		handleRevealImage(w, r)
+	case "/api/submit-guess":
+		handleSubmitGuess(w, r)
+	case "/api/sketch":
+		handleSketch(w, r)
+	case "/api/sketches":
+		handleSketches(w, r)
+	case "/api/generate-questions":
+		handleGenerateQuestions(w, r)
+	case "/api/reload-questions":
+		handleReloadQuestions(w, r)
+	case "/api/packs":
+		handlePacks(w, r)
+	case "/api/select-packs":
+		handleSelectPacks(w, r)
+	case "/api/compose-game":
+		handleComposeGame(w, r)
+	case "/api/import-csv":
+		handleImportCSV(w, r)
+	case "/api/export-csv":
+		handleExportCSV(w, r)
+	case "/api/questions":
+		handleQuestions(w, r)
+	case "/api/reorder-questions":
+		handleReorderQuestions(w, r)
+	case "/api/upload-asset":
+		handleUploadAsset(w, r)
+	case "/api/grid-tile":
+		handleGridTile(w, r)
+	case "/api/flag":
+		handleFlag(w, r)
+	case "/api/panorama":
+		handlePanorama(w, r)
+	case "/api/panorama-tile":
+		handlePanoramaTile(w, r)
 	case "/api/previous-question":
 		handlePreviousQuestion(w, r)
 	case "/api/next-question":
@@ -85,11 +126,10 @@ Output:
   - On error: 500 status code with error message
 */
 func handleGameState(w http.ResponseWriter, r *http.Request) {
-	// get the game state from the game singleton
-	state := game.GetGame()
-	// populate some extra fields from session etc.
+	// get a copy of the game state from the game singleton
+	// which is safe to give to the user making the request
 	p := session.GetMe(r)
-	state.CurrentUser = p
+	state := game.GetGame().Redacted(p)
 
 	// Encode the state as JSON and send it back
 	err := json.NewEncoder(w).Encode(state)
@@ -218,6 +258,8 @@ We add that answer to the question.Answers array so that we can
 retrospectively calculate scores etc.
 */
 func handleSubmitAnswer(w http.ResponseWriter, r *http.Request) {
+	// answers such as drawings can be large, but not too large
+	r.Body = http.MaxBytesReader(w, r.Body, maxAnswerBytes)
 	// parse the json game.Answer object in the form post
 	decoder := json.NewDecoder(r.Body)
 	// decode the json into a game.Answer object
@@ -231,3 +273,63 @@ func handleSubmitAnswer(w http.ResponseWriter, r *http.Request) {
 	}
 	game.GetGame().SubmitAnswer(answer)
 }
+
+/*
+Recieves a form post containing a json packet representing the current
+players response to a single statement in a rapid fire 'truefalse' question.
+The server records the time of each response and scores the whole answer
+once every statement has been responded to.
+See also: game.SubmitStatement
+*/
+func handleSubmitStatement(w http.ResponseWriter, r *http.Request) {
+	type StatementRequest struct {
+		Index    int  `json:"index"`
+		Response bool `json:"response"`
+	}
+	p := session.GetMe(r)
+	if p == nil {
+		http.Error(w, "Not logged in", http.StatusUnauthorized)
+		return
+	}
+	var req StatementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Failed to decode statement response", http.StatusBadRequest)
+		return
+	}
+	if err := game.GetGame().SubmitStatement(p.Username, req.Index, req.Response); err != nil {
+		logger.Warn("Rejected statement response", p.Username, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+}
+
+/*
+Recieves a form post containing a json packet holding the current players
+guess at the target word of a 'wordle' question and responds with the
+guess and its per letter feedback.
+See also: game.SubmitGuess
+*/
+func handleSubmitGuess(w http.ResponseWriter, r *http.Request) {
+	type GuessRequest struct {
+		Guess string `json:"guess"`
+	}
+	p := session.GetMe(r)
+	if p == nil {
+		http.Error(w, "Not logged in", http.StatusUnauthorized)
+		return
+	}
+	var req GuessRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Failed to decode guess", http.StatusBadRequest)
+		return
+	}
+	guess, err := game.GetGame().SubmitGuess(p.Username, req.Guess)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(guess); err != nil {
+		http.Error(w, "Failed to encode guess", http.StatusInternalServerError)
+		return
+	}
+}
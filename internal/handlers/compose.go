@@ -0,0 +1,78 @@
+// internal/handlers/compose.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/richard-senior/1pcc/internal/game"
+	"github.com/richard-senior/1pcc/internal/session"
+)
+
+// splitList splits a comma separated query parameter, dropping empty entries
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+/*
+handleComposeGame lets the host build the game from a pool of questions before
+it starts, eg. /api/compose-game?count=20&max=90&min=1&perCategory=3&require=geolocation
+picks 20 questions from questions.json with percents falling from 90 to 1, no
+more than 3 from a category and at least one geolocation. The pool can be made
+from packs with packs=music,geography. The seed defaults to the current time
+and is sent back so that the same game can be composed again
+*/
+func handleComposeGame(w http.ResponseWriter, r *http.Request) {
+	au := session.GetMe(r)
+	if au == nil || !au.IsAdmin {
+		http.Error(w, "Only the host can compose the game", http.StatusForbidden)
+		return
+	}
+	q := r.URL.Query()
+	c := game.Composition{
+		MaxPercent: 100,
+		MinPercent: 1,
+		Require:    splitList(q.Get("require")),
+		Packs:      splitList(q.Get("packs")),
+		Seed:       time.Now().UnixMilli(), // small enough for the host's browser to show exactly
+	}
+	var err error
+	for _, p := range []struct {
+		name  string
+		value *int
+	}{{"count", &c.Count}, {"max", &c.MaxPercent}, {"min", &c.MinPercent}, {"perCategory", &c.PerCategory}} {
+		if v := q.Get(p.name); v != "" {
+			if *p.value, err = strconv.Atoi(v); err != nil {
+				http.Error(w, "Invalid "+p.name, http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	if s := q.Get("seed"); s != "" {
+		if c.Seed, err = strconv.ParseInt(s, 10, 64); err != nil {
+			http.Error(w, "Invalid seed", http.StatusBadRequest)
+			return
+		}
+	}
+	gs := game.GetGame()
+	problems, err := gs.ComposeGame(c)
+	if err != nil {
+		msg := err.Error()
+		for _, p := range problems {
+			msg += "\n" + p.String()
+		}
+		game.MessagePlayer(au.Username, "Game not composed: "+err.Error(), 15)
+		http.Error(w, msg, http.StatusUnprocessableEntity)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]int64{"seed": c.Seed, "totalQuestions": int64(gs.TotalQuestions)})
+}
@@ -0,0 +1,84 @@
+// internal/handlers/csv.go
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/game"
+	"github.com/richard-senior/1pcc/internal/session"
+)
+
+// the largest CSV file the host can upload
+const maxCSVBytes = 10 << 20
+
+/*
+handleImportCSV lets the host upload a spreadsheet of questions saved as CSV,
+which becomes a new question pack, eg. POST /api/import-csv?pack=music&title=Music+Night
+with the CSV as a "file" form field or as the body. If the rows have problems
+no pack is made and the problems are sent back, one per line
+*/
+func handleImportCSV(w http.ResponseWriter, r *http.Request) {
+	au := session.GetMe(r)
+	if au == nil || !au.IsAdmin {
+		http.Error(w, "Only the host can import questions", http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxCSVBytes)
+	var body io.Reader = r.Body
+	source := "upload.csv"
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		f, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "No file uploaded", http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		body, source = f, header.Filename
+	}
+	name := r.URL.Query().Get("pack")
+	problems, err := game.ImportCSVPack(body, source, name, r.URL.Query().Get("title"))
+	if err != nil {
+		msg := err.Error()
+		for _, p := range problems {
+			msg += "\n" + p.String()
+		}
+		game.MessagePlayer(au.Username, "Pack not made: "+err.Error(), 15)
+		http.Error(w, msg, http.StatusUnprocessableEntity)
+		return
+	}
+	game.MessagePlayer(au.Username, fmt.Sprintf("Pack %s made from %s", name, source), 8)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{"pack": name})
+}
+
+/*
+handleExportCSV sends the host the questions of a pack, or of questions.json
+if no pack is given, as CSV for editing in a spreadsheet, eg. /api/export-csv?pack=music
+*/
+func handleExportCSV(w http.ResponseWriter, r *http.Request) {
+	au := session.GetMe(r)
+	if au == nil || !au.IsAdmin {
+		http.Error(w, "Only the host can export questions", http.StatusForbidden)
+		return
+	}
+	name := r.URL.Query().Get("pack")
+	questions, err := game.PackQuestions(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if name == "" {
+		name = "questions"
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, name))
+	game.ExportCSV(w, questions)
+}
@@ -0,0 +1,203 @@
+// internal/handlers/editor.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/richard-senior/1pcc/internal/game"
+	"github.com/richard-senior/1pcc/internal/session"
+)
+
+// the largest question and the largest image the host can send to the editor
+const (
+	maxQuestionBytes = 1 << 20
+	maxAssetBytes    = 20 << 20
+)
+
+/*
+sendEditError tells the host why an edit wasn't made. If the questions would
+have had problems they're sent back, one per line
+*/
+func sendEditError(w http.ResponseWriter, problems []game.Problem, err error) {
+	msg := err.Error()
+	for _, p := range problems {
+		msg += "\n" + p.String()
+	}
+	switch {
+	case len(problems) > 0:
+		http.Error(w, msg, http.StatusUnprocessableEntity)
+	case errors.Is(err, game.ErrBadEdit):
+		http.Error(w, msg, http.StatusBadRequest)
+	case errors.Is(err, os.ErrNotExist):
+		http.Error(w, msg, http.StatusNotFound)
+	default:
+		http.Error(w, msg, http.StatusInternalServerError)
+	}
+}
+
+// problemLines returns the problems which didn't stop an edit, such as warnings
+// and problems the file already had, to send back with it
+func problemLines(problems []game.Problem) []string {
+	list := []string{}
+	for _, p := range problems {
+		list = append(list, p.String())
+	}
+	return list
+}
+
+// playable reports whether the questions can be loaded, which they can't while
+// they have any problems other than warnings, even ones an edit was allowed to keep
+func playable(problems []game.Problem) bool {
+	for _, p := range problems {
+		if !p.Warning {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+handleQuestions lets the host edit the questions of a pack, or of
+questions.json if no pack is given, one question at a time:
+
+	GET    /api/questions?pack=music          the questions file
+	POST   /api/questions?pack=music&at=3     adds the question in the body, at the end if at isn't given
+	PUT    /api/questions?pack=music&n=3      replaces question 3 with the question in the body
+	DELETE /api/questions?pack=music&n=3      deletes question 3
+
+Adding a question to a pack which doesn't exist makes the pack. Every change
+is validated before it's saved and the old file is kept as questions.json.bak.
+A change is saved as long as it adds no problems, so a file can be put right a
+question at a time, but it can only be played once it has no problems left and
+playable in the response says whether it has. Until then the game carries on
+with the questions it has. Changes to questions.json are picked up by the game
+straight away, changes to the packs being played when the host reloads the
+questions
+*/
+func handleQuestions(w http.ResponseWriter, r *http.Request) {
+	au := session.GetMe(r)
+	if au == nil || !au.IsAdmin {
+		http.Error(w, "Only the host can edit the questions", http.StatusForbidden)
+		return
+	}
+	query := r.URL.Query()
+	pack := query.Get("pack")
+	n, _ := strconv.Atoi(query.Get("n"))
+	if r.Method == http.MethodGet {
+		data, err := game.EditableQuestions(pack)
+		if err != nil {
+			sendEditError(w, nil, err)
+			return
+		}
+		w.Write(data)
+		return
+	}
+
+	var q game.Question
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxQuestionBytes))
+		// misspelt fields would otherwise be lost without a word
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&q); err != nil {
+			http.Error(w, fmt.Sprintf("The question isn't valid JSON: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	var problems []game.Problem
+	var err error
+	switch r.Method {
+	case http.MethodPost:
+		at, _ := strconv.Atoi(query.Get("at"))
+		n, problems, err = game.AddQuestion(pack, q, at)
+		if errors.Is(err, os.ErrNotExist) && pack != "" {
+			n = 1
+			problems, err = game.CreatePack(game.Pack{Name: pack}, []game.Question{q})
+		}
+	case http.MethodPut:
+		problems, err = game.UpdateQuestion(pack, n, q)
+	case http.MethodDelete:
+		problems, err = game.DeleteQuestion(pack, n)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		sendEditError(w, problems, err)
+		return
+	}
+	if r.Method == http.MethodPost {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(map[string]any{"number": n, "problems": problemLines(problems), "playable": playable(problems)})
+}
+
+/*
+handleReorderQuestions lets the host put the questions of a pack, or of
+questions.json if no pack is given, in a new order, eg.
+POST /api/reorder-questions?pack=music&order=2,1,3 swaps the first two of
+three questions. Every question must be in the order once
+*/
+func handleReorderQuestions(w http.ResponseWriter, r *http.Request) {
+	au := session.GetMe(r)
+	if au == nil || !au.IsAdmin {
+		http.Error(w, "Only the host can edit the questions", http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var order []int
+	for _, s := range splitList(r.URL.Query().Get("order")) {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("%q isn't a question number", s), http.StatusBadRequest)
+			return
+		}
+		order = append(order, n)
+	}
+	problems, err := game.ReorderQuestions(r.URL.Query().Get("pack"), order)
+	if err != nil {
+		sendEditError(w, problems, err)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{"problems": problemLines(problems), "playable": playable(problems)})
+}
+
+/*
+handleUploadAsset lets the host upload an image for a question, eg.
+POST /api/upload-asset?pack=music with the image as a "file" form field. The
+image is saved in the pack's images directory, or static/images if no pack is
+given, with a name made from its contents, and the url to give the question
+is sent back eg. {"url": "images/3f2a9c0b1d4e.png"}
+*/
+func handleUploadAsset(w http.ResponseWriter, r *http.Request) {
+	au := session.GetMe(r)
+	if au == nil || !au.IsAdmin {
+		http.Error(w, "Only the host can upload images", http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxAssetBytes)
+	f, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "No file uploaded", http.StatusBadRequest)
+		return
+	}
+	defer f.Close()
+	url, err := game.SaveAsset(r.URL.Query().Get("pack"), header.Filename, f)
+	if err != nil {
+		sendEditError(w, nil, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{"url": url})
+}
@@ -0,0 +1,86 @@
+// internal/handlers/generate.go
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/richard-senior/1pcc/internal/game"
+	"github.com/richard-senior/1pcc/internal/puzzle"
+	"github.com/richard-senior/1pcc/internal/session"
+)
+
+// the most questions that can be generated at once
+var maxGenerated = 50
+
+/*
+handleGenerateQuestions lets the host add generated questions to the end of
+the game, eg. /api/generate-questions?kind=flags&count=5&difficulty=2
+Kinds are flags and puzzles (see the puzzle package). The difficulty defaults
+to any and the seed, which makes the same questions each time, to the current
+time. The seed used is sent back so that a quiz can be made again
+*/
+func handleGenerateQuestions(w http.ResponseWriter, r *http.Request) {
+	au := session.GetMe(r)
+	if au == nil || !au.IsAdmin {
+		http.Error(w, "Only the host can add questions", http.StatusForbidden)
+		return
+	}
+	count, err := strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil || count < 1 || count > maxGenerated {
+		http.Error(w, fmt.Sprintf("count must be between 1 and %d", maxGenerated), http.StatusBadRequest)
+		return
+	}
+	difficulty := 0
+	if d := r.URL.Query().Get("difficulty"); d != "" {
+		if difficulty, err = strconv.Atoi(d); err != nil {
+			http.Error(w, "Invalid difficulty", http.StatusBadRequest)
+			return
+		}
+	}
+	seed := time.Now().UnixNano()
+	if s := r.URL.Query().Get("seed"); s != "" {
+		if seed, err = strconv.ParseInt(s, 10, 64); err != nil {
+			http.Error(w, "Invalid seed", http.StatusBadRequest)
+			return
+		}
+	}
+	rng := rand.New(rand.NewSource(seed))
+	var questions []game.Question
+	switch r.URL.Query().Get("kind") {
+	case "flags":
+		questions, err = game.GenerateFlagQuestions(count, difficulty, rng)
+	case "puzzles":
+		questions, err = puzzle.Generate(count, difficulty, seed)
+	default:
+		http.Error(w, "Unknown kind of question", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	game.GetGame().AddQuestions(questions)
+	json.NewEncoder(w).Encode(map[string]int64{"seed": seed})
+}
+
+/*
+handleFlag serves the image of a flag in a generated question, eg.
+/api/flag?id=3f2a9c0b1d4e5f67
+Flag ids are opaque as the names of the flag images are the answers.
+See also: game.FlagImage
+*/
+func handleFlag(w http.ResponseWriter, r *http.Request) {
+	svg, err := game.FlagImage(r.URL.Query().Get("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Write(svg)
+}
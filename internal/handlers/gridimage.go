@@ -0,0 +1,57 @@
+// internal/handlers/gridimage.go
+package handlers
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"sync"
+
+	"github.com/richard-senior/1pcc/internal/game"
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// Cache of encoded grid tiles keyed by tile id. Tile ids change whenever the
+// questions are loaded so the cache is emptied once it holds maxGridTiles
+const maxGridTiles = 500
+
+var (
+	gridTileCache   = make(map[string][]byte)
+	gridTileCacheMu sync.Mutex
+)
+
+/*
+handleGridTile serves one cell of a 'gridimage' question as png, eg.
+/api/grid-tile?id=3f2a9c0b1d4e5f67
+Tile ids are opaque so nothing about where the tile belongs can be read from
+the url. See also: game.GetGridTile
+*/
+func handleGridTile(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	gridTileCacheMu.Lock()
+	tile, exists := gridTileCache[id]
+	gridTileCacheMu.Unlock()
+	if !exists {
+		img, err := game.GetGame().GetGridTile(id)
+		if err != nil {
+			logger.Warn("Failed to create grid tile: " + err.Error())
+			http.NotFound(w, r)
+			return
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			http.Error(w, "Failed to encode image", http.StatusInternalServerError)
+			return
+		}
+		tile = buf.Bytes()
+		gridTileCacheMu.Lock()
+		if len(gridTileCache) >= maxGridTiles {
+			gridTileCache = make(map[string][]byte)
+		}
+		gridTileCache[id] = tile
+		gridTileCacheMu.Unlock()
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Write(tile)
+}
@@ -0,0 +1,52 @@
+// internal/handlers/media.go
+package handlers
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// the directory from which audio and video clips are served
+var mediaDir = http.Dir("static/media")
+
+// mime types for the media formats browsers commonly play
+var mediaTypes = map[string]string{
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".aac":  "audio/aac",
+	".ogg":  "audio/ogg",
+	".oga":  "audio/ogg",
+	".wav":  "audio/wav",
+	".flac": "audio/flac",
+	".mp4":  "video/mp4",
+	".m4v":  "video/mp4",
+	".webm": "video/webm",
+	".ogv":  "video/ogg",
+	".mov":  "video/quicktime",
+}
+
+/*
+MediaHandler serves audio and video clips from static/media at /media/...
+Browsers seek within media using HTTP range requests so these are honoured
+(via http.ServeContent) which allows clips to be started part way through
+*/
+func MediaHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/media")
+	f, err := mediaDir.Open(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	if ct, exists := mediaTypes[strings.ToLower(path.Ext(name))]; exists {
+		w.Header().Set("Content-Type", ct)
+	}
+	w.Header().Set("Accept-Ranges", "bytes")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
@@ -0,0 +1,98 @@
+// internal/handlers/packs.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/game"
+	"github.com/richard-senior/1pcc/internal/session"
+)
+
+// the directory from which the images and clips bundled with question packs are served
+var packsDir = http.Dir("packs")
+
+// the images which are served from packs, along with the clips in mediaTypes
+var packImageTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".svg":  "image/svg+xml",
+}
+
+/*
+PackHandler serves the images and clips bundled with question packs at
+/packs/<pack>/... Only files of the types in packImageTypes and mediaTypes are
+served, so the pack's json files, which hold the answers, and any backups of
+them are not
+*/
+func PackHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/packs")
+	ext := strings.ToLower(path.Ext(name))
+	ct, exists := packImageTypes[ext]
+	if !exists {
+		ct, exists = mediaTypes[ext]
+	}
+	if !exists {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := packsDir.Open(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", ct)
+	w.Header().Set("Accept-Ranges", "bytes")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
+
+// handlePacks lists the question packs and which of them are being played
+func handlePacks(w http.ResponseWriter, r *http.Request) {
+	packs, err := game.ListPacks()
+	if err != nil {
+		http.Error(w, "Failed to list packs", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"packs":    packs,
+		"selected": game.GetGame().GetPacks(),
+	})
+}
+
+/*
+handleSelectPacks lets the host choose the packs to play before the game
+starts, eg. /api/select-packs?packs=music,geography plays the music pack then
+the geography pack. No packs goes back to questions.json. If the packs have
+problems they aren't used and the problems are sent back, one per line
+*/
+func handleSelectPacks(w http.ResponseWriter, r *http.Request) {
+	au := session.GetMe(r)
+	if au == nil || !au.IsAdmin {
+		http.Error(w, "Only the host can choose the packs", http.StatusForbidden)
+		return
+	}
+	packs := splitList(r.URL.Query().Get("packs"))
+	gs := game.GetGame()
+	problems, err := gs.SelectPacks(packs)
+	if err != nil {
+		msg := err.Error()
+		for _, p := range problems {
+			msg += "\n" + p.String()
+		}
+		game.MessagePlayer(au.Username, "Packs not chosen: "+err.Error(), 15)
+		http.Error(w, msg, http.StatusUnprocessableEntity)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]int{"totalQuestions": gs.TotalQuestions})
+}
@@ -0,0 +1,77 @@
+// internal/handlers/panorama.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/richard-senior/1pcc/internal/game"
+	"github.com/richard-senior/1pcc/internal/imaging"
+	"github.com/richard-senior/1pcc/internal/logger"
+	"github.com/richard-senior/1pcc/internal/session"
+)
+
+/*
+handlePanorama describes how the local panorama image of a question is
+sliced into tiles so that phones only download the parts they are looking
+at, at a size to suit, eg. /api/panorama?question=3
+See also: handlePanoramaTile
+*/
+func handlePanorama(w http.ResponseWriter, r *http.Request) {
+	path, ok := getPanoramaPath(w, r)
+	if !ok {
+		return
+	}
+	pyramid, err := imaging.GetPyramid(path)
+	if err != nil {
+		logger.Error("Failed to read panorama", err)
+		http.Error(w, "Failed to read panorama", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(pyramid)
+}
+
+/*
+handlePanoramaTile serves a single jpeg tile of the local panorama image of
+a question, eg. /api/panorama-tile?question=3&level=2&col=4&row=1
+Tiles never change so browsers may cache them
+*/
+func handlePanoramaTile(w http.ResponseWriter, r *http.Request) {
+	path, ok := getPanoramaPath(w, r)
+	if !ok {
+		return
+	}
+	level, lerr := strconv.Atoi(r.URL.Query().Get("level"))
+	col, cerr := strconv.Atoi(r.URL.Query().Get("col"))
+	row, rerr := strconv.Atoi(r.URL.Query().Get("row"))
+	if lerr != nil || cerr != nil || rerr != nil {
+		http.Error(w, "Invalid tile", http.StatusBadRequest)
+		return
+	}
+	tile, err := imaging.GetTile(path, level, col, row)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Write(tile)
+}
+
+// getPanoramaPath finds the panorama image for the question in the request,
+// writing the error response and returning false if there isn't one.
+// Observer screens aren't logged in so anyone may ask
+func getPanoramaPath(w http.ResponseWriter, r *http.Request) (string, bool) {
+	qn, err := strconv.Atoi(r.URL.Query().Get("question"))
+	if err != nil {
+		http.Error(w, "Invalid question number", http.StatusBadRequest)
+		return "", false
+	}
+	path, err := game.GetGame().GetPanorama(qn, session.GetMe(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return "", false
+	}
+	return path, true
+}
@@ -0,0 +1,36 @@
+// internal/handlers/reload.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/richard-senior/1pcc/internal/game"
+	"github.com/richard-senior/1pcc/internal/session"
+)
+
+/*
+handleReloadQuestions lets the host reload the questions file mid game, eg.
+after fixing a typo. If the file has problems it isn't used and the problems
+are sent back, one per line
+*/
+func handleReloadQuestions(w http.ResponseWriter, r *http.Request) {
+	au := session.GetMe(r)
+	if au == nil || !au.IsAdmin {
+		http.Error(w, "Only the host can reload the questions", http.StatusForbidden)
+		return
+	}
+	gs := game.GetGame()
+	problems, err := gs.ReloadQuestions()
+	if err != nil {
+		msg := err.Error()
+		for _, p := range problems {
+			msg += "\n" + p.String()
+		}
+		game.MessagePlayer(au.Username, "Questions not reloaded: "+err.Error(), 15)
+		http.Error(w, msg, http.StatusUnprocessableEntity)
+		return
+	}
+	game.MessagePlayer(au.Username, "Questions reloaded", 8)
+	json.NewEncoder(w).Encode(map[string]int{"totalQuestions": gs.TotalQuestions})
+}
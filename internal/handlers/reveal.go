@@ -0,0 +1,97 @@
+// internal/handlers/reveal.go
+package handlers
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"net/http"
+	"sync"
+
+	"github.com/richard-senior/1pcc/internal/game"
+	"github.com/richard-senior/1pcc/internal/imaging"
+	"github.com/richard-senior/1pcc/internal/logger"
+)
+
+// Cache of generated reveal stages keyed by image path, mode and stage
+type revealCache struct {
+	images map[string]image.Image
+	mu     sync.RWMutex
+}
+
+var revealImageCache = &revealCache{images: make(map[string]image.Image)}
+
+/*
+handleRevealImage serves the image of the current 'reveal' question as
+it should currently appear, obscured according to how much of the time
+limit has passed. The stage is always decided by the server so that
+players can't get a clearer image by asking for it early.
+*/
+func handleRevealImage(w http.ResponseWriter, r *http.Request) {
+	cq := game.GetGame().GetCurrentQuestion()
+	if cq == nil || cq.Type != "reveal" {
+		http.NotFound(w, r)
+		return
+	}
+	path := imaging.LocalPath(cq.ImageUrl)
+	if path == "" {
+		http.Error(w, "Reveal questions need a local image", http.StatusInternalServerError)
+		return
+	}
+	img, err := getRevealStage(path, cq.RevealMode, cq.RevealStage, cq.GetRevealStages())
+	if err != nil {
+		logger.Error("Failed to create reveal image", err)
+		http.Error(w, "Failed to create reveal image", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := png.Encode(w, img); err != nil {
+		http.Error(w, "Failed to encode image", http.StatusInternalServerError)
+		return
+	}
+}
+
+/**
+* Returns the given stage of the reveal of the image at the given path,
+* generating and caching it if necessary.
+* In "pixelate" mode the pixel blocks shrink geometrically from an eighth of
+* the image size down to a single pixel. In "crop" mode the visible centre of
+* the image grows linearly from 1/stages of the image to the whole thing
+* @param path the local path of the source image
+* @param mode "pixelate" or "crop"
+* @param stage the zero based stage required
+* @param stages the total number of stages
+* @return the image for the stage
+ */
+func getRevealStage(path string, mode string, stage int, stages int) (image.Image, error) {
+	key := fmt.Sprintf("%s|%s|%d|%d", path, mode, stage, stages)
+	revealImageCache.mu.RLock()
+	img, exists := revealImageCache.images[key]
+	revealImageCache.mu.RUnlock()
+	if exists {
+		return img, nil
+	}
+
+	src, err := imaging.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	progress := float64(stage) / float64(max(1, stages-1))
+	switch mode {
+	case "crop":
+		fraction := (1.0 + float64(stage)) / float64(stages)
+		img = imaging.CropZoom(src, fraction)
+	default:
+		b := src.Bounds()
+		maxBlock := float64(max(b.Dx(), b.Dy())) / 8.0
+		blockSize := int(math.Round(math.Pow(math.Max(1.0, maxBlock), 1.0-progress)))
+		img = imaging.Pixelate(src, blockSize)
+	}
+
+	revealImageCache.mu.Lock()
+	revealImageCache.images[key] = img
+	revealImageCache.mu.Unlock()
+	return img, nil
+}
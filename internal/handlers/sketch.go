@@ -0,0 +1,75 @@
+// internal/handlers/sketch.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/richard-senior/1pcc/internal/game"
+	"github.com/richard-senior/1pcc/internal/logger"
+	"github.com/richard-senior/1pcc/internal/session"
+)
+
+/*
+handleSketch serves a players drawing for a 'sketch' question as SVG.
+Drawings are not sent with the game state so screens fetch them one at a
+time from here, eg. /api/sketch?question=3&username=bob
+See also: game.GetSketch
+*/
+func handleSketch(w http.ResponseWriter, r *http.Request) {
+	p := session.GetMe(r)
+	if p == nil {
+		http.Error(w, "Not logged in", http.StatusUnauthorized)
+		return
+	}
+	qn, err := strconv.Atoi(r.URL.Query().Get("question"))
+	if err != nil {
+		http.Error(w, "Invalid question number", http.StatusBadRequest)
+		return
+	}
+	strokes, err := game.GetGame().GetSketch(qn, r.URL.Query().Get("username"), p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprint(w, game.SketchSVG(strokes))
+}
+
+/*
+handleSketches lets the host judge the drawings for the current 'sketch'
+question and choose which drawing the observer screens show, eg.
+/api/sketches?action=judge&username=bob&points=3
+/api/sketches?action=show&username=bob (an empty username shows none)
+*/
+func handleSketches(w http.ResponseWriter, r *http.Request) {
+	au := session.GetMe(r)
+	if au == nil || !au.IsAdmin {
+		http.Error(w, "Only the host can judge drawings", http.StatusForbidden)
+		return
+	}
+	username := r.URL.Query().Get("username")
+	action := r.URL.Query().Get("action")
+	var err error
+	switch action {
+	case "judge":
+		points, perr := strconv.ParseFloat(r.URL.Query().Get("points"), 32)
+		if perr != nil {
+			http.Error(w, "Invalid points value", http.StatusBadRequest)
+			return
+		}
+		logger.Info(fmt.Sprintf("Judging drawing: %s - %.1f", username, points))
+		err = game.GetGame().JudgeSketch(username, float32(points))
+	case "show":
+		err = game.GetGame().FeatureSketch(username)
+	default:
+		http.Error(w, "Invalid action", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+}
@@ -0,0 +1,81 @@
+// internal/imaging/grid.go
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+)
+
+// the background of composite cells not filled by their image
+var compositeBackground = color.RGBA{0, 0, 0, 255}
+
+/**
+* Reads the width and height of the image in the given file without decoding it
+* @param path the path of the image file on the local filesystem
+* @return the width and height in pixels
+ */
+func Size(path string) (int, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return 0, 0, err
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+/**
+* Cuts one cell out of an image divided into a grid of equal cells
+* @param src the image to cut from
+* @param cols the number of columns in the grid
+* @param rows the number of rows in the grid
+* @param index the cell wanted, counting left to right then top to bottom
+* @return the cell as a new image
+ */
+func Cell(src image.Image, cols int, rows int, index int) *image.RGBA {
+	b := src.Bounds()
+	col, row := index%cols, index/cols
+	cell := image.Rect(col*b.Dx()/cols, row*b.Dy()/rows, (col+1)*b.Dx()/cols, (row+1)*b.Dy()/rows).Add(b.Min)
+	dst := image.NewRGBA(image.Rect(0, 0, cell.Dx(), cell.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, cell.Min, draw.Src)
+	return dst
+}
+
+/**
+* Builds a single image from separate images laid out in a grid, each scaled
+* to fit its cell without being stretched
+* @param paths the local paths of the images in cell order, an empty path leaves the cell empty
+* @param cols the number of columns in the grid
+* @param rows the number of rows in the grid
+* @param cellWidth the width of each cell in pixels
+* @param cellHeight the height of each cell in pixels
+* @return the composite image
+ */
+func Composite(paths []string, cols int, rows int, cellWidth int, cellHeight int) (*image.RGBA, error) {
+	dst := image.NewRGBA(image.Rect(0, 0, cols*cellWidth, rows*cellHeight))
+	draw.Draw(dst, dst.Bounds(), &image.Uniform{compositeBackground}, image.Point{}, draw.Src)
+	for i, path := range paths {
+		if path == "" || i >= cols*rows {
+			continue
+		}
+		src, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		b := src.Bounds()
+		w, h := cellWidth, b.Dy()*cellWidth/max(1, b.Dx())
+		if h > cellHeight {
+			w, h = b.Dx()*cellHeight/max(1, b.Dy()), cellHeight
+		}
+		w, h = max(1, w), max(1, h)
+		x := (i%cols)*cellWidth + (cellWidth-w)/2
+		y := (i/cols)*cellHeight + (cellHeight-h)/2
+		draw.Draw(dst, image.Rect(x, y, x+w, y+h), Scale(src, b, w, h), image.Point{}, draw.Src)
+	}
+	return dst, nil
+}
@@ -0,0 +1,124 @@
+// internal/imaging/imaging.go
+package imaging
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // register decoders for image.Decode
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"strings"
+)
+
+/**
+* Converts a url of the form /static/images/foo.png as used in the questions
+* file into the path of the file on the local filesystem
+* Remote urls (http etc.) are returned as an empty string
+* @param url the url as found in a question
+* @return the local file path or empty string if the url is not local
+ */
+func LocalPath(url string) string {
+	if url == "" || strings.Contains(url, "://") {
+		return ""
+	}
+	return strings.TrimPrefix(url, "/")
+}
+
+/**
+* Decodes the png, jpeg or gif image in the given file
+* @param path the path of the image file on the local filesystem
+* @return the decoded image
+ */
+func Load(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return img, nil
+}
+
+/**
+* Returns a copy of the given image in which each blockSize x blockSize square
+* of pixels has been replaced with the average colour of that square
+* @param src the image to pixelate
+* @param blockSize the width and height of each block in pixels
+* @return the pixelated image
+ */
+func Pixelate(src image.Image, blockSize int) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	if blockSize <= 1 {
+		draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+		return dst
+	}
+	for by := 0; by < b.Dy(); by += blockSize {
+		for bx := 0; bx < b.Dx(); bx += blockSize {
+			block := image.Rect(bx, by, min(bx+blockSize, b.Dx()), min(by+blockSize, b.Dy()))
+			var r, g, bl, a, n uint64
+			for y := block.Min.Y; y < block.Max.Y; y++ {
+				for x := block.Min.X; x < block.Max.X; x++ {
+					cr, cg, cb, ca := src.At(b.Min.X+x, b.Min.Y+y).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					bl += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			avg := color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(bl / n),
+				A: uint16(a / n),
+			}
+			draw.Draw(dst, block, &image.Uniform{avg}, image.Point{}, draw.Src)
+		}
+	}
+	return dst
+}
+
+/**
+* Returns an image the same size as the source showing only the centre portion
+* of the source scaled up to fill it, as if zoomed in.
+* @param src the image to crop
+* @param fraction how much of the width and height of the source to show, 0 to 1
+* @return the cropped and scaled image
+ */
+func CropZoom(src image.Image, fraction float64) *image.RGBA {
+	b := src.Bounds()
+	fraction = math.Max(0.01, math.Min(1.0, fraction))
+	cw := max(1, int(float64(b.Dx())*fraction))
+	ch := max(1, int(float64(b.Dy())*fraction))
+	crop := image.Rect(0, 0, cw, ch).Add(image.Pt(b.Min.X+(b.Dx()-cw)/2, b.Min.Y+(b.Dy()-ch)/2))
+	return Scale(src, crop, b.Dx(), b.Dy())
+}
+
+/**
+* Scales the given region of the source image to the given size using
+* nearest neighbour sampling
+* @param src the source image
+* @param region the part of the source image to scale
+* @param width the width of the resulting image
+* @param height the height of the resulting image
+* @return the scaled image
+ */
+func Scale(src image.Image, region image.Rectangle, width int, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		sy := region.Min.Y + y*region.Dy()/height
+		for x := 0; x < width; x++ {
+			sx := region.Min.X + x*region.Dx()/width
+			dst.Set(x, y, src.At(sx, sy))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,317 @@
+// internal/imaging/shapes.go
+package imaging
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Shape is an element of an SVG image which has an id, such as a country on
+// a map, flattened into polygons in the coordinates of the whole image
+type Shape struct {
+	Id       string         // the id attribute of the element
+	Name     string         // the element title, name or data-name if it has one, otherwise the id
+	Polygons [][][2]float64 // each closed sub path of the element as a list of points
+}
+
+// the number of straight lines each curve is flattened into
+var curveSegments = 8
+
+// cache of loaded shapes keyed by file path
+var (
+	shapeCache   = make(map[string][]Shape)
+	shapeCacheMu sync.Mutex
+)
+
+// an affine transform [a b c d e f] as used by svg
+type transform [6]float64
+
+var identity = transform{1, 0, 0, 1, 0, 0}
+
+func (t transform) apply(x float64, y float64) [2]float64 {
+	return [2]float64{t[0]*x + t[2]*y + t[4], t[1]*x + t[3]*y + t[5]}
+}
+
+// then returns the transform which applies t followed by o
+func (t transform) then(o transform) transform {
+	return transform{
+		o[0]*t[0] + o[2]*t[1], o[1]*t[0] + o[3]*t[1],
+		o[0]*t[2] + o[2]*t[3], o[1]*t[2] + o[3]*t[3],
+		o[0]*t[4] + o[2]*t[5] + o[4], o[1]*t[4] + o[3]*t[5] + o[5],
+	}
+}
+
+var transformFunc = regexp.MustCompile(`(matrix|translate|scale)\s*\(([^)]*)\)`)
+var number = regexp.MustCompile(`[-+]?(?:\d+\.?\d*|\.\d+)(?:[eE][-+]?\d+)?`)
+
+// parseTransform understands the matrix, translate and scale svg transforms
+func parseTransform(s string) transform {
+	t := identity
+	// svg applies the right most transform first
+	fns := transformFunc.FindAllStringSubmatch(s, -1)
+	for i := len(fns) - 1; i >= 0; i-- {
+		var v []float64
+		for _, n := range number.FindAllString(fns[i][2], -1) {
+			f, _ := strconv.ParseFloat(n, 64)
+			v = append(v, f)
+		}
+		var o transform
+		switch {
+		case fns[i][1] == "matrix" && len(v) == 6:
+			o = transform{v[0], v[1], v[2], v[3], v[4], v[5]}
+		case fns[i][1] == "translate" && len(v) == 1:
+			o = transform{1, 0, 0, 1, v[0], 0}
+		case fns[i][1] == "translate" && len(v) == 2:
+			o = transform{1, 0, 0, 1, v[0], v[1]}
+		case fns[i][1] == "scale" && len(v) == 1:
+			o = transform{v[0], 0, 0, v[0], 0, 0}
+		case fns[i][1] == "scale" && len(v) == 2:
+			o = transform{v[0], 0, 0, v[1], 0, 0}
+		default:
+			continue
+		}
+		t = t.then(o)
+	}
+	return t
+}
+
+/**
+* Reads every path (and polygon) element with an id from the given SVG file,
+* along with anything inside a group with an id, so that a country made of
+* several paths in a group becomes a single shape.
+* Shapes are loaded once and then cached
+* @param path the path of the svg file on the local filesystem
+* @return the shapes in the order they appear in the file
+ */
+func LoadShapes(path string) ([]Shape, error) {
+	shapeCacheMu.Lock()
+	defer shapeCacheMu.Unlock()
+	if shapes, exists := shapeCache[path]; exists {
+		return shapes, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	type frame struct {
+		t     transform
+		shape *Shape // the group with an id we're inside, if any
+	}
+	var shapes []*Shape
+	stack := []frame{{t: identity}}
+	decoder := xml.NewDecoder(f)
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			top := stack[len(stack)-1]
+			attrs := make(map[string]string)
+			for _, a := range el.Attr {
+				attrs[a.Name.Local] = a.Value
+			}
+			t := top.t
+			if tr, ok := attrs["transform"]; ok {
+				t = parseTransform(tr).then(t)
+			}
+			current := top.shape
+			id := attrs["id"]
+			isShape := el.Name.Local == "path" || el.Name.Local == "polygon" || el.Name.Local == "g"
+			if current == nil && id != "" && isShape {
+				current = &Shape{Id: id, Name: id}
+				for _, n := range []string{"name", "data-name", "title"} {
+					if attrs[n] != "" {
+						current.Name = attrs[n]
+						break
+					}
+				}
+				shapes = append(shapes, current)
+			}
+			if current != nil {
+				switch el.Name.Local {
+				case "path":
+					current.Polygons = append(current.Polygons, flattenPath(attrs["d"], t)...)
+				case "polygon":
+					current.Polygons = append(current.Polygons, parsePoints(attrs["points"], t))
+				}
+			}
+			stack = append(stack, frame{t: t, shape: current})
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	ret := make([]Shape, 0, len(shapes))
+	for _, s := range shapes {
+		if len(s.Polygons) > 0 {
+			ret = append(ret, *s)
+		}
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("%s has no paths with ids", path)
+	}
+	shapeCache[path] = ret
+	return ret, nil
+}
+
+// parsePoints reads the points attribute of a polygon element
+func parsePoints(s string, t transform) [][2]float64 {
+	var ret [][2]float64
+	v := number.FindAllString(s, -1)
+	for i := 0; i+1 < len(v); i += 2 {
+		x, _ := strconv.ParseFloat(v[i], 64)
+		y, _ := strconv.ParseFloat(v[i+1], 64)
+		ret = append(ret, t.apply(x, y))
+	}
+	return ret
+}
+
+var pathToken = regexp.MustCompile(`[MmLlHhVvCcSsQqTtAaZz]|[-+]?(?:\d+\.?\d*|\.\d+)(?:[eE][-+]?\d+)?`)
+
+/**
+* Flattens the d attribute of an svg path into polygons, one per sub path.
+* Curves are replaced with curveSegments straight lines and arcs with a
+* single straight line, which is plenty for hit testing clicks
+* @param d the path data
+* @param t the transform to apply to every point
+* @return the sub paths as lists of points
+ */
+func flattenPath(d string, t transform) [][][2]float64 {
+	toks := pathToken.FindAllString(d, -1)
+	var polys [][][2]float64
+	var poly [][2]float64
+	var cx, cy, sx, sy, lcx, lcy float64 // current, sub path start and last control point
+	var cmd, last byte
+	args := map[byte]int{'M': 2, 'L': 2, 'H': 1, 'V': 1, 'C': 6, 'S': 4, 'Q': 4, 'T': 2, 'A': 7, 'Z': 0}
+	closePoly := func() {
+		if len(poly) > 2 {
+			polys = append(polys, poly)
+		}
+		poly = nil
+	}
+	add := func(x, y float64) {
+		poly = append(poly, t.apply(x, y))
+	}
+	curve := func(pts ...float64) {
+		// pts holds the control points and end point of a quadratic or cubic bezier
+		x0, y0 := cx, cy
+		for i := 1; i <= curveSegments; i++ {
+			u := float64(i) / float64(curveSegments)
+			var x, y float64
+			if len(pts) == 4 {
+				x = (1-u)*(1-u)*x0 + 2*(1-u)*u*pts[0] + u*u*pts[2]
+				y = (1-u)*(1-u)*y0 + 2*(1-u)*u*pts[1] + u*u*pts[3]
+			} else {
+				x = math.Pow(1-u, 3)*x0 + 3*(1-u)*(1-u)*u*pts[0] + 3*(1-u)*u*u*pts[2] + u*u*u*pts[4]
+				y = math.Pow(1-u, 3)*y0 + 3*(1-u)*(1-u)*u*pts[1] + 3*(1-u)*u*u*pts[3] + u*u*u*pts[5]
+			}
+			add(x, y)
+		}
+	}
+	i := 0
+	for i < len(toks) {
+		if c := toks[i][0]; strings.ContainsRune("MmLlHhVvCcSsQqTtAaZz", rune(c)) && len(toks[i]) == 1 {
+			cmd = c
+			i++
+			if cmd == 'Z' || cmd == 'z' {
+				closePoly()
+				cx, cy = sx, sy
+				last = 'Z'
+				continue
+			}
+		}
+		if cmd == 0 {
+			i++
+			continue
+		}
+		upper := cmd &^ 0x20
+		rel := cmd != upper
+		n := args[upper]
+		if i+n > len(toks) {
+			break
+		}
+		v := make([]float64, n)
+		for j := range v {
+			v[j], _ = strconv.ParseFloat(toks[i+j], 64)
+		}
+		i += n
+		ox, oy := 0.0, 0.0
+		if rel {
+			ox, oy = cx, cy
+		}
+		switch upper {
+		case 'M':
+			closePoly()
+			cx, cy = v[0]+ox, v[1]+oy
+			sx, sy = cx, cy
+			add(cx, cy)
+			// further coordinate pairs are line tos
+			if rel {
+				cmd = 'l'
+			} else {
+				cmd = 'L'
+			}
+		case 'L', 'T', 'A':
+			cx, cy = v[n-2]+ox, v[n-1]+oy
+			add(cx, cy)
+		case 'H':
+			cx = v[0] + ox
+			add(cx, cy)
+		case 'V':
+			cy = v[0] + oy
+			add(cx, cy)
+		case 'C':
+			curve(v[0]+ox, v[1]+oy, v[2]+ox, v[3]+oy, v[4]+ox, v[5]+oy)
+			lcx, lcy = v[2]+ox, v[3]+oy
+			cx, cy = v[4]+ox, v[5]+oy
+		case 'S':
+			// the first control point is the reflection of the last one
+			c1x, c1y := cx, cy
+			if last == 'C' || last == 'S' {
+				c1x, c1y = 2*cx-lcx, 2*cy-lcy
+			}
+			curve(c1x, c1y, v[0]+ox, v[1]+oy, v[2]+ox, v[3]+oy)
+			lcx, lcy = v[0]+ox, v[1]+oy
+			cx, cy = v[2]+ox, v[3]+oy
+		case 'Q':
+			curve(v[0]+ox, v[1]+oy, v[2]+ox, v[3]+oy)
+			cx, cy = v[2]+ox, v[3]+oy
+		}
+		last = upper
+	}
+	closePoly()
+	return polys
+}
+
+/**
+* Determines whether the given point is inside the shape using the even-odd
+* rule across all of its sub paths, so holes such as lakes are outside
+* @param x the x coordinate of the point in image coordinates
+* @param y the y coordinate of the point in image coordinates
+* @return true if the point is inside the shape
+ */
+func (s Shape) Contains(x float64, y float64) bool {
+	inside := false
+	for _, poly := range s.Polygons {
+		n := len(poly)
+		for i, j := 0, n-1; i < n; j, i = i, i+1 {
+			xi, yi := poly[i][0], poly[i][1]
+			xj, yj := poly[j][0], poly[j][1]
+			if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
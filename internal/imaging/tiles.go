@@ -0,0 +1,190 @@
+// internal/imaging/tiles.go
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"os"
+	"sync"
+)
+
+// the width and height of the square tiles large images are sliced into
+var TileSize = 512
+
+// the jpeg quality of the tiles
+var tileQuality = 80
+
+// Level is one zoom level of a Pyramid
+type Level struct {
+	Width  int `json:"width"`  // the width of the whole image at this level
+	Height int `json:"height"` // the height of the whole image at this level
+	Cols   int `json:"cols"`   // how many tiles across
+	Rows   int `json:"rows"`   // how many tiles down
+}
+
+// Pyramid describes how a large image is sliced into tiles at a number of
+// zoom levels. Level zero is the smallest, each level after is twice the
+// size of the one before and the last is the image at full size
+type Pyramid struct {
+	Width    int     `json:"width"`
+	Height   int     `json:"height"`
+	TileSize int     `json:"tileSize"`
+	Levels   []Level `json:"levels"`
+}
+
+// how many decoded source images, and how many bytes of encoded tiles, are
+// kept. The oldest are dropped first
+var (
+	maxTileSources   = 4
+	maxTileCacheSize = 64 << 20
+)
+
+// tileSource is a source image which is decoded once, by whichever request
+// needs it first, while any others needing it wait
+type tileSource struct {
+	once sync.Once
+	img  image.Image
+	err  error
+}
+
+// cache of decoded source images and encoded tiles, keyed by file path. The
+// lock is only held to look things up, never while decoding or encoding
+var (
+	tileSources     = make(map[string]*tileSource)
+	tileSourceOrder []string
+	tileCache       = make(map[string][]byte)
+	tileCacheOrder  []string
+	tileCacheSize   int
+	tileCacheMu     sync.Mutex
+)
+
+/**
+* Works out the zoom levels of the image in the given file without decoding it.
+* The smallest level fits in a single tile
+* @param path the path of the image file on the local filesystem
+* @return the pyramid of levels
+ */
+func GetPyramid(path string) (Pyramid, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Pyramid{}, err
+	}
+	defer f.Close()
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return Pyramid{}, err
+	}
+	p := Pyramid{Width: cfg.Width, Height: cfg.Height, TileSize: TileSize}
+	for w, h := cfg.Width, cfg.Height; ; w, h = (w+1)/2, (h+1)/2 {
+		p.Levels = append([]Level{{
+			Width:  w,
+			Height: h,
+			Cols:   (w + TileSize - 1) / TileSize,
+			Rows:   (h + TileSize - 1) / TileSize,
+		}}, p.Levels...)
+		if w <= TileSize && h <= TileSize {
+			break
+		}
+	}
+	return p, nil
+}
+
+/**
+* Returns a tile of the image in the given file as a jpeg, generating and
+* caching it if necessary. Tiles on the right and bottom edges are smaller
+* than TileSize if the image doesn't divide exactly
+* @param path the path of the image file on the local filesystem
+* @param level the zoom level, see GetPyramid
+* @param col the column of the tile
+* @param row the row of the tile
+* @return the jpeg encoded tile
+ */
+func GetTile(path string, level int, col int, row int) ([]byte, error) {
+	p, err := GetPyramid(path)
+	if err != nil {
+		return nil, err
+	}
+	if level < 0 || level >= len(p.Levels) {
+		return nil, fmt.Errorf("there is no level %d", level)
+	}
+	l := p.Levels[level]
+	if col < 0 || col >= l.Cols || row < 0 || row >= l.Rows {
+		return nil, fmt.Errorf("there is no tile %d,%d at level %d", col, row, level)
+	}
+	key := fmt.Sprintf("%s|%d|%d|%d", path, level, col, row)
+	tileCacheMu.Lock()
+	tile, exists := tileCache[key]
+	tileCacheMu.Unlock()
+	if exists {
+		return tile, nil
+	}
+	src, err := getTileSource(path)
+	if err != nil {
+		return nil, err
+	}
+	// the part of the level this tile covers
+	x0, y0 := col*TileSize, row*TileSize
+	x1, y1 := min(x0+TileSize, l.Width), min(y0+TileSize, l.Height)
+	// and the same part of the full size image
+	b := src.Bounds()
+	region := image.Rect(
+		b.Min.X+x0*p.Width/l.Width, b.Min.Y+y0*p.Height/l.Height,
+		b.Min.X+x1*p.Width/l.Width, b.Min.Y+y1*p.Height/l.Height,
+	)
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, Scale(src, region, x1-x0, y1-y0), &jpeg.Options{Quality: tileQuality}); err != nil {
+		return nil, err
+	}
+	tile = buf.Bytes()
+	tileCacheMu.Lock()
+	defer tileCacheMu.Unlock()
+	// two requests for the same tile may both have encoded it
+	if _, exists := tileCache[key]; !exists {
+		tileCache[key] = tile
+		tileCacheOrder = append(tileCacheOrder, key)
+		tileCacheSize += len(tile)
+		for tileCacheSize > maxTileCacheSize && len(tileCacheOrder) > 1 {
+			tileCacheSize -= len(tileCache[tileCacheOrder[0]])
+			delete(tileCache, tileCacheOrder[0])
+			tileCacheOrder = tileCacheOrder[1:]
+		}
+	}
+	return tile, nil
+}
+
+// getTileSource returns the decoded image in the given file, decoding it if
+// it isn't one of the last few used. An image which can't be decoded isn't
+// kept so it's tried again next time
+func getTileSource(path string) (image.Image, error) {
+	tileCacheMu.Lock()
+	ts, exists := tileSources[path]
+	if !exists {
+		ts = &tileSource{}
+		tileSources[path] = ts
+		tileSourceOrder = append(tileSourceOrder, path)
+		if len(tileSourceOrder) > maxTileSources {
+			delete(tileSources, tileSourceOrder[0])
+			tileSourceOrder = tileSourceOrder[1:]
+		}
+	}
+	tileCacheMu.Unlock()
+	ts.once.Do(func() {
+		ts.img, ts.err = Load(path)
+	})
+	if ts.err != nil {
+		tileCacheMu.Lock()
+		if tileSources[path] == ts {
+			delete(tileSources, path)
+			for i, p := range tileSourceOrder {
+				if p == path {
+					tileSourceOrder = append(tileSourceOrder[:i], tileSourceOrder[i+1:]...)
+					break
+				}
+			}
+		}
+		tileCacheMu.Unlock()
+	}
+	return ts.img, ts.err
+}
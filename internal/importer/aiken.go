@@ -0,0 +1,75 @@
+// internal/importer/aiken.go
+package importer
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	aikenChoice = regexp.MustCompile(`^([A-Za-z])[.)]\s+(.*)$`)
+	aikenAnswer = regexp.MustCompile(`^ANSWER:\s*([A-Za-z])\s*$`)
+)
+
+/*
+aiken reads Moodle's Aiken format, multichoice questions written as the
+question on a line, a line for each choice starting with its letter and
+a line giving the letter of the right answer eg.
+
+	What is the capital of France?
+	A. London
+	B) Paris
+	ANSWER: B
+*/
+func (b *bank) aiken(text string) {
+	var question string
+	var letters, choices []string
+	start := 0
+	reset := func() {
+		question, letters, choices = "", nil, nil
+	}
+	for i, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		n := i + 1
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := aikenAnswer.FindStringSubmatch(line); m != nil && question != "" {
+			correct := -1
+			for j, l := range letters {
+				if strings.EqualFold(l, m[1]) {
+					correct = j
+				}
+			}
+			switch {
+			case len(choices) < 2:
+				b.skip(start, question, "there aren't enough choices")
+			case correct < 0:
+				b.skip(start, question, "the answer %s isn't one of the choices", m[1])
+			default:
+				b.add(multichoice(question, choices, []int{correct}), start)
+			}
+			reset()
+			continue
+		}
+		if m := aikenChoice.FindStringSubmatch(line); m != nil && question != "" {
+			letters = append(letters, m[1])
+			choices = append(choices, m[2])
+			continue
+		}
+		if len(choices) > 0 {
+			// a new question has started without an answer to the last
+			b.skip(start, question, "there is no ANSWER line")
+			reset()
+		}
+		if question == "" {
+			start = n
+			question = line
+		} else {
+			question += " " + line
+		}
+	}
+	if question != "" {
+		b.skip(start, question, "there is no ANSWER line")
+	}
+}
@@ -0,0 +1,210 @@
+// internal/importer/gift.go
+package importer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/game"
+)
+
+// GIFT escapes its special characters with a backslash. They are swapped for
+// characters from the private use area while an item is parsed, then back
+var (
+	giftProtect = strings.NewReplacer(`\\`, "\ue000", `\:`, "\ue001", `\~`, "\ue002", `\=`, "\ue003",
+		`\#`, "\ue004", `\{`, "\ue005", `\}`, "\ue006", `\n`, "\ue007")
+	giftRestore = strings.NewReplacer("\ue000", `\`, "\ue001", ":", "\ue002", "~", "\ue003", "=",
+		"\ue004", "#", "\ue005", "{", "\ue006", "}", "\ue007", "\n")
+)
+
+// the formats a GIFT question's text can be written in, all of which are imported as plain text
+var giftFormats = []string{"[html]", "[moodle]", "[markdown]", "[plain]"}
+
+/*
+gift reads Moodle's GIFT format, in which each question is separated from the
+next by a blank line and its answers are written in braces eg.
+
+	$CATEGORY: top/Geography
+	::Capitals:: What is the capital of France? {=Paris ~London ~Rome}
+	Paris is in France. {T}
+	Which city is the capital of Scotland? {=Edinburgh =Edinburgh city}
+
+Multiple choice and true or false questions become multichoice questions and
+short answers become freetext. Essays, matching questions, numbers with a
+tolerance and answers that are only partly right can't be marked by 1pcc so
+they're left out
+*/
+func (b *bank) gift(text string) {
+	category := ""
+	var item []string
+	start := 0
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i := 0; i <= len(lines); i++ {
+		line := ""
+		if i < len(lines) {
+			line = strings.TrimSpace(lines[i])
+		}
+		if strings.HasPrefix(line, "//") {
+			continue
+		}
+		if line != "" {
+			if item == nil {
+				start = i + 1
+			}
+			item = append(item, line)
+			continue
+		}
+		if item == nil {
+			continue
+		}
+		if c, ok := strings.CutPrefix(item[0], "$CATEGORY:"); ok {
+			parts := strings.Split(strings.TrimSpace(c), "/")
+			category = decode(parts[len(parts)-1])
+			item = item[1:]
+		}
+		if len(item) > 0 {
+			if q, ok := b.giftItem(strings.Join(item, "\n"), start); ok {
+				q.Category = category
+				b.add(q, start)
+			}
+		}
+		item = nil
+	}
+}
+
+// giftItem converts a single GIFT question, reporting it if it can't be
+func (b *bank) giftItem(item string, line int) (q game.Question, ok bool) {
+	item = giftProtect.Replace(item)
+	if rest, ok := strings.CutPrefix(item, "::"); ok {
+		if _, after, found := strings.Cut(rest, "::"); found {
+			item = strings.TrimSpace(after)
+		}
+	}
+	for _, f := range giftFormats {
+		item = strings.TrimPrefix(item, f)
+	}
+	open := strings.Index(item, "{")
+	end := strings.LastIndex(item, "}")
+	if open < 0 || end < open {
+		b.skip(line, giftRestore.Replace(item), "it has no answers")
+		return q, false
+	}
+	question := strings.TrimSpace(item[:open])
+	// a question with text after its answers is a missing word question
+	if after := strings.TrimSpace(item[end+1:]); after != "" {
+		question += " _____ " + after
+	}
+	question = giftRestore.Replace(question)
+	answers := strings.TrimSpace(item[open+1 : end])
+	for _, f := range giftFormats {
+		answers = strings.TrimPrefix(answers, f)
+	}
+
+	switch {
+	case answers == "":
+		b.skip(line, question, "essays can't be marked")
+		return q, false
+	case strings.HasPrefix(answers, "#"):
+		return b.giftNumber(question, answers[1:], line)
+	}
+	// true or false, with or without feedback
+	tf, _, _ := strings.Cut(answers, "#")
+	switch strings.ToUpper(strings.TrimSpace(tf)) {
+	case "T", "TRUE":
+		return multichoice(question, []string{"True", "False"}, []int{0}), true
+	case "F", "FALSE":
+		return multichoice(question, []string{"True", "False"}, []int{1}), true
+	}
+
+	var choices []string
+	var correct []int
+	wrong := false
+	for _, a := range giftAnswers(answers) {
+		if strings.Contains(a.text, "->") {
+			b.skip(line, question, "matching questions can't be asked")
+			return q, false
+		}
+		if a.weight > 0 && a.weight < 100 {
+			b.skip(line, question, "answers worth %d%% can't be marked, 1pcc only has right and wrong", a.weight)
+			return q, false
+		}
+		if a.marker == '=' || a.weight == 100 {
+			correct = append(correct, len(choices))
+		} else {
+			wrong = true
+		}
+		choices = append(choices, giftRestore.Replace(a.text))
+	}
+	switch {
+	case len(choices) == 0:
+		b.skip(line, question, "it has no answers")
+		return q, false
+	case len(correct) == 0:
+		b.skip(line, question, "none of the answers is right")
+		return q, false
+	case wrong:
+		return multichoice(question, choices, correct), true
+	}
+	// only right answers, this is a short answer question
+	var right []string
+	for _, i := range correct {
+		right = append(right, choices[i])
+	}
+	return freetext(question, right), true
+}
+
+// giftNumber converts a numerical question, which can only be asked if the
+// answer is an exact number
+func (b *bank) giftNumber(question string, answers string, line int) (game.Question, bool) {
+	answers = strings.TrimPrefix(strings.TrimSpace(answers), "=")
+	answers, _, _ = strings.Cut(answers, "#")
+	answers = strings.TrimSpace(answers)
+	if strings.ContainsAny(answers, ":=~") || strings.Contains(answers, "..") {
+		b.skip(line, question, "numbers with a tolerance or range can't be marked")
+		return game.Question{}, false
+	}
+	if _, err := strconv.ParseFloat(answers, 64); err != nil {
+		b.skip(line, question, "%q isn't a number", answers)
+		return game.Question{}, false
+	}
+	return freetext(question, []string{answers}), true
+}
+
+// giftAnswer is one of the answers in the braces of a GIFT question
+type giftAnswer struct {
+	marker rune
+	weight int
+	text   string
+}
+
+// giftAnswers splits the answers in the braces of a GIFT question, each of
+// which starts with = if it's right or ~ if not, and may have a weight such
+// as ~%100% for a right answer, ~%50% for a partly right one and feedback
+// after a #
+func giftAnswers(answers string) []giftAnswer {
+	var list []giftAnswer
+	for _, r := range answers {
+		if r != '=' && r != '~' {
+			if len(list) > 0 {
+				list[len(list)-1].text += string(r)
+			}
+			continue
+		}
+		list = append(list, giftAnswer{marker: r})
+	}
+	for i := range list {
+		a := &list[i]
+		a.text, _, _ = strings.Cut(a.text, "#")
+		a.text = strings.TrimSpace(a.text)
+		if rest, ok := strings.CutPrefix(a.text, "%"); ok {
+			if w, after, found := strings.Cut(rest, "%"); found {
+				a.weight, _ = strconv.Atoi(w)
+				a.text = strings.TrimSpace(after)
+				if a.marker == '=' && a.weight <= 0 {
+					a.marker = '~'
+				}
+			}
+		}
+	}
+	return list
+}
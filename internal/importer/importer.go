@@ -0,0 +1,140 @@
+// internal/importer/importer.go
+package importer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/game"
+)
+
+// what imported questions are worth and how long the players get, none of the formats say
+var (
+	defaultPercent   = 50
+	defaultTimeLimit = 30
+	defaultPoints    = 2
+	defaultCategory  = "general knowledge"
+)
+
+// Formats are the formats which can be imported
+var Formats = []string{"gift", "aiken", "opentdb"}
+
+/**
+* Converts a quiz bank written for other software into questions, so that it
+* can be made into a question pack. Moodle's GIFT and Aiken formats and the
+* JSON of the Open Trivia Database are understood. Items which can't be asked,
+* such as essays, are left out and reported
+* @param format one of Formats
+* @param r the quiz bank
+* @param source the name of the file, used in problems
+* @return the questions, the line each started on, the items which couldn't be converted and an error if the bank couldn't be read at all
+ */
+func Import(format string, r io.Reader, source string) ([]game.Question, []int, []game.Problem, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var b bank
+	b.source = source
+	switch strings.ToLower(format) {
+	case "gift":
+		b.gift(string(data))
+	case "aiken":
+		b.aiken(string(data))
+	case "opentdb":
+		err = b.openTDB(data)
+	default:
+		err = fmt.Errorf("unknown format %q, the formats are %s", format, strings.Join(Formats, ", "))
+	}
+	return b.questions, b.lines, b.skipped, err
+}
+
+// bank collects the questions converted from a quiz bank
+type bank struct {
+	source    string
+	questions []game.Question
+	lines     []int
+	skipped   []game.Problem
+}
+
+func (b *bank) add(q game.Question, line int) {
+	if q.Percent == 0 {
+		q.Percent = defaultPercent
+	}
+	if q.Category == "" {
+		q.Category = defaultCategory
+	}
+	q.TimeLimit = defaultTimeLimit
+	q.PointsAvailable = defaultPoints
+	b.questions = append(b.questions, q)
+	b.lines = append(b.lines, line)
+}
+
+// skip reports an item which couldn't be converted
+func (b *bank) skip(line int, text string, format string, a ...any) {
+	if len([]rune(text)) > 40 {
+		text = string([]rune(text)[:40]) + "..."
+	}
+	b.skipped = append(b.skipped, game.Problem{
+		File:    b.source,
+		Line:    line,
+		Message: fmt.Sprintf("%q left out, ", text) + fmt.Sprintf(format, a...),
+		Warning: true,
+	})
+}
+
+// multichoice makes a multichoice question, the choices being answered A, B, C...
+func multichoice(question string, choices []string, correct []int) game.Question {
+	q := game.Question{Type: "multichoice", Question: decode(question)}
+	for i, c := range choices {
+		q.Choices = append(q.Choices, game.Choice{Choice: decode(c), Answer: string(rune('A' + i))})
+	}
+	for _, i := range correct {
+		q.CorrectAnswers = append(q.CorrectAnswers, q.Choices[i].Answer)
+	}
+	return q
+}
+
+// freetext makes a question with a typed answer. Typing mistakes are forgiven
+// in proportion to the length of the shortest answer, one for every five
+// letters, except in numbers which must be exact
+func freetext(question string, answers []string) game.Question {
+	q := game.Question{Type: "freetext", Question: decode(question)}
+	shortest := -1
+	numeric := true
+	for _, a := range answers {
+		a = decode(a)
+		q.CorrectAnswers = append(q.CorrectAnswers, a)
+		if shortest < 0 || len([]rune(a)) < shortest {
+			shortest = len([]rune(a))
+		}
+		if strings.Trim(a, "0123456789.,-") != "" {
+			numeric = false
+		}
+	}
+	if !numeric {
+		q.PenalisationFactor = float32(min(3, max(1, shortest/5)))
+	}
+	return q
+}
+
+// html tags and comments, which are taken out of imported text
+var tags = regexp.MustCompile(`<[!/]?[A-Za-z][^<>]*>|<!--.*?-->`)
+
+// the angle brackets left once the tags have gone, which are escaped
+var brackets = strings.NewReplacer("<", "&lt;", ">", "&gt;")
+
+/*
+decode turns html entities such as &quot; into the characters they stand for
+and takes out any markup. The players see question text as html, so anything
+imported must not be able to add tags of its own, not even ones that were
+written as entities such as &lt;script&gt;
+*/
+func decode(s string) string {
+	s = tags.ReplaceAllString(s, " ")
+	s = tags.ReplaceAllString(html.UnescapeString(s), " ")
+	return brackets.Replace(strings.Join(strings.Fields(s), " "))
+}
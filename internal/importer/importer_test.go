@@ -0,0 +1,196 @@
+// internal/importer/importer_test.go
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/richard-senior/1pcc/internal/game"
+)
+
+// importTest is a quiz bank holding a single item and what it should become,
+// a nil question meaning the item is left out with the given problem
+type importTest struct {
+	name    string
+	bank    string
+	want    *game.Question
+	problem string
+}
+
+// choices makes the choices of a multichoice question, answered A, B, C...
+func choices(texts ...string) []game.Choice {
+	var list []game.Choice
+	for i, t := range texts {
+		list = append(list, game.Choice{Choice: t, Answer: string(rune('A' + i))})
+	}
+	return list
+}
+
+// trueFalse is a true or false question as the importers make it
+func trueFalse(question string, answer string) *game.Question {
+	return &game.Question{Type: "multichoice", Question: question, Choices: choices("True", "False"), CorrectAnswers: []string{answer}}
+}
+
+// runImportTests imports each bank and compares the result, filling in the
+// defaults the importers give every question
+func runImportTests(t *testing.T, format string, tests []importTest) {
+	t.Helper()
+	for _, tt := range tests {
+		questions, lines, skipped, err := Import(format, strings.NewReader(tt.bank), "bank.txt")
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if tt.want == nil {
+			if len(questions) != 0 || len(skipped) != 1 || !strings.HasSuffix(skipped[0].Message, tt.problem) || !skipped[0].Warning {
+				t.Errorf("%s: made %d questions and left out %+v, want it left out as %q", tt.name, len(questions), skipped, tt.problem)
+			}
+			continue
+		}
+		if len(questions) != 1 || len(skipped) != 0 {
+			t.Errorf("%s: made %d questions and left out %+v, want 1 question", tt.name, len(questions), skipped)
+			continue
+		}
+		want := *tt.want
+		if want.Percent == 0 {
+			want.Percent = defaultPercent
+		}
+		if want.Category == "" {
+			want.Category = defaultCategory
+		}
+		want.TimeLimit = defaultTimeLimit
+		want.PointsAvailable = defaultPoints
+		if !reflect.DeepEqual(questions[0], want) {
+			t.Errorf("%s: made\n%+v\nwant\n%+v", tt.name, questions[0], want)
+		}
+		if lines[0] != 1 {
+			t.Errorf("%s: starts on line %d, want 1", tt.name, lines[0])
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Who wrote &quot;Hamlet&quot;?", `Who wrote "Hamlet"?`},
+		{"Fish &amp; chips", "Fish & chips"},
+		{"<b>Bold</b> move", "Bold move"},
+		{"&lt;script&gt;alert(1)&lt;/script&gt;", "alert(1)"},
+		{"<img src=x onerror=alert(1)>Look", "Look"},
+		{"Is 5 < 6 and 7 > 6?", "Is 5 &lt; 6 and 7 &gt; 6?"},
+		{"&lt; 5 and 6 &gt;", "&lt; 5 and 6 &gt;"},
+		{"a<!-- hidden -->b", "a b"},
+		{"  lots   of\n space ", "lots of space"},
+	}
+	for _, tt := range tests {
+		if got := decode(tt.in); got != tt.want {
+			t.Errorf("decode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFreetextPenalisation(t *testing.T) {
+	tests := []struct {
+		answers []string
+		want    float32
+	}{
+		{[]string{"Paris"}, 1},
+		{[]string{"Edinburgh"}, 1},
+		{[]string{"Constantinople"}, 2},
+		{[]string{"Llanfairpwllgwyngyll"}, 3},
+		{[]string{"Constantinople", "Istanbul"}, 1},
+		{[]string{"1,066"}, 0},
+		{[]string{"42", "forty two"}, 1},
+	}
+	for _, tt := range tests {
+		if got := freetext("q", tt.answers).PenalisationFactor; got != tt.want {
+			t.Errorf("%v forgives %v mistakes, want %v", tt.answers, got, tt.want)
+		}
+	}
+}
+
+func TestGIFT(t *testing.T) {
+	runImportTests(t, "gift", []importTest{
+		{"multichoice", "::Capitals:: What is the capital of France? {=Paris ~London ~Rome}",
+			&game.Question{Type: "multichoice", Question: "What is the capital of France?", Choices: choices("Paris", "London", "Rome"), CorrectAnswers: []string{"A"}}, ""},
+		{"multichoice over lines with feedback", "Which are primes?\n{\n~4 # even\n=3\n=5 # yes\n}",
+			&game.Question{Type: "multichoice", Question: "Which are primes?", Choices: choices("4", "3", "5"), CorrectAnswers: []string{"B", "C"}}, ""},
+		{"in a category", "$CATEGORY: top/Geography\nIs Paris in France? {T}",
+			&game.Question{Type: "multichoice", Question: "Is Paris in France?", Category: "Geography", Choices: choices("True", "False"), CorrectAnswers: []string{"A"}}, ""},
+		{"true", "Paris is in France. {TRUE#well done}", trueFalse("Paris is in France.", "A"), ""},
+		{"false", "Paris is in Spain. {F}\n// a comment", trueFalse("Paris is in Spain.", "B"), ""},
+		{"short answer", "Which city is the capital of Scotland? {=Edinburgh =Edinburgh city}",
+			&game.Question{Type: "freetext", Question: "Which city is the capital of Scotland?", CorrectAnswers: []string{"Edinburgh", "Edinburgh city"}, PenalisationFactor: 1}, ""},
+		{"a full weight answer", "Name a primary colour {~%100%red ~%100%blue ~green}",
+			&game.Question{Type: "multichoice", Question: "Name a primary colour", Choices: choices("red", "blue", "green"), CorrectAnswers: []string{"A", "B"}}, ""},
+		{"no weight is wrong", "Name a primary colour {=%0%green =red}",
+			&game.Question{Type: "multichoice", Question: "Name a primary colour", Choices: choices("green", "red"), CorrectAnswers: []string{"B"}}, ""},
+		{"missing word", "Mahatma Gandhi's birthday is an Indian holiday on {~15th ~3rd =2nd} of October.",
+			&game.Question{Type: "multichoice", Question: "Mahatma Gandhi's birthday is an Indian holiday on _____ of October.", Choices: choices("15th", "3rd", "2nd"), CorrectAnswers: []string{"C"}}, ""},
+		{"escaped characters", `What is 1 \= 1\: a \{test\}? {=yes ~no \~ never}`,
+			&game.Question{Type: "multichoice", Question: "What is 1 = 1: a {test}?", Choices: choices("yes", "no ~ never"), CorrectAnswers: []string{"A"}}, ""},
+		{"html", "[html]<p>What is <b>H<sub>2</sub>O</b>?</p> {=water ~&lt;b&gt;ice&lt;/b&gt;}",
+			&game.Question{Type: "multichoice", Question: "What is H 2 O ?", Choices: choices("water", "ice"), CorrectAnswers: []string{"A"}}, ""},
+		{"an exact number", "When was the Battle of Hastings? {#1066}",
+			&game.Question{Type: "freetext", Question: "When was the Battle of Hastings?", CorrectAnswers: []string{"1066"}}, ""},
+		{"a number with a tolerance", "What is pi? {#3.14:0.01}", nil, "numbers with a tolerance or range can't be marked"},
+		{"a number range", "What is pi? {#3..4}", nil, "numbers with a tolerance or range can't be marked"},
+		{"not a number", "What is pi? {#lots}", nil, `"lots" isn't a number`},
+		{"an essay", "Write about Paris. {}", nil, "essays can't be marked"},
+		{"matching", "Match them {=France -> Paris =Italy -> Rome}", nil, "matching questions can't be asked"},
+		{"partial credit", "Name a primary colour {~%50%red ~%50%blue ~green}", nil, "answers worth 50% can't be marked, 1pcc only has right and wrong"},
+		{"nothing right", "Name a primary colour {~green ~purple}", nil, "none of the answers is right"},
+		{"no braces", "What is the capital of France?", nil, "it has no answers"},
+	})
+}
+
+func TestAiken(t *testing.T) {
+	runImportTests(t, "aiken", []importTest{
+		{"multichoice", "What is the capital of France?\nA. London\nB) Paris\nC. Rome\nANSWER: B",
+			&game.Question{Type: "multichoice", Question: "What is the capital of France?", Choices: choices("London", "Paris", "Rome"), CorrectAnswers: []string{"B"}}, ""},
+		{"over two lines", "What is the capital\nof France?\r\na. London\r\nb. Paris\r\nANSWER: b",
+			&game.Question{Type: "multichoice", Question: "What is the capital of France?", Choices: choices("London", "Paris"), CorrectAnswers: []string{"B"}}, ""},
+		{"html", "Is 1 < 2?\nA. <i>yes</i>\nB. no\nANSWER: A",
+			&game.Question{Type: "multichoice", Question: "Is 1 &lt; 2?", Choices: choices("yes", "no"), CorrectAnswers: []string{"A"}}, ""},
+		{"one choice", "What is the capital of France?\nA. Paris\nANSWER: A", nil, "there aren't enough choices"},
+		{"the answer isn't a choice", "What is the capital of France?\nA. London\nB. Paris\nANSWER: D", nil, "the answer D isn't one of the choices"},
+		{"no answer", "What is the capital of France?\nA. London\nB. Paris", nil, "there is no ANSWER line"},
+	})
+
+	// a question without an answer doesn't stop the next being read
+	questions, lines, skipped, _ := Import("aiken", strings.NewReader("One?\nA. a\nB. b\n\nTwo?\nA. a\nB. b\nANSWER: A\n"), "bank.txt")
+	if len(questions) != 1 || questions[0].Question != "Two?" || lines[0] != 5 || len(skipped) != 1 || skipped[0].Line != 1 {
+		t.Errorf("made %+v on lines %v and left out %+v, want Two? on line 5 with One? left out", questions, lines, skipped)
+	}
+}
+
+func TestOpenTDB(t *testing.T) {
+	runImportTests(t, "opentdb", []importTest{
+		{"multiple", `{"response_code": 0, "results": [{"type": "multiple", "difficulty": "easy", "category": "Science &amp; Nature",
+			"question": "Which is a noble gas?", "correct_answer": "Argon", "incorrect_answers": ["Oxygen", "nitrogen", "Carbon"]}]}`,
+			&game.Question{Type: "multichoice", Question: "Which is a noble gas?", Category: "Science & Nature", Percent: 80,
+				Choices: choices("Argon", "Carbon", "nitrogen", "Oxygen"), CorrectAnswers: []string{"A"}}, ""},
+		{"boolean", `[{"type": "boolean", "difficulty": "hard", "category": "History",
+			"question": "The &quot;Great&quot; Fire of London was in 1667.", "correct_answer": "False", "incorrect_answers": ["True"]}]`,
+			&game.Question{Type: "multichoice", Question: `The "Great" Fire of London was in 1667.`, Category: "History", Percent: 20,
+				Choices: choices("True", "False"), CorrectAnswers: []string{"B"}}, ""},
+		{"an unknown difficulty", `[{"type": "boolean", "difficulty": "fiendish", "category": "History",
+			"question": "Is it?", "correct_answer": "True", "incorrect_answers": ["False"]}]`,
+			&game.Question{Type: "multichoice", Question: "Is it?", Category: "History", Choices: choices("True", "False"), CorrectAnswers: []string{"A"}}, ""},
+		{"an injected answer", `[{"type": "multiple", "difficulty": "medium", "category": "Art",
+			"question": "Which?", "correct_answer": "&lt;img src=x onerror=alert(1)&gt;Monet", "incorrect_answers": ["Manet"]}]`,
+			&game.Question{Type: "multichoice", Question: "Which?", Category: "Art", Percent: 50, Choices: choices("Manet", "Monet"), CorrectAnswers: []string{"B"}}, ""},
+		{"no answer", `[{"type": "multiple", "question": "Which?", "incorrect_answers": ["a"]}]`, nil, "it has no correct answer"},
+		{"an unknown type", `[{"type": "essay", "question": "Why?", "correct_answer": "because"}]`, nil, `questions of type "essay" can't be asked`},
+		{"a bad item", `[{"type": 7}]`, nil, "json: cannot unmarshal number into Go struct field openTDBItem.type of type string"},
+	})
+
+	if _, _, _, err := Import("opentdb", strings.NewReader("<html>"), "bank.json"); err == nil {
+		t.Errorf("imported html as Open Trivia Database JSON")
+	}
+	if _, _, _, err := Import("moodle", strings.NewReader(""), "bank.xml"); err == nil {
+		t.Errorf("imported an unknown format")
+	}
+}
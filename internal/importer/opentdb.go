@@ -0,0 +1,95 @@
+// internal/importer/opentdb.go
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/game"
+)
+
+// the percent given to questions of each of the Open Trivia Database's difficulties
+var openTDBPercents = map[string]int{"easy": 80, "medium": 50, "hard": 20}
+
+// openTDBItem is a question as the Open Trivia Database writes it
+type openTDBItem struct {
+	Type             string   `json:"type"`
+	Difficulty       string   `json:"difficulty"`
+	Category         string   `json:"category"`
+	Question         string   `json:"question"`
+	CorrectAnswer    string   `json:"correct_answer"`
+	IncorrectAnswers []string `json:"incorrect_answers"`
+}
+
+/*
+openTDB reads the JSON of the Open Trivia Database, either a response from its
+api eg. {"response_code": 0, "results": [...]} or just the list of questions.
+The text is html encoded, as the api sends it by default
+*/
+func (b *bank) openTDB(data []byte) error {
+	var response struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		if err := json.Unmarshal(data, &response.Results); err != nil {
+			return fmt.Errorf("%s isn't Open Trivia Database JSON: %v", b.source, err)
+		}
+	} else if err := json.Unmarshal(data, &response); err != nil {
+		return fmt.Errorf("%s isn't Open Trivia Database JSON: %v", b.source, err)
+	}
+	offset := 0
+	for _, raw := range response.Results {
+		// the line each question starts on, for reporting problems with it
+		if i := bytes.Index(data[offset:], raw); i >= 0 {
+			offset += i
+		}
+		line := bytes.Count(data[:offset], []byte("\n")) + 1
+		var item openTDBItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			b.skip(line, string(raw), "%v", err)
+			continue
+		}
+		q := b.openTDBQuestion(item, line)
+		if q == nil {
+			continue
+		}
+		q.Category = decode(item.Category)
+		q.Percent = openTDBPercents[strings.ToLower(item.Difficulty)]
+		b.add(*q, line)
+	}
+	return nil
+}
+
+// openTDBQuestion converts a question, reporting it if it can't be
+func (b *bank) openTDBQuestion(item openTDBItem, line int) *game.Question {
+	if item.CorrectAnswer == "" {
+		b.skip(line, decode(item.Question), "it has no correct answer")
+		return nil
+	}
+	switch item.Type {
+	case "boolean":
+		correct := 0
+		if strings.EqualFold(item.CorrectAnswer, "false") {
+			correct = 1
+		}
+		q := multichoice(item.Question, []string{"True", "False"}, []int{correct})
+		return &q
+	case "multiple":
+		// the correct answer would always be first, so the choices are sorted
+		choices := append([]string{item.CorrectAnswer}, item.IncorrectAnswers...)
+		sort.Slice(choices, func(i, j int) bool {
+			return strings.ToLower(decode(choices[i])) < strings.ToLower(decode(choices[j]))
+		})
+		for i, c := range choices {
+			if c == item.CorrectAnswer {
+				q := multichoice(item.Question, choices, []int{i})
+				return &q
+			}
+		}
+	}
+	b.skip(line, decode(item.Question), "questions of type %q can't be asked", item.Type)
+	return nil
+}
@@ -0,0 +1,79 @@
+// internal/puzzle/algebra.go
+package puzzle
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/richard-senior/1pcc/internal/game"
+)
+
+/**
+* Simple algebra. Easy puzzles solve ax + b = c, harder ones have x on both
+* sides and the hardest are bat and ball style word problems whose obvious
+* answer is wrong
+ */
+func algebra(rng *rand.Rand, difficulty int) game.Question {
+	x := 2 + rng.Intn(9)
+	switch difficulty {
+	case 1:
+		a, b := 2+rng.Intn(4), 1+rng.Intn(20)
+		q := game.Question{
+			Question:   fmt.Sprintf("If %dx + %d = %d, what is x?", a, b, a*x+b),
+			Percent:    85 - 2*a,
+			HostAnswer: fmt.Sprintf("Take away %d then divide by %d, x is %d", b, a, x),
+		}
+		setChoices(&q, strconv.Itoa(x), numbers(x, (a*x+b)/a, a*x), rng)
+		return q
+	case 2:
+		c := 2 + rng.Intn(4)
+		a := c + 1 + rng.Intn(4)
+		d := 1 + rng.Intn(20)
+		b := d + (c-a)*x
+		q := game.Question{
+			Question:   fmt.Sprintf("If %dx %s = %dx + %d, what is x?", a, signed(b), c, d),
+			Percent:    60 - 2*(a-c),
+			HostAnswer: fmt.Sprintf("Taking %dx from both sides leaves %s %s = %d, so x is %d", c, xTerm(a-c), signed(b), d, x),
+		}
+		setChoices(&q, strconv.Itoa(x), numbers(x, (d-b)/(a+c), d-b), rng)
+		return q
+	default:
+		// prices in pence, the difference is a round number of pounds
+		diff := 100 * (1 + rng.Intn(3))
+		ball := 5 * (1 + rng.Intn(10))
+		total := diff + 2*ball
+		q := game.Question{
+			Question: fmt.Sprintf("A bat and a ball cost %s in total. The bat costs %s more than the ball. How much does the ball cost?",
+				pounds(total), pounds(diff)),
+			Percent:    20,
+			HostAnswer: fmt.Sprintf("The ball costs %s and the bat %s, not %s", pounds(ball), pounds(ball+diff), pounds(total-diff)),
+		}
+		setChoices(&q, pounds(ball), []string{pounds(total - diff), pounds(ball * 2), pounds(ball + 5), pounds(ball + 10), pounds(ball + 15)}, rng)
+		return q
+	}
+}
+
+// xTerm writes a multiple of x, leaving out a coefficient of 1
+func xTerm(a int) string {
+	if a == 1 {
+		return "x"
+	}
+	return fmt.Sprintf("%dx", a)
+}
+
+// signed writes a number with its sign, as it follows an x term
+func signed(n int) string {
+	if n < 0 {
+		return fmt.Sprintf("- %d", -n)
+	}
+	return fmt.Sprintf("+ %d", n)
+}
+
+// pounds writes an amount of pence as money, eg. 110 is £1.10 and 5 is 5p
+func pounds(pence int) string {
+	if pence < 100 {
+		return fmt.Sprintf("%dp", pence)
+	}
+	return fmt.Sprintf("£%d.%02d", pence/100, pence%100)
+}
@@ -0,0 +1,119 @@
+// internal/puzzle/oddoneout.go
+package puzzle
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/richard-senior/1pcc/internal/game"
+)
+
+// property is something a number may or may not be
+type property struct {
+	name       string // eg. "even", as in "the others are all even"
+	difficulty int
+	test       func(n int) bool
+}
+
+var properties = []property{
+	{"even", 1, func(n int) bool { return n%2 == 0 }},
+	{"multiples of 5", 1, func(n int) bool { return n%5 == 0 }},
+	{"multiples of 3", 2, func(n int) bool { return n%3 == 0 }},
+	{"square numbers", 2, func(n int) bool { return isSquare(n) }},
+	{"multiples of 7", 3, func(n int) bool { return n%7 == 0 }},
+	{"prime", 3, isPrime},
+	{"triangular numbers", 3, func(n int) bool { return isSquare(8*n + 1) }},
+}
+
+func isSquare(n int) bool {
+	for i := 0; i*i <= n; i++ {
+		if i*i == n {
+			return true
+		}
+	}
+	return false
+}
+
+func isPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for i := 2; i*i <= n; i++ {
+		if n%i == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// the largest number used in odd one out puzzles
+var oddOneOutMax = 100
+
+/**
+* Which number is the odd one out. All but one of the numbers share a
+* property and care is taken that no other property picks out a different
+* number
+ */
+func oddOneOut(rng *rand.Rand, difficulty int) game.Question {
+	var candidates []property
+	for _, p := range properties {
+		if p.difficulty == difficulty {
+			candidates = append(candidates, p)
+		}
+	}
+	p := candidates[rng.Intn(len(candidates))]
+	// give up looking for a set no other property spoils eventually
+	for attempt := 0; ; attempt++ {
+		var have, lack []int
+		for len(have) < numChoices-1 || len(lack) < 1 {
+			n := 2 + rng.Intn(oddOneOutMax-1)
+			if p.test(n) && len(have) < numChoices-1 && !contains(have, n) {
+				have = append(have, n)
+			} else if !p.test(n) && len(lack) < 1 {
+				lack = append(lack, n)
+			}
+		}
+		set := append(have, lack[0])
+		if attempt > 1000 || !ambiguous(set, lack[0], p) {
+			q := game.Question{
+				Question:   "Which is the odd one out?",
+				Percent:    95 - 25*difficulty,
+				HostAnswer: fmt.Sprintf("%d, the others are all %s", lack[0], p.name),
+			}
+			setChoices(&q, strconv.Itoa(lack[0]), numbers(lack[0], have...), rng)
+			return q
+		}
+	}
+}
+
+// ambiguous reports whether some other property singles out a different
+// number of the set as the odd one out
+func ambiguous(set []int, odd int, chosen property) bool {
+	for _, p := range properties {
+		if p.name == chosen.name {
+			continue
+		}
+		var with, without []int
+		for _, n := range set {
+			if p.test(n) {
+				with = append(with, n)
+			} else {
+				without = append(without, n)
+			}
+		}
+		if (len(with) == 1 && with[0] != odd) || (len(without) == 1 && without[0] != odd) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []int, n int) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+// internal/puzzle/puzzle.go
+package puzzle
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/richard-senior/1pcc/internal/game"
+)
+
+// template creates a puzzle of the given difficulty (1 to 3) as a question
+// with the choices, answer and Percent filled in
+type template func(rng *rand.Rand, difficulty int) game.Question
+
+// the kinds of puzzle, picked at random for each question
+var templates = []template{
+	sequence,
+	triangles,
+	oddOneOut,
+	algebra,
+}
+
+// how many choices each puzzle has, including the right one
+var numChoices = 4
+
+/**
+* Generates logic puzzles in the style of The 1% Club, sequences, counting
+* triangles, odd one out and simple algebra, as multichoice questions ready
+* to be added to a game. The same seed always gives the same puzzles
+* @param count how many puzzles to generate
+* @param difficulty 1 (easy) to 3 (hard) or 0 for a mixture
+* @param seed the seed for the random choices
+* @return the questions or an error
+ */
+func Generate(count int, difficulty int, seed int64) ([]game.Question, error) {
+	if difficulty < 0 || difficulty > 3 {
+		return nil, fmt.Errorf("difficulty must be 1, 2 or 3 (or 0 for a mixture)")
+	}
+	rng := rand.New(rand.NewSource(seed))
+	questions := make([]game.Question, count)
+	for i := range questions {
+		d := difficulty
+		if d == 0 {
+			d = 1 + rng.Intn(3)
+		}
+		q := templates[rng.Intn(len(templates))](rng, d)
+		q.Type = "multichoice"
+		q.Category = "logic puzzles"
+		q.PointsAvailable = 2
+		q.TimeLimit = 40
+		q.ReadTime = 5
+		q.Percent = max(1, min(99, q.Percent))
+		questions[i] = q
+	}
+	return questions, nil
+}
+
+/**
+* Fills in the choices of the question in a random order, lettered from A,
+* with the correct answer among them. Wrong answers which repeat the correct
+* answer or each other are skipped
+* @param q the question
+* @param correct the correct answer
+* @param wrong the wrong answers, most plausible first, only as many as are needed are used
+* @param rng the source of randomness
+ */
+func setChoices(q *game.Question, correct string, wrong []string, rng *rand.Rand) {
+	choices := []string{correct}
+	for _, w := range wrong {
+		if len(choices) == numChoices {
+			break
+		}
+		duplicate := false
+		for _, c := range choices {
+			duplicate = duplicate || c == w
+		}
+		if !duplicate {
+			choices = append(choices, w)
+		}
+	}
+	rng.Shuffle(len(choices), func(i, j int) { choices[i], choices[j] = choices[j], choices[i] })
+	q.Choices = nil
+	for i, c := range choices {
+		letter := string(rune('A' + i))
+		q.Choices = append(q.Choices, game.Choice{Choice: c, Answer: letter})
+		if c == correct {
+			q.CorrectAnswers = []string{letter}
+		}
+	}
+}
+
+// numbers returns the wrong answers for a numeric puzzle, the given
+// plausible mistakes followed by numbers close to the correct answer
+func numbers(correct int, mistakes ...int) []string {
+	var ret []string
+	for _, m := range mistakes {
+		ret = append(ret, strconv.Itoa(m))
+	}
+	for d := 1; len(ret) < numChoices*2; d++ {
+		ret = append(ret, strconv.Itoa(correct+d), strconv.Itoa(correct-d))
+	}
+	return ret
+}
@@ -0,0 +1,96 @@
+// internal/puzzle/sequence.go
+package puzzle
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/game"
+)
+
+// how many terms of a sequence are shown
+var sequenceTerms = 5
+
+/**
+* What comes next in a sequence. Easy sequences go up by a fixed step or
+* multiply by a fixed amount, harder ones have growing steps, add the two
+* previous terms or interleave two sequences
+ */
+func sequence(rng *rand.Rand, difficulty int) game.Question {
+	var terms []int
+	var rule string
+	var percent int
+	n := sequenceTerms + 1
+	switch kind := (difficulty-1)*2 + rng.Intn(2); kind {
+	case 0:
+		// arithmetic
+		a, d := 1+rng.Intn(20), 2+rng.Intn(8)
+		for i := 0; i < n; i++ {
+			terms = append(terms, a+i*d)
+		}
+		rule = fmt.Sprintf("The numbers go up by %d each time", d)
+		percent = 95 - d
+	case 1:
+		// arithmetic going down
+		d := 3 + rng.Intn(9)
+		a := d*n + rng.Intn(20)
+		for i := 0; i < n; i++ {
+			terms = append(terms, a-i*d)
+		}
+		rule = fmt.Sprintf("The numbers go down by %d each time", d)
+		percent = 90 - d
+	case 2:
+		// geometric
+		a, r := 1+rng.Intn(5), 2+rng.Intn(2)
+		for i, t := 0, a; i < n; i, t = i+1, t*r {
+			terms = append(terms, t)
+		}
+		rule = fmt.Sprintf("Each number is %d times the one before", r)
+		percent = 75 - 5*r
+	case 3:
+		// the step grows by the same amount each time
+		a, d, k := 1+rng.Intn(10), 1+rng.Intn(4), 1+rng.Intn(3)
+		for i, t := 0, a; i < n; i, t, d = i+1, t+d, d+k {
+			terms = append(terms, t)
+		}
+		rule = fmt.Sprintf("The gap between the numbers grows by %d each time", k)
+		percent = 60 - 5*k
+	case 4:
+		// each term is the sum of the two before
+		a, b := 1+rng.Intn(5), 2+rng.Intn(6)
+		terms = []int{a, b}
+		for len(terms) < n {
+			terms = append(terms, terms[len(terms)-1]+terms[len(terms)-2])
+		}
+		rule = "Each number is the sum of the two before it"
+		percent = 40
+	default:
+		// two sequences interleaved
+		a, d, b, e := 1+rng.Intn(10), 2+rng.Intn(5), 20+rng.Intn(20), 1+rng.Intn(4)
+		for i := 0; i < n; i++ {
+			if i%2 == 0 {
+				terms = append(terms, a+i/2*d)
+			} else {
+				terms = append(terms, b-i/2*e)
+			}
+		}
+		rule = fmt.Sprintf("Two sequences take turns, one going up by %d and the other down by %d", d, e)
+		percent = 20
+	}
+	shown := make([]string, sequenceTerms)
+	for i := range shown {
+		shown[i] = strconv.Itoa(terms[i])
+	}
+	answer := terms[sequenceTerms]
+	last, prev := terms[sequenceTerms-1], terms[sequenceTerms-2]
+	q := game.Question{
+		Question:   fmt.Sprintf("What comes next?<br/>%s, ?", strings.Join(shown, ", ")),
+		Percent:    percent,
+		HostAnswer: fmt.Sprintf("%s, so the answer is %d", rule, answer),
+	}
+	// the usual mistake is to carry on the last step
+	setChoices(&q, strconv.Itoa(answer), numbers(answer, last+(last-prev), last+2*(last-prev), answer+last-prev), rng)
+	return q
+}
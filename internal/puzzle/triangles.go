@@ -0,0 +1,59 @@
+// internal/puzzle/triangles.go
+package puzzle
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/richard-senior/1pcc/internal/game"
+)
+
+/**
+* How many triangles are in a triangle cut by lines from its top corner to
+* its base and by lines across it parallel to its base. Every triangle has
+* the top corner, two of the lines from it and one of the lines across
+* (including the base) so there are (across + 1) x (down + 2 choose 2)
+ */
+func triangles(rng *rand.Rand, difficulty int) game.Question {
+	down := difficulty + rng.Intn(2)
+	across := difficulty - 1 + rng.Intn(2)
+	if difficulty == 1 {
+		across = 0
+	}
+	perLine := (down + 2) * (down + 1) / 2
+	answer := (across + 1) * perLine
+	q := game.Question{
+		Question:   "How many triangles are there?<br/>" + trianglesSVG(down, across),
+		Percent:    95 - 2*answer,
+		HostAnswer: fmt.Sprintf("Each of the %d lines across makes %d triangles with the lines from the top, %d altogether", across+1, perLine, answer),
+	}
+	if across == 0 {
+		q.HostAnswer = fmt.Sprintf("Any two of the %d lines from the top make a triangle with the base, %d altogether", down+2, answer)
+	}
+	regions := (down + 1) * (across + 1)
+	// forgetting the triangles made by the lines across, or counting only the smallest
+	setChoices(&q, strconv.Itoa(answer), numbers(answer, perLine, regions, answer-1-down), rng)
+	return q
+}
+
+// trianglesSVG draws a triangle with the given number of lines from the top
+// corner to the base and across
+func trianglesSVG(down int, across int) string {
+	var b strings.Builder
+	top, left, right, bottom := [2]float64{100, 10}, 10.0, 190.0, 170.0
+	b.WriteString(`<svg viewBox="0 0 200 180" width="200" height="180" stroke="white" stroke-width="2" fill="none">`)
+	fmt.Fprintf(&b, `<polygon points="%g,%g %g,%g %g,%g"/>`, top[0], top[1], left, bottom, right, bottom)
+	for i := 1; i <= down; i++ {
+		x := left + (right-left)*float64(i)/float64(down+1)
+		fmt.Fprintf(&b, `<line x1="%g" y1="%g" x2="%.1f" y2="%g"/>`, top[0], top[1], x, bottom)
+	}
+	for i := 1; i <= across; i++ {
+		y := top[1] + (bottom-top[1])*float64(i)/float64(across+1)
+		half := (right - left) / 2 * (y - top[1]) / (bottom - top[1])
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f"/>`, top[0]-half, y, top[0]+half, y)
+	}
+	b.WriteString(`</svg>`)
+	return b.String()
+}
@@ -20,7 +20,7 @@ class ClickMap extends PageElement {
     ];
 
     constructor() {
-        super('click-container', ['geolocation','kazakhstan'])
+        super('click-container', ['geolocation','kazakhstan','country'])
         this.isPlayableComponent = true;
         this.answerx = null;
         this.answery = null;
@@ -41,6 +41,9 @@ class ClickMap extends PageElement {
         this.clickTimeThreshold = 200; // milliseconds to consider it a click
         this.secondPointerDown = false;
         this.currentMarker = null;
+        // for questions with hit regions, the points clicked and their markers
+        this.clicks = [];
+        this.targetMarkers = [];
         // dimensions etc
         this.startPoint = { x: 0, y: 0 };
         this.viewBoxStart = { x: 0, y: 0 };
@@ -160,7 +163,13 @@ class ClickMap extends PageElement {
 
         // draw marker for any answer the player has already submitted
         let a = this.getPlayerAnswer()
-        if (a) {
+        this.clicks = [];
+        this.targetMarkers = [];
+        if (this.isServerScored(cq)) {
+            for (const c of a?.clicks ?? []) {
+                this.drawMarker(c[0], c[1], "#FFFFFF", null);
+            }
+        } else if (a) {
             let coords = this.parseCoordinates(a.answer);
             let x = coords[0];
             let y = coords[1];
@@ -222,6 +231,8 @@ class ClickMap extends PageElement {
         this.getContent();
         // now get the location of markers from the current question
         let cq = this.getCurrentQuestion();
+        if (cq.regions) {return this.drawRegions();}
+        if (cq.type === "country") {return this.drawCountries();}
         // First add the correct answer marker at the very beginning (bottom z-order)
         // This ensures it's drawn first and other elements appear on top
         if (!cq.correctAnswers || cq.correctAnswers.length < 1) {return this.svg;}
@@ -237,7 +248,8 @@ class ClickMap extends PageElement {
         // Add circles for each answer with different colors and 80% opacity
         let p = this.getCurrentPlayer();
         cq.answers.forEach((answer, index) => {
-            let coords = this.parseCoordinates(answer.answer);
+            // answers scored by the server hold the map coordinates in clicks
+            let coords = answer.clicks?.[0] ?? this.parseCoordinates(answer.answer);
             const x = coords[0];
             const y = coords[1];
             // Select a color based on the index, cycling through the colors array
@@ -258,6 +270,14 @@ class ClickMap extends PageElement {
         let cq = gs.getCurrentQuestion();
         let ap = cq.pointsAvailable;
 
+        if (this.isServerScored(cq)) {
+            if (this.clicks.length === 0) {return null;}
+            a.clicks = this.clicks;
+            a.answer = this.clicks.map(c => `${c[0].toFixed(0)} - ${c[1].toFixed(0)}`).join(', ');
+            a.comment = '';
+            return a;
+        }
+
         if (this.answerx === null || this.answery === null) {
             return null;
         }
@@ -322,6 +342,109 @@ class ClickMap extends PageElement {
         return a;
     }
 
+    /**
+     * Questions with hit regions, a map projection or countries are scored
+     * by the server from the points clicked
+     * @param {Question} cq the current question
+     * @returns {boolean} true if the server scores the question
+     */
+    isServerScored(cq) {
+        return !!(cq.targets || cq.projection || cq.type === "country");
+    }
+
+    /**
+     * Colours the country shapes of the map for a 'country' question. The
+     * correct countries, which only arrive once the question has ended, are
+     * shown in green and every other country a player picked in their colour
+     * @returns {Document.Object} the svg
+     */
+    drawCountries() {
+        let cq = this.getCurrentQuestion();
+        const colour = (id, fill) => {
+            const shape = id ? this.svg.querySelector(`[id="${CSS.escape(id)}"]`) : null;
+            if (!shape) {return;}
+            // groups pass the fill on to the paths inside them
+            for (const s of [shape, ...shape.querySelectorAll('path, polygon')]) {
+                s.style.fill = fill;
+            }
+        };
+        let p = this.getCurrentPlayer();
+        (cq.answers ?? []).forEach((answer, index) => {
+            colour(answer.country, ClickMap.colors[index % ClickMap.colors.length]);
+            // the current players click has already been drawn by getContent
+            if (p && answer.username === p.username) {return;}
+            for (const c of answer.clicks ?? []) {
+                this.drawMarker(c[0], c[1], ClickMap.colors[index % ClickMap.colors.length], null);
+            }
+        });
+        for (const id of cq.correctAnswers ?? []) {
+            colour(id, "#6aaa64");
+        }
+        return this.svg;
+    }
+
+    /**
+     * Draws the hit regions of the current question, which only arrive once
+     * the question has ended, followed by every players clicks
+     * @returns {Document.Object} the svg
+     */
+    drawRegions() {
+        let cq = this.getCurrentQuestion();
+        const ns = "http://www.w3.org/2000/svg";
+        for (const r of cq.regions) {
+            let shape = null;
+            if (r.polygon && r.polygon.length >= 3) {
+                shape = document.createElementNS(ns, "polygon");
+                shape.setAttribute("points", r.polygon.map(p => p.join(',')).join(' '));
+            } else {
+                shape = document.createElementNS(ns, "ellipse");
+                shape.setAttribute("cx", r.cx);
+                shape.setAttribute("cy", r.cy);
+                shape.setAttribute("rx", r.rx);
+                shape.setAttribute("ry", r.ry);
+            }
+            shape.setAttribute("fill", "#6aaa64");
+            shape.setAttribute("fill-opacity", "0.4");
+            shape.setAttribute("stroke", "#6aaa64");
+            shape.setAttribute("stroke-width", "3");
+            this.svg.appendChild(shape);
+        }
+        let p = this.getCurrentPlayer();
+        (cq.answers ?? []).forEach((answer, index) => {
+            // the current players clicks have already been drawn by getContent
+            if (p && answer.username === p.username) {return;}
+            const color = ClickMap.colors[index % ClickMap.colors.length];
+            for (const c of answer.clicks ?? []) {
+                this.drawMarker(c[0], c[1], color, null);
+            }
+        });
+        return this.svg;
+    }
+
+    /**
+     * Places the players marker where they clicked. For questions with more than
+     * one target each click adds a marker, up to one per target, after which
+     * the oldest marker is replaced
+     * @param {number} x the x screen coordinate of the click
+     * @param {number} y the y screen coordinate of the click
+     */
+    placeMarker(x, y) {
+        let cq = this.getCurrentQuestion();
+        if (!cq.targets || cq.targets < 2) {
+            this.addMarker(x, y, "#000000", ClickMap.markerIdPrefix);
+            if (this.isServerScored(cq) && this.answerx !== null) {this.clicks = [[this.answerx, this.answery]];}
+            return;
+        }
+        const marker = this.addMarker(x, y, "#000000", null);
+        if (!marker) {return;}
+        this.clicks.push([this.answerx, this.answery]);
+        this.targetMarkers.push(marker);
+        if (this.clicks.length > cq.targets) {
+            this.clicks.shift();
+            this.targetMarkers.shift().remove();
+        }
+    }
+
     drawMarker(x, y, colour, id) {
         if (!this.svg) return;
         if (!x || !y || !colour) return;
@@ -483,7 +606,7 @@ class ClickMap extends PageElement {
                     //let y = this.parseCoordinate(dy)
                     let x = tx;
                     let y = ty;
-                    this.addMarker(x, y, "#000000", ClickMap.markerIdPrefix);
+                    this.placeMarker(x, y);
                 }
             }, 350);
         }, nonPassiveOpts);
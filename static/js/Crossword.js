@@ -0,0 +1,262 @@
+/**
+ * PageElement which implements the 'crossword' question type.
+ * The grid and clues come from the question, the answers to the clues do not
+ * arrive until the question has ended. The player fills in the cells and submits
+ * the whole grid, the server checks it cell by cell and awards a share of the
+ * points for each word filled in correctly.
+ */
+class Crossword extends PageElement {
+    constructor() {
+        super('crossword-container', ['crossword']);
+        this.isPlayableComponent = true;
+        this.lastQuestionActive = false;
+        this.inputs = [];
+    }
+
+    shouldUpdate() {
+        // only redraw when the question starts or stops so typed letters aren't lost
+        const currentQuestionActive = this.isQuestionActive();
+        if (currentQuestionActive !== this.lastQuestionActive) {
+            this.lastQuestionActive = currentQuestionActive;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #crossword-container {
+                padding: 15px;
+                margin: 0 auto;
+                display: flex;
+                flex-wrap: wrap;
+                gap: 20px;
+                justify-content: center;
+            }
+            .crossword-grid {
+                display: inline-grid;
+                gap: 2px;
+                background-color: black;
+                border: 2px solid black;
+                align-self: flex-start;
+            }
+            .crossword-cell {
+                position: relative;
+                width: 2.2em;
+                height: 2.2em;
+                background-color: white;
+            }
+            .crossword-cell.black {
+                background-color: black;
+            }
+            .crossword-cell.wrong {
+                background-color: #f3c5c5;
+            }
+            .crossword-number {
+                position: absolute;
+                top: 1px;
+                left: 2px;
+                font-size: 0.6em;
+                color: black;
+            }
+            .crossword-cell input, .crossword-letter {
+                width: 100%;
+                height: 100%;
+                border: none;
+                background: transparent;
+                text-align: center;
+                font-size: 1.2em;
+                font-weight: bold;
+                text-transform: uppercase;
+                color: black;
+                display: flex;
+                align-items: center;
+                justify-content: center;
+                box-sizing: border-box;
+            }
+            .crossword-clues {
+                color: white;
+                text-align: left;
+                max-width: 400px;
+            }
+            .crossword-clues h3 {
+                color: var(--bcclightgold);
+                margin: 10px 0 5px 0;
+            }
+            .crossword-results {
+                color: white;
+                width: 100%;
+            }
+        `;
+    }
+
+    /**
+     * @returns {object} the clue number to show in each cell keyed by 'row,col'
+     */
+    getNumbers() {
+        let cq = this.getCurrentQuestion();
+        const ret = {};
+        for (const clue of cq.clues ?? []) {
+            if (clue.length > 0) {ret[`${clue.row},${clue.col}`] = clue.number;}
+        }
+        return ret;
+    }
+
+    /**
+     * Creates the grid of cells
+     * @param {function} cellContent called with (row, col) for each white cell
+     * and returns the element to put in it
+     * @returns {Document.Object} the grid
+     */
+    createGrid(cellContent) {
+        let cq = this.getCurrentQuestion();
+        const rows = cq.crosswordGrid ?? [];
+        const numbers = this.getNumbers();
+        const grid = document.createElement('div');
+        grid.className = 'crossword-grid';
+        const width = Math.max(...rows.map(r => [...r].length));
+        grid.style.gridTemplateColumns = `repeat(${width}, 2.2em)`;
+        rows.forEach((row, r) => {
+            const chars = [...row];
+            for (let c = 0; c < width; c++) {
+                const cell = document.createElement('div');
+                cell.className = 'crossword-cell';
+                if (c >= chars.length || chars[c] === '#') {
+                    cell.classList.add('black');
+                    grid.appendChild(cell);
+                    continue;
+                }
+                const number = numbers[`${r},${c}`];
+                if (number) {
+                    const n = document.createElement('span');
+                    n.className = 'crossword-number';
+                    n.textContent = number;
+                    cell.appendChild(n);
+                }
+                cell.appendChild(cellContent(r, c, cell));
+                grid.appendChild(cell);
+            }
+        });
+        return grid;
+    }
+
+    /**
+     * Creates the across and down clue lists
+     * @returns {Document.Object} the clue lists
+     */
+    createClues() {
+        let cq = this.getCurrentQuestion();
+        const container = document.createElement('div');
+        container.className = 'crossword-clues';
+        for (const direction of ['across', 'down']) {
+            const clues = (cq.clues ?? []).filter(c => c.direction === direction);
+            if (clues.length === 0) {continue;}
+            const heading = document.createElement('h3');
+            heading.textContent = direction.charAt(0).toUpperCase() + direction.slice(1);
+            container.appendChild(heading);
+            for (const clue of clues) {
+                const div = document.createElement('div');
+                div.textContent = `${clue.number}. ${clue.clue} (${clue.length})`;
+                container.appendChild(div);
+            }
+        }
+        return container;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+        container.style.display = 'contents';
+        let cp = this.getCurrentPlayer();
+        const isPlayer = cp && !cp.isSpectator;
+        const disabled = !this.isQuestionActive() || this.hasAnswered();
+        this.inputs = [];
+        container.appendChild(this.createGrid((r, c) => {
+            if (!isPlayer) {return document.createElement('span');}
+            const input = document.createElement('input');
+            input.type = 'text';
+            input.maxLength = 1;
+            input.disabled = disabled;
+            input.dataset.row = r;
+            input.dataset.col = c;
+            input.addEventListener('input', () => {
+                // move on to the next cell as each letter is typed
+                if (!input.value) {return;}
+                const i = this.inputs.indexOf(input);
+                if (i >= 0 && i + 1 < this.inputs.length) {this.inputs[i + 1].focus();}
+            });
+            this.inputs.push(input);
+            return input;
+        }));
+        container.appendChild(this.createClues());
+        return container;
+    }
+
+    /**
+     * Sends the whole filled grid, one string per row, as the answer.
+     * Unused cells are sent as '#' and empty cells as spaces
+     * @returns {Answer} the answer object or null if no cells have been filled in
+     */
+    getAnswer() {
+        let cq = this.getCurrentQuestion();
+        if (!cq || this.inputs.length === 0) {return null;}
+        const cells = (cq.crosswordGrid ?? []).map(row => [...row].map(c => c === '#' ? '#' : ' '));
+        let filled = false;
+        for (const input of this.inputs) {
+            const letter = input.value.trim().toUpperCase();
+            if (!letter) {continue;}
+            cells[input.dataset.row][input.dataset.col] = letter;
+            filled = true;
+        }
+        if (!filled) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.cells = cells.map(row => row.join(''));
+        answer.comment = '';
+        this.inputs.forEach(input => input.disabled = true);
+        return answer;
+    }
+
+    /**
+     * Shows the completed grid and which words each player got
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq) {return container;}
+        // work out the solution from the clue answers
+        const solution = {};
+        for (const clue of cq.clues ?? []) {
+            if (!clue.answer || clue.length === 0) {continue;}
+            [...clue.answer].forEach((letter, i) => {
+                const r = clue.direction === 'down' ? clue.row + i : clue.row;
+                const c = clue.direction === 'down' ? clue.col : clue.col + i;
+                solution[`${r},${c}`] = letter;
+            });
+        }
+        // mark the cells the current player got wrong
+        let cp = this.getCurrentPlayer();
+        const mine = (cq.answers ?? []).find(a => cp && a.username === cp.username);
+        container.appendChild(this.createGrid((r, c, cell) => {
+            const letter = document.createElement('span');
+            letter.className = 'crossword-letter';
+            letter.textContent = solution[`${r},${c}`] ?? '';
+            if (mine && mine.cells && [...(mine.cells[r] ?? '')][c] !== letter.textContent) {
+                cell.classList.add('wrong');
+            }
+            return letter;
+        }));
+        const results = document.createElement('div');
+        results.className = 'crossword-results';
+        for (const a of cq.answers ?? []) {
+            const div = document.createElement('div');
+            const words = a.words && a.words.length > 0 ? a.words.join(', ') : 'none';
+            div.textContent = `${a.username}: ${words}`;
+            results.appendChild(div);
+        }
+        container.appendChild(results);
+        return container;
+    }
+}
@@ -0,0 +1,179 @@
+/**
+ * PageElement which implements the countdown numbers style 'expression' question type.
+ * The player is given some numbers and a target and types an arithmetic
+ * expression using each number at most once to get as close to the target as they can.
+ * The server evaluates the expression and scores it on its distance from the target.
+ */
+class Expression extends PageElement {
+    constructor() {
+        super('expression-container', ['expression']);
+        this.isPlayableComponent = true;
+        this.lastQuestionActive = false;
+        this.textInput = null;
+        this.tiles = [];
+    }
+
+    shouldUpdate() {
+        const currentQuestionActive = this.isQuestionActive();
+        if (currentQuestionActive !== this.lastQuestionActive) {
+            this.lastQuestionActive = currentQuestionActive;
+            // enable or disable in place so that the expression being typed isn't lost
+            const disabled = !currentQuestionActive || this.hasAnswered();
+            if (this.textInput) {this.textInput.disabled = disabled;}
+            this.tiles.forEach(tile => tile.disabled = disabled);
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #expression-container {
+                padding: 15px;
+                margin: 0 auto;
+                text-align: center;
+            }
+            .expression-target {
+                color: var(--bcclightgold);
+                font-size: 3em;
+                font-weight: bold;
+                margin-bottom: 15px;
+            }
+            .expression-tiles {
+                display: flex;
+                flex-wrap: wrap;
+                gap: 10px;
+                justify-content: center;
+                margin-bottom: 15px;
+            }
+            .expression-tile {
+                min-width: 2.5em;
+                padding: 10px;
+                font-size: 1.4em;
+                font-weight: bold;
+                background-color: var(--bccblue);
+                color: white;
+                border: none;
+                border-radius: 6px;
+                cursor: pointer;
+            }
+            .expression-tile.operator {
+                background-color: var(--bccdarkgrey);
+            }
+            .expression-input {
+                width: 100%;
+                padding: 15px 20px;
+                border: 2px solid var(--bccblue);
+                border-radius: 8px;
+                font-size: 1.4em;
+                box-sizing: border-box;
+            }
+            .expression-results {
+                color: white;
+                width: 100%;
+                border-collapse: collapse;
+                margin-top: 10px;
+            }
+            .expression-results td {
+                padding: 4px 10px;
+                text-align: left;
+            }
+        `;
+    }
+
+    /**
+     * Creates a button which adds the given text to the expression
+     * @param {string} text the text to add
+     * @param {string} className extra class for the button
+     * @returns {Document.Object} the button
+     */
+    createTile(text, className) {
+        const button = document.createElement('button');
+        button.className = `expression-tile ${className}`;
+        button.textContent = text;
+        button.disabled = !this.isQuestionActive() || this.hasAnswered();
+        button.addEventListener('click', () => {
+            if (!this.textInput || this.textInput.disabled) {return;}
+            this.textInput.value += text;
+            this.textInput.focus();
+        });
+        this.tiles.push(button);
+        return button;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+        this.tiles = [];
+
+        const target = document.createElement('div');
+        target.className = 'expression-target';
+        target.textContent = cq.target;
+        container.appendChild(target);
+
+        let cp = this.getCurrentPlayer();
+        const isPlayer = cp && !cp.isSpectator;
+        const numbers = document.createElement('div');
+        numbers.className = 'expression-tiles';
+        for (const n of cq.numbers ?? []) {
+            numbers.appendChild(this.createTile(`${n}`, 'number'));
+        }
+        container.appendChild(numbers);
+
+        // spectators only see the target and numbers
+        if (!isPlayer) {return container;}
+
+        const operators = document.createElement('div');
+        operators.className = 'expression-tiles';
+        for (const op of [...(cq.operators || '+-*/'), '(', ')']) {
+            operators.appendChild(this.createTile(op, 'operator'));
+        }
+        container.appendChild(operators);
+
+        this.textInput = document.createElement('input');
+        this.textInput.type = 'text';
+        this.textInput.className = 'expression-input';
+        this.textInput.placeholder = 'eg. (25 + 50) * 3';
+        this.textInput.disabled = !this.isQuestionActive() || this.hasAnswered();
+        container.appendChild(this.textInput);
+        return container;
+    }
+
+    /**
+     * The server evaluates the expression, so we just send what the player typed
+     * @returns {Answer} the answer object or null if nothing has been typed
+     */
+    getAnswer() {
+        if (!this.textInput) {return null;}
+        const text = this.textInput.value.trim();
+        if (!text) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.answer = text;
+        answer.comment = '';
+        this.textInput.disabled = true;
+        this.tiles.forEach(tile => tile.disabled = true);
+        return answer;
+    }
+
+    /**
+     * Shows each players expression along with its value
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq || !cq.answers) {return container;}
+        const table = document.createElement('table');
+        table.className = 'expression-results';
+        for (const a of cq.answers) {
+            if (a.answer === '...') {continue;}
+            const row = table.insertRow();
+            row.insertCell().textContent = a.username;
+            row.insertCell().textContent = a.answer;
+            row.insertCell().textContent = a.comment;
+        }
+        container.appendChild(table);
+        return container;
+    }
+}
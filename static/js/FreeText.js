@@ -217,21 +217,21 @@ class FreeText extends PageElement {
 
         // Get the current question to access the correct answer
         const currentQuestion = gs.getCurrentQuestion();
-        if (!currentQuestion || !currentQuestion.correctAnswers || !currentQuestion.penalisationFactor) {
-            this.warn('FreeText: No correct answer available or missing penalisationFactor');
+        if (!currentQuestion || !currentQuestion.correctAnswers) {
+            this.warn('FreeText: No correct answer available');
             return null;
         }
-        let maxDistance = parseInt(currentQuestion.penalisationFactor);
+        // no penalisationFactor means the answer must be exact, as for numbers
+        let maxDistance = parseInt(currentQuestion.penalisationFactor ?? 0);
         if (isNaN(maxDistance)) {
             this.warn('FreeText: penalisationFactor is not a number');
             return null;
         }
         if (maxDistance == 0) {
-            if (currentQuestion.correctAnswers.includes(textInput)) {
-                answer.points = currentQuestion.pointsAvailable;
-            } else {
-                answer.points = 0;
-            }
+            const ca = currentQuestion.correctAnswers.find(ca => ca.toLowerCase() === textValue.toLowerCase());
+            answer.points = ca ? currentQuestion.pointsAvailable : 0;
+            answer.answer = ca ?? textValue;
+            answer.comment = ca ? 'Exact match' : 'No exact match';
             return answer;
         }
         for (const ca of currentQuestion.correctAnswers) {
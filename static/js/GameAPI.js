@@ -51,11 +51,20 @@ class GameAPI {
     initialisePageElements() {
         // Move page element initialization to separate method
         this.allPageElements.push(new QuestionView());
+        this.allPageElements.push(new MediaPlayer());
         this.allPageElements.push(new ClickMap());
         this.allPageElements.push(new FreeText());
         this.allPageElements.push(new MultiChoice());
         this.allPageElements.push(new StreetView());
         this.allPageElements.push(new GridImage());
+        this.allPageElements.push(new TrueFalse());
+        this.allPageElements.push(new RevealImage());
+        this.allPageElements.push(new Wordle());
+        this.allPageElements.push(new Crossword());
+        this.allPageElements.push(new Expression());
+        this.allPageElements.push(new Matching());
+        this.allPageElements.push(new RangeEstimate());
+        this.allPageElements.push(new Sketch());
         // Initialize buttons
         this.allPageElements.push(new AnswerButton());
         this.allPageElements.push(new NextQuestionButton());
@@ -71,6 +80,10 @@ class GameAPI {
         this.allPageElements.push(new CurrentAnswers());
         // host
         this.allPageElements.push(new PlayerAdmin());
+        this.allPageElements.push(new SketchGallery());
+        this.allPageElements.push(new QuestionGenerator());
+        this.allPageElements.push(new PackSelector());
+        this.allPageElements.push(new SeenWarning());
         // player
         this.allPageElements.push(new PlayerMessage());
     }
@@ -553,6 +566,7 @@ class GameAPI {
             switch(questionType) {
                 case 'kazakhstan':
                 case 'geolocation':
+                case 'country':
                     return element instanceof ClickMap;
                 case 'multichoice':
                     return element instanceof MultiChoice;
@@ -560,6 +574,22 @@ class GameAPI {
                     return element instanceof FreeText;
                 case 'gridimage':
                     return element instanceof GridImage;
+                case 'truefalse':
+                    return element instanceof TrueFalse;
+                case 'reveal':
+                    return element instanceof RevealImage;
+                case 'wordle':
+                    return element instanceof Wordle;
+                case 'crossword':
+                    return element instanceof Crossword;
+                case 'expression':
+                    return element instanceof Expression;
+                case 'matching':
+                    return element instanceof Matching;
+                case 'range':
+                    return element instanceof RangeEstimate;
+                case 'sketch':
+                    return element instanceof Sketch;
                 default:
                     return null;
             }
@@ -1,6 +1,8 @@
 /**
  * A PageElement which implements an image which is overlayed with
  * a grid of elements (div's) into which answers can be dragged and dropped
+ * Local images are sliced into tiles by the server, each fetched by an
+ * opaque id, remote images are divided up here
  */
 class GridImage extends PageElement {
     constructor() {
@@ -160,8 +162,13 @@ class GridImage extends PageElement {
         const gridBoard = document.createElement('div');
         gridBoard.className = 'grid-board';
 
-        // First, let's determine the image dimensions
-        if (cq.imageUrl) {
+        // tiles sliced by the server already know their size
+        if (cq.tiles && cq.tileSize) {
+            gridBoard.style.aspectRatio = `${cq.tileSize[0] * this.gridSize.cols} / ${cq.tileSize[1] * this.gridSize.rows}`;
+            gridBoard.style.width = '100%';
+            gridBoard.style.height = 'auto';
+        } else if (cq.imageUrl) {
+            // First, let's determine the image dimensions
             const img = new Image();
             img.onload = () => {
                 const aspectRatio = img.height / img.width;
@@ -185,7 +192,14 @@ class GridImage extends PageElement {
             cell.className = 'grid-cell';
             cell.dataset.cellIndex = i;
 
-            if (cq.imageUrl) {
+            if (cq.tiles) {
+                cell.style.cssText = `
+                    position: relative;
+                    overflow: hidden;
+                    background-image: url(/api/grid-tile?id=${cq.tiles[i]});
+                    background-size: 100% 100%;
+                `;
+            } else if (cq.imageUrl) {
                 cell.style.cssText = `
                     position: relative;
                     overflow: hidden;
@@ -59,6 +59,9 @@ class Leaderboard extends PageElement {
             return container;
         }
 
+        // only show calibration once some range questions have been played
+        const showCalibration = Object.values(api.leaderboard ?? {}).some(p => p.rangesAnswered > 0);
+
         // Create table
         const t = document.createElement('table');
         let h = `
@@ -68,6 +71,7 @@ class Leaderboard extends PageElement {
                         <th>Player</th>
                         <th>Rating</th>
                         <th>Total</th>
+                        ${showCalibration ? '<th>Calibration</th>' : ''}
                     </tr>
                 </thead>
                 <tbody>
@@ -88,6 +92,7 @@ class Leaderboard extends PageElement {
                         <td>${player.username}</td>
                         <td>${player.percent}%</td>
                         <td>${pts}</td>
+                        ${showCalibration ? `<td>${this.getCalibration(player)}</td>` : ''}
                     </tr>
                 `;
             }
@@ -107,4 +112,14 @@ class Leaderboard extends PageElement {
             return container;
         }
     }
+
+    /**
+     * @param {Player} player
+     * @returns {string} how often the players ranges held the true value eg. 3/4 (75%)
+     */
+    getCalibration(player) {
+        if (!player.rangesAnswered) {return '-';}
+        const pc = Math.round(100 * player.rangesHit / player.rangesAnswered);
+        return `${player.rangesHit}/${player.rangesAnswered} (${pc}%)`;
+    }
 }
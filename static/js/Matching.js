@@ -0,0 +1,279 @@
+/**
+ * PageElement which implements the 'matching' pairs question type.
+ * The question choices make up the left hand list and the question matches
+ * the right hand list, either of which may be text or images (imgUrl).
+ * The player links items by clicking one on the left and then one on the right,
+ * the links are drawn as lines between the two lists.
+ * The server scores each correct pair, the correct pairs are only sent once
+ * the question has ended.
+ */
+class Matching extends PageElement {
+    constructor() {
+        super('matching-container', ['matching']);
+        this.isPlayableComponent = true;
+        this.lastQuestionActive = false;
+        this.links = [];
+        this.selected = -1;
+        this.questionNumber = -1;
+        this.board = null;
+        this.lines = null;
+        this.linkColour = 'var(--bcclightgold)';
+        this.correctColour = '#6aaa64';
+        this.wrongColour = '#c0504d';
+        window.addEventListener('resize', () => this.drawLinks());
+    }
+
+    shouldUpdate() {
+        const currentQuestionActive = this.isQuestionActive();
+        if (currentQuestionActive !== this.lastQuestionActive) {
+            this.lastQuestionActive = currentQuestionActive;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #matching-container {
+                padding: 15px;
+                margin: 0 auto;
+            }
+            .matching-board {
+                position: relative;
+                display: flex;
+                justify-content: space-between;
+                gap: 80px;
+                max-width: 800px;
+                margin: 0 auto;
+            }
+            .matching-column {
+                display: flex;
+                flex-direction: column;
+                gap: 10px;
+                flex: 1;
+                z-index: 1;
+            }
+            .matching-item {
+                padding: 10px;
+                background-color: var(--bccblue);
+                color: white;
+                border: 2px solid transparent;
+                border-radius: 8px;
+                cursor: pointer;
+                text-align: center;
+                user-select: none;
+            }
+            .matching-item.selected {
+                border-color: var(--bcclightgold);
+            }
+            .matching-item.linked {
+                background-color: var(--bccdarkgrey);
+            }
+            .matching-item img {
+                max-width: 100%;
+                max-height: 120px;
+                display: block;
+                margin: 0 auto;
+            }
+            .matching-lines {
+                position: absolute;
+                top: 0;
+                left: 0;
+                width: 100%;
+                height: 100%;
+                pointer-events: none;
+            }
+        `;
+    }
+
+    /**
+     * Creates one item of either list
+     * @param {Choice} choice the item
+     * @returns {Document.Object} the item
+     */
+    createItem(choice) {
+        const item = document.createElement('div');
+        item.className = 'matching-item';
+        if (choice.imgUrl) {
+            const img = document.createElement('img');
+            img.src = choice.imgUrl;
+            img.alt = choice.choice;
+            // lines must be redrawn once images have a size
+            img.addEventListener('load', () => this.drawLinks());
+            item.appendChild(img);
+        }
+        if (choice.choice) {
+            const text = document.createElement('div');
+            text.textContent = choice.choice;
+            item.appendChild(text);
+        }
+        return item;
+    }
+
+    /**
+     * Creates the two lists and the layer the links are drawn on
+     * @param {function} onLeft called with the index of a clicked left hand item
+     * @param {function} onRight called with the index of a clicked right hand item
+     * @returns {Document.Object} the board
+     */
+    createBoard(onLeft, onRight) {
+        let cq = this.getCurrentQuestion();
+        this.board = document.createElement('div');
+        this.board.className = 'matching-board';
+        const svg = document.createElementNS('http://www.w3.org/2000/svg', 'svg');
+        svg.classList.add('matching-lines');
+        this.board.appendChild(svg);
+        const left = document.createElement('div');
+        left.className = 'matching-column left';
+        (cq.choices ?? []).forEach((c, i) => {
+            const item = this.createItem(c);
+            if (onLeft) {item.addEventListener('click', () => onLeft(i));}
+            left.appendChild(item);
+        });
+        const right = document.createElement('div');
+        right.className = 'matching-column right';
+        (cq.matches ?? []).forEach((m, i) => {
+            const item = this.createItem(m);
+            if (onRight) {item.addEventListener('click', () => onRight(i));}
+            right.appendChild(item);
+        });
+        this.board.appendChild(left);
+        this.board.appendChild(right);
+        return this.board;
+    }
+
+    /**
+     * Draws the given links between the two lists
+     * @param {array} lines objects of {from, to, colour} where from and to are
+     * indexes into the left and right lists
+     */
+    drawLines(lines) {
+        if (!this.board || !this.board.isConnected) {return;}
+        const svg = this.board.querySelector('.matching-lines');
+        const lefts = this.board.querySelectorAll('.matching-column.left .matching-item');
+        const rights = this.board.querySelectorAll('.matching-column.right .matching-item');
+        const b = this.board.getBoundingClientRect();
+        svg.replaceChildren();
+        for (const line of lines) {
+            if (!lefts[line.from] || !rights[line.to]) {continue;}
+            const l = lefts[line.from].getBoundingClientRect();
+            const r = rights[line.to].getBoundingClientRect();
+            const path = document.createElementNS('http://www.w3.org/2000/svg', 'line');
+            path.setAttribute('x1', l.right - b.left);
+            path.setAttribute('y1', l.top + l.height / 2 - b.top);
+            path.setAttribute('x2', r.left - b.left);
+            path.setAttribute('y2', r.top + r.height / 2 - b.top);
+            path.setAttribute('stroke', line.colour);
+            path.setAttribute('stroke-width', '4');
+            path.setAttribute('stroke-linecap', 'round');
+            svg.appendChild(path);
+        }
+    }
+
+    /**
+     * Draws the players links, or the correct links once the answer is shown
+     */
+    drawLinks() {
+        if (!this.board) {return;}
+        // wait for the board to be laid out
+        requestAnimationFrame(() => requestAnimationFrame(() => {
+            if (this.lines) {
+                this.drawLines(this.lines);
+                return;
+            }
+            const lines = [];
+            this.links.forEach((to, from) => {
+                if (to >= 0) {lines.push({from: from, to: to, colour: this.linkColour});}
+            });
+            this.drawLines(lines);
+            const lefts = this.board.querySelectorAll('.matching-column.left .matching-item');
+            const rights = this.board.querySelectorAll('.matching-column.right .matching-item');
+            lefts.forEach((item, i) => {
+                item.classList.toggle('selected', i === this.selected);
+                item.classList.toggle('linked', this.links[i] >= 0);
+            });
+            rights.forEach((item, i) => item.classList.toggle('linked', this.links.includes(i)));
+        }));
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        this.lines = null;
+        if (this.questionNumber !== cq.questionNumber) {
+            this.questionNumber = cq.questionNumber;
+            this.links = (cq.choices ?? []).map(() => -1);
+            this.selected = -1;
+        }
+        let cp = this.getCurrentPlayer();
+        const canPlay = cp && !cp.isSpectator && this.isQuestionActive() && !this.hasAnswered();
+        if (!canPlay) {
+            const board = this.createBoard(null, null);
+            this.drawLinks();
+            return board;
+        }
+        const board = this.createBoard(
+            (i) => {
+                // clicking a linked item again unlinks it
+                if (this.links[i] >= 0) {
+                    this.links[i] = -1;
+                    this.selected = -1;
+                } else {
+                    this.selected = i === this.selected ? -1 : i;
+                }
+                this.drawLinks();
+            },
+            (i) => {
+                if (this.selected < 0) {return;}
+                // a right hand item can only be linked once
+                const existing = this.links.indexOf(i);
+                if (existing >= 0) {this.links[existing] = -1;}
+                this.links[this.selected] = i;
+                this.selected = -1;
+                this.drawLinks();
+            }
+        );
+        this.drawLinks();
+        return board;
+    }
+
+    /**
+     * Sends the index of the right hand item linked to each left hand item
+     * @returns {Answer} the answer object or null if nothing has been linked
+     */
+    getAnswer() {
+        if (!this.links.some(l => l >= 0)) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.links = [...this.links];
+        answer.answer = '';
+        answer.comment = '';
+        return answer;
+    }
+
+    /**
+     * Shows the correct links, along with whether the current player got each one
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq) {return container;}
+        let cp = this.getCurrentPlayer();
+        const mine = (cq.answers ?? []).find(a => cp && a.username === cp.username);
+        const matches = (cq.matches ?? []).map(m => m.choice.trim().toLowerCase());
+        this.lines = [];
+        (cq.choices ?? []).forEach((c, from) => {
+            const to = matches.indexOf((c.answer ?? '').trim().toLowerCase());
+            if (to < 0) {return;}
+            let colour = this.linkColour;
+            if (mine && mine.links) {
+                colour = mine.links[from] === to ? this.correctColour : this.wrongColour;
+            }
+            this.lines.push({from: from, to: to, colour: colour});
+        });
+        container.appendChild(this.createBoard(null, null));
+        this.drawLinks();
+        return container;
+    }
+}
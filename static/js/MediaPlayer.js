@@ -0,0 +1,153 @@
+/**
+ * PageElement which plays the audio or video clip attached to a question.
+ * Can be used with any question type, players answer using the normal answer
+ * component for the question. Only the observer screens play the clip.
+ * The server tells us the time at which the clip should be at its start offset
+ * (currentQuestion.mediaStartsAt) and its own clock (gameState.serverTime) so
+ * every screen can work out where in the clip it should be and play together.
+ */
+class MediaPlayer extends PageElement {
+    constructor() {
+        super('media-container', ['*']);
+        this.media = null;
+        this.startTimer = null;
+        this.clockOffset = 0;
+        // how far (seconds) a screen may drift before we seek to correct it
+        this.maxDrift = 0.3;
+    }
+
+    shouldShow() {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return false;}
+        return !!(cq.audioUrl || cq.videoUrl);
+    }
+
+    shouldUpdate() {
+        // keep the playback in sync on every poll
+        if (this.media) {this.sync();}
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #media-container {
+                width: 100%;
+                max-width: 800px;
+                margin: 0 auto 20px auto;
+                text-align: center;
+            }
+            .media-video {
+                width: 100%;
+                height: auto;
+                border-radius: 8px;
+            }
+            .media-enable-button {
+                padding: 10px 20px;
+                font-size: 1.2em;
+                cursor: pointer;
+            }
+        `;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+        if (cq.videoUrl) {
+            this.media = document.createElement('video');
+            this.media.className = 'media-video';
+            this.media.src = cq.videoUrl;
+            this.media.playsInline = true;
+        } else {
+            this.media = document.createElement('audio');
+            this.media.src = cq.audioUrl;
+        }
+        this.media.preload = 'auto';
+        this.media.controls = false;
+        // stop at the end offset if there is one
+        this.media.addEventListener('timeupdate', () => {
+            if (cq.mediaEnd && this.media.currentTime >= cq.mediaEnd) {
+                this.media.pause();
+            }
+        });
+        container.appendChild(this.media);
+        this.sync();
+        return container;
+    }
+
+    /**
+     * @returns {number} the current time (ms) according to the server clock
+     */
+    getServerNow() {
+        let gs = this.getGameState();
+        if (gs && gs.serverTime) {
+            this.clockOffset = gs.serverTime - Date.now();
+        }
+        return Date.now() + this.clockOffset;
+    }
+
+    /**
+     * Works out where the clip should be right now and plays, pauses or
+     * seeks the media element to match
+     */
+    sync() {
+        let cq = this.getCurrentQuestion();
+        if (!cq || !this.media) {return;}
+        if (!cq.mediaStartsAt || !this.isQuestionActive()) {
+            this.cancelStart();
+            if (!this.media.paused) {this.media.pause();}
+            return;
+        }
+        const start = cq.mediaStart || 0;
+        const untilStart = cq.mediaStartsAt - this.getServerNow();
+        if (untilStart > 0) {
+            // not time yet, park at the start offset and schedule playback
+            if (!this.startTimer) {
+                this.media.currentTime = start;
+                this.startTimer = setTimeout(() => {
+                    this.startTimer = null;
+                    this.sync();
+                }, untilStart);
+            }
+            return;
+        }
+        const target = start + (-untilStart / 1000);
+        if (cq.mediaEnd && target >= cq.mediaEnd) {
+            if (!this.media.paused) {this.media.pause();}
+            return;
+        }
+        if (Math.abs(this.media.currentTime - target) > this.maxDrift) {
+            this.media.currentTime = target;
+        }
+        if (this.media.paused) {this.play();}
+    }
+
+    /**
+     * Starts playback. Browsers may refuse to play sound until the page has
+     * been interacted with, in which case we offer a button to press
+     */
+    play() {
+        const p = this.media.play();
+        if (!p) {return;}
+        p.catch(error => {
+            this.warn('Playback was blocked:', error);
+            const container = this.getElement();
+            if (!container || container.querySelector('.media-enable-button')) {return;}
+            const button = document.createElement('button');
+            button.className = 'media-enable-button';
+            button.textContent = 'Enable sound';
+            button.addEventListener('click', () => {
+                button.remove();
+                this.sync();
+            });
+            container.appendChild(button);
+        });
+    }
+
+    cancelStart() {
+        if (this.startTimer) {
+            clearTimeout(this.startTimer);
+            this.startTimer = null;
+        }
+    }
+}
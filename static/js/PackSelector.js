@@ -0,0 +1,153 @@
+/**
+ * PageElement for the host which lists the question packs and lets the
+ * host choose which of them to play before the game starts, either in
+ * order or composed into a game of falling percents
+ */
+class PackSelector extends PageElement {
+    constructor() {
+        super('pack-selector', ['*']);
+        this.packs = null;
+        this.packsLoaded = false;
+        this.selected = '';
+    }
+
+    static checkedPacks() {
+        const checked = document.querySelectorAll('#pack-selector input[type=checkbox]:checked');
+        return Array.from(checked).map(cb => cb.value).join(',');
+    }
+
+    static async select() {
+        await GameAPI.sendHttpRequest(`/api/select-packs?packs=${PackSelector.checkedPacks()}`);
+    }
+
+    static async compose() {
+        const value = id => document.getElementById(`pack-selector-${id}`)?.value ?? '';
+        const params = ['count', 'max', 'min', 'perCategory', 'require', 'seed']
+            .map(p => `${p}=${value(p)}`).join('&');
+        await GameAPI.sendHttpRequest(`/api/compose-game?packs=${PackSelector.checkedPacks()}&${params}`);
+    }
+
+    static async importCsv() {
+        const file = document.getElementById('pack-selector-csv')?.files?.[0];
+        const name = document.getElementById('pack-selector-name')?.value ?? '';
+        if (!file || name === '') {return;}
+        const form = new FormData();
+        form.append('file', file);
+        const response = await fetch(`/api/import-csv?pack=${encodeURIComponent(name)}`, {
+            method: 'POST',
+            body: form
+        });
+        const result = document.getElementById('pack-selector-result');
+        if (result) {result.textContent = response.ok ? '' : await response.text();}
+        // list the new pack
+        const ps = GameAPI.getInstance().allPageElements.find(pe => pe instanceof PackSelector);
+        if (ps && response.ok) {ps.loadPacks();}
+    }
+
+    async loadPacks() {
+        const response = await GameAPI.sendHttpRequest('/api/packs');
+        if (!response) {
+            this.warn('Failed to load the question packs');
+            return;
+        }
+        this.packs = JSON.parse(response).packs;
+        this.packsLoaded = true;
+    }
+
+    shouldShow() {
+        let cp = this.getCurrentPlayer();
+        return !!(cp && cp.isAdmin);
+    }
+
+    shouldUpdate() {
+        if (this.packs === null) {
+            this.packs = [];
+            this.loadPacks();
+            return false;
+        }
+        const gs = this.getGameState();
+        const selected = (gs?.packs ?? []).join(',') + '#' + (gs?.composition?.seed ?? '');
+        if (this.packsLoaded || selected !== this.selected) {
+            this.packsLoaded = false;
+            this.selected = selected;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #pack-selector {
+                margin: 10px 0;
+                color: white;
+            }
+            #pack-selector label {
+                display: block;
+            }
+            #pack-selector input[type=number] {
+                width: 3.5em;
+            }
+            #pack-selector input[type=text] {
+                width: 8em;
+            }
+            #pack-selector .pack-details {
+                color: #aaa;
+                font-size: 0.8em;
+            }
+        `;
+    }
+
+    getContent(api) {
+        const container = document.createElement('div');
+        const selected = this.getGameState()?.packs ?? [];
+        const composition = this.getGameState()?.composition;
+        const difficulties = ['', 'easy', 'medium', 'hard'];
+        for (const p of this.packs ?? []) {
+            const details = [
+                p.author ? `by ${p.author}` : '',
+                difficulties[p.difficulty] ?? '',
+                `${p.questions} questions`,
+                p.duration ? `${p.duration} minutes` : '',
+                (p.tags ?? []).join(', ')
+            ].filter(d => d !== '').join(' · ');
+            const label = document.createElement('label');
+            label.innerHTML = `
+                <input type="checkbox" value="${p.name}" ${selected.includes(p.name) ? 'checked' : ''}>
+                ${p.title} <span class="pack-details">${details}</span>
+                <a class="pack-details" href="/api/export-csv?pack=${p.name}">[csv]</a>
+            `;
+            container.appendChild(label);
+        }
+        const compose = document.createElement('div');
+        compose.innerHTML = `
+            <button class="small-button" onclick="PackSelector.select()">play chosen packs in order</button>
+            or compose
+            <input type="number" id="pack-selector-count" min="1" value="${composition?.count ?? 20}">
+            questions from
+            <input type="number" id="pack-selector-max" min="1" max="100" value="${composition?.maxPercent ?? 90}">%
+            to
+            <input type="number" id="pack-selector-min" min="1" max="100" value="${composition?.minPercent ?? 1}">%
+            at most
+            <input type="number" id="pack-selector-perCategory" min="0" value="${composition?.perCategory ?? 3}">
+            per category including
+            <input type="text" id="pack-selector-require" placeholder="eg. geolocation" value="${(composition?.require ?? []).join(',')}">
+            seed
+            <input type="text" id="pack-selector-seed" placeholder="random">
+            <button class="small-button" onclick="PackSelector.compose()">compose</button>
+            ${composition ? `<span class="pack-details">composed with seed ${composition.seed}</span>` : ''}
+        `;
+        container.appendChild(compose);
+        const upload = document.createElement('div');
+        upload.innerHTML = `
+            new pack
+            <input type="text" id="pack-selector-name" placeholder="name">
+            from a spreadsheet saved as CSV
+            <input type="file" id="pack-selector-csv" accept=".csv,text/csv">
+            <button class="small-button" onclick="PackSelector.importCsv()">import</button>
+            <a class="pack-details" href="/api/export-csv">[questions.json as csv]</a>
+            <pre id="pack-selector-result" class="pack-details"></pre>
+        `;
+        container.appendChild(upload);
+        return container;
+    }
+}
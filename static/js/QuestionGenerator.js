@@ -0,0 +1,70 @@
+/**
+ * PageElement for the host which adds generated questions, such as
+ * identifying flags or logic puzzles, to the end of the game and reloads
+ * the questions file after it has been edited
+ */
+class QuestionGenerator extends PageElement {
+    constructor() {
+        super('question-generator', ['*']);
+        this.totalQuestions = -1;
+    }
+
+    static click(kind) {
+        const count = document.getElementById('question-generator-count')?.value ?? 1;
+        const difficulty = document.getElementById('question-generator-difficulty')?.value ?? 0;
+        GameAPI.sendHttpRequest(`/api/generate-questions?kind=${kind}&count=${count}&difficulty=${difficulty}`);
+    }
+
+    static reload() {
+        GameAPI.sendHttpRequest('/api/reload-questions');
+    }
+
+    shouldShow() {
+        let cp = this.getCurrentPlayer();
+        return !!(cp && cp.isAdmin);
+    }
+
+    shouldUpdate() {
+        const total = this.getGameState()?.totalQuestions ?? 0;
+        if (total !== this.totalQuestions) {
+            this.totalQuestions = total;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #question-generator {
+                display: flex;
+                flex-wrap: wrap;
+                align-items: center;
+                gap: 5px;
+                margin: 10px 0;
+                color: white;
+            }
+            #question-generator input {
+                width: 3em;
+            }
+        `;
+    }
+
+    getContent(api) {
+        const container = document.createElement('div');
+        container.style.display = 'contents';
+        container.innerHTML = `
+            <span>${this.totalQuestions} questions, add</span>
+            <input type="number" id="question-generator-count" min="1" max="50" value="5">
+            <select id="question-generator-difficulty">
+                <option value="0">any</option>
+                <option value="1">easy</option>
+                <option value="2">medium</option>
+                <option value="3">hard</option>
+            </select>
+            <button class="small-button" onclick="QuestionGenerator.click('flags')">flag questions</button>
+            <button class="small-button" onclick="QuestionGenerator.click('puzzles')">logic puzzles</button>
+            <button class="small-button" onclick="QuestionGenerator.reload()">reload questions</button>
+        `;
+        return container;
+    }
+}
@@ -0,0 +1,139 @@
+/**
+ * PageElement which implements the 'range' estimation question type.
+ * Rather than a single number the player gives a lower and upper bound which
+ * they think the true value lies between. The server scores the range, only
+ * ranges that hold the true value score and narrower ranges score more.
+ */
+class RangeEstimate extends PageElement {
+    constructor() {
+        super('range-container', ['range']);
+        this.isPlayableComponent = true;
+        this.lastQuestionActive = false;
+        this.lowerInput = null;
+        this.upperInput = null;
+    }
+
+    shouldUpdate() {
+        const currentQuestionActive = this.isQuestionActive();
+        if (currentQuestionActive !== this.lastQuestionActive) {
+            this.lastQuestionActive = currentQuestionActive;
+            // enable or disable in place so that typed values aren't lost
+            const disabled = !currentQuestionActive || this.hasAnswered();
+            if (this.lowerInput) {this.lowerInput.disabled = disabled;}
+            if (this.upperInput) {this.upperInput.disabled = disabled;}
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #range-container {
+                padding: 15px;
+                margin: 0 auto;
+                text-align: center;
+            }
+            .range-inputs {
+                display: flex;
+                gap: 15px;
+                align-items: center;
+                justify-content: center;
+                color: white;
+                font-size: 1.2em;
+            }
+            .range-input {
+                width: 40%;
+                padding: 15px 20px;
+                border: 2px solid var(--bccblue);
+                border-radius: 8px;
+                font-size: 16px;
+            }
+            .range-input:disabled {
+                opacity: 0.5;
+                cursor: not-allowed;
+            }
+            .range-results {
+                color: white;
+                width: 100%;
+                border-collapse: collapse;
+                margin-top: 10px;
+            }
+            .range-results td {
+                padding: 4px 10px;
+                text-align: left;
+            }
+        `;
+    }
+
+    /**
+     * @param {string} placeholder the placeholder text
+     * @returns {Document.Object} a number input for one of the bounds
+     */
+    createInput(placeholder) {
+        const input = document.createElement('input');
+        input.type = 'number';
+        input.step = 'any';
+        input.className = 'range-input';
+        input.placeholder = placeholder;
+        input.disabled = !this.isQuestionActive() || this.hasAnswered();
+        return input;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        let cp = this.getCurrentPlayer();
+        if (!cp || cp.isSpectator) {return null;}
+        const container = document.createElement('div');
+        container.className = 'range-inputs';
+        this.lowerInput = this.createInput('at least');
+        this.upperInput = this.createInput('at most');
+        const and = document.createElement('span');
+        and.textContent = 'to';
+        container.appendChild(this.lowerInput);
+        container.appendChild(and);
+        container.appendChild(this.upperInput);
+        return container;
+    }
+
+    /**
+     * The server checks the bounds and scores the range, so we just send them
+     * @returns {Answer} the answer object or null if either bound is missing
+     */
+    getAnswer() {
+        if (!this.lowerInput || !this.upperInput) {return null;}
+        const lower = parseFloat(this.lowerInput.value);
+        const upper = parseFloat(this.upperInput.value);
+        if (isNaN(lower) || isNaN(upper)) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.lower = lower;
+        answer.upper = upper;
+        answer.answer = `${lower} to ${upper}`;
+        answer.comment = '';
+        this.lowerInput.disabled = true;
+        this.upperInput.disabled = true;
+        return answer;
+    }
+
+    /**
+     * Shows each players range and whether it held the true value
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq || !cq.answers) {return container;}
+        const table = document.createElement('table');
+        table.className = 'range-results';
+        for (const a of cq.answers) {
+            if (a.lower === undefined || a.upper === undefined) {continue;}
+            const row = table.insertRow();
+            row.insertCell().textContent = a.username;
+            row.insertCell().textContent = a.answer;
+            row.insertCell().textContent = a.inRange ? '✔' : '✘';
+            row.insertCell().textContent = parseFloat(a.points).toFixed(1);
+        }
+        container.appendChild(table);
+        return container;
+    }
+}
@@ -0,0 +1,124 @@
+/**
+ * PageElement which implements the progressive image 'reveal' question type.
+ * The image starts heavily obscured and becomes clearer as the time runs down.
+ * The obscured stages are generated by the server which decides which stage
+ * to serve, so the full image is never available before the question ends.
+ * The player types their answer, the earlier they answer correctly the more points
+ */
+class RevealImage extends PageElement {
+    constructor() {
+        super('reveal-container', ['reveal']);
+        this.isPlayableComponent = true;
+        this.stage = -1;
+        this.image = null;
+        this.textInput = null;
+        this.lastQuestionActive = false;
+    }
+
+    shouldUpdate() {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return false;}
+        const stage = cq.revealStage ?? 0;
+        // swap the image without redrawing the input the player is typing in
+        if (this.image && stage !== this.stage) {
+            this.stage = stage;
+            this.image.src = `/api/reveal-image?stage=${stage}`;
+        }
+        const currentQuestionActive = this.isQuestionActive();
+        if (currentQuestionActive !== this.lastQuestionActive) {
+            this.lastQuestionActive = currentQuestionActive;
+            if (this.textInput) {
+                this.textInput.disabled = !currentQuestionActive || this.hasAnswered();
+            }
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #reveal-container {
+                padding: 15px;
+                margin: 0 auto;
+            }
+            .reveal-image-container {
+                width: 100%;
+                max-width: 800px;
+                margin: 0 auto 20px auto;
+                border-radius: 8px;
+                overflow: hidden;
+            }
+            .reveal-image {
+                width: 100%;
+                height: auto;
+                display: block;
+                image-rendering: pixelated;
+            }
+            .reveal-input {
+                width: 100%;
+                padding: 15px 20px;
+                border: 2px solid var(--bccblue);
+                border-radius: 8px;
+                font-size: 16px;
+            }
+            .reveal-input:disabled {
+                opacity: 0.5;
+                cursor: not-allowed;
+            }
+        `;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+
+        const imageContainer = document.createElement('div');
+        imageContainer.className = 'reveal-image-container';
+        this.stage = cq.revealStage ?? 0;
+        this.image = document.createElement('img');
+        this.image.className = 'reveal-image';
+        this.image.alt = 'Reveal Image';
+        this.image.src = `/api/reveal-image?stage=${this.stage}`;
+        imageContainer.appendChild(this.image);
+        container.appendChild(imageContainer);
+
+        // spectators only see the image
+        let cp = this.getCurrentPlayer();
+        if (!cp || cp.isSpectator) {return container;}
+
+        this.textInput = document.createElement('input');
+        this.textInput.type = 'text';
+        this.textInput.className = 'reveal-input';
+        this.textInput.placeholder = 'What is it?';
+        this.textInput.disabled = !this.isQuestionActive() || this.hasAnswered();
+        container.appendChild(this.textInput);
+        return container;
+    }
+
+    /**
+     * The server decides the points based on the reveal stage at the time
+     * the answer arrives, so we just send what the player typed
+     * @returns {Answer} the answer object or null if nothing has been typed
+     */
+    getAnswer() {
+        if (!this.textInput) {return null;}
+        const text = this.textInput.value.trim();
+        if (!text) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.answer = text;
+        answer.comment = '';
+        this.textInput.disabled = true;
+        return answer;
+    }
+
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq || !cq.imageUrl) {return container;}
+        const image = document.createElement('img');
+        image.className = 'reveal-image';
+        image.src = cq.imageUrl;
+        container.prepend(image);
+        return container;
+    }
+}
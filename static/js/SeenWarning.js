@@ -0,0 +1,48 @@
+/**
+ * PageElement for the host which warns, before a question is started,
+ * how many of the current players have been shown it in an earlier game
+ */
+class SeenWarning extends PageElement {
+    constructor() {
+        super('seen-warning', ['*']);
+        this.lastWarning = null;
+    }
+
+    getWarning() {
+        const cq = this.getCurrentQuestion();
+        if (!cq || !cq.seenBy || this.isQuestionActive() || cq.isTimedOut) {return '';}
+        const players = Object.values(this.getPlayers() ?? {}).filter(p => !p.isAdmin && !p.isSpectator);
+        const seen = (this.getGameState()?.allQuestions ?? []).filter(q => q.seenBy).length;
+        return `Seen before by ${cq.seenBy} of the ${players.length} current players` +
+            ` (${seen} of the questions to come have been seen by someone)`;
+    }
+
+    shouldShow() {
+        let cp = this.getCurrentPlayer();
+        return !!(cp && cp.isAdmin) && this.getWarning() !== '';
+    }
+
+    shouldUpdate() {
+        const warning = this.getWarning();
+        if (warning !== this.lastWarning) {
+            this.lastWarning = warning;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #seen-warning {
+                color: var(--bcclightgold);
+                margin: 10px 0;
+            }
+        `;
+    }
+
+    getContent(api) {
+        const container = document.createElement('div');
+        container.textContent = this.lastWarning;
+        return container;
+    }
+}
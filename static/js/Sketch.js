@@ -0,0 +1,242 @@
+/**
+ * PageElement which implements the pictionary style 'sketch' question type.
+ * Players draw on a canvas which is sent to the server as a list of strokes,
+ * each a flat list of x,y points in a 1000 x 1000 square.
+ * The host judges the drawings (see SketchGallery) and chooses which
+ * drawing the observer screens show.
+ */
+class Sketch extends PageElement {
+    // must match the limits in internal/game/sketch.go
+    static size = 1000;
+    static maxStrokes = 200;
+    static maxPoints = 5000;
+    // points closer together than this (in sketch units) are dropped
+    static minDistance = 6;
+
+    constructor() {
+        super('sketch-container', ['sketch']);
+        this.isPlayableComponent = true;
+        this.lastQuestionActive = false;
+        this.questionNumber = -1;
+        this.featured = null;
+        this.strokes = [];
+        this.canvas = null;
+        this.drawing = false;
+    }
+
+    shouldUpdate() {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return false;}
+        const currentQuestionActive = this.isQuestionActive();
+        const featured = cq.featured ?? '';
+        if (currentQuestionActive !== this.lastQuestionActive || featured !== this.featured) {
+            this.lastQuestionActive = currentQuestionActive;
+            this.featured = featured;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #sketch-container {
+                padding: 15px;
+                margin: 0 auto;
+                text-align: center;
+            }
+            .sketch-canvas {
+                width: 100%;
+                max-width: 500px;
+                aspect-ratio: 1;
+                background-color: white;
+                border-radius: 8px;
+                touch-action: none;
+                cursor: crosshair;
+            }
+            .sketch-buttons {
+                display: flex;
+                gap: 10px;
+                justify-content: center;
+                margin-top: 10px;
+            }
+            .sketch-featured {
+                width: 100%;
+                max-width: 700px;
+                border-radius: 8px;
+            }
+            .sketch-caption {
+                color: var(--bcclightgold);
+                font-size: 1.4em;
+                margin-top: 10px;
+            }
+        `;
+    }
+
+    /**
+     * @returns {number} the number of points in the drawing so far
+     */
+    countPoints() {
+        return this.strokes.reduce((n, s) => n + s.length / 2, 0);
+    }
+
+    /**
+     * Converts a pointer event into a point in the sketch square
+     * @param {PointerEvent} e
+     * @returns {array} [x, y]
+     */
+    toSketch(e) {
+        const r = this.canvas.getBoundingClientRect();
+        const x = Math.round((e.clientX - r.left) * Sketch.size / r.width);
+        const y = Math.round((e.clientY - r.top) * Sketch.size / r.height);
+        return [Math.max(0, Math.min(Sketch.size, x)), Math.max(0, Math.min(Sketch.size, y))];
+    }
+
+    /**
+     * Redraws the strokes onto the canvas
+     */
+    redraw() {
+        if (!this.canvas) {return;}
+        const ctx = this.canvas.getContext('2d');
+        ctx.clearRect(0, 0, Sketch.size, Sketch.size);
+        ctx.lineWidth = 8;
+        ctx.lineCap = 'round';
+        ctx.lineJoin = 'round';
+        ctx.strokeStyle = 'black';
+        for (const s of this.strokes) {
+            ctx.beginPath();
+            ctx.moveTo(s[0], s[1]);
+            for (let i = 2; i < s.length; i += 2) {ctx.lineTo(s[i], s[i + 1]);}
+            if (s.length === 2) {ctx.lineTo(s[0], s[1]);}
+            ctx.stroke();
+        }
+    }
+
+    /**
+     * Creates the canvas the player draws on
+     * @param {boolean} enabled false if the player can no longer draw
+     * @returns {Document.Object} the canvas
+     */
+    createCanvas(enabled) {
+        this.canvas = document.createElement('canvas');
+        this.canvas.className = 'sketch-canvas';
+        this.canvas.width = Sketch.size;
+        this.canvas.height = Sketch.size;
+        if (!enabled) {return this.canvas;}
+        this.canvas.addEventListener('pointerdown', (e) => {
+            if (this.strokes.length >= Sketch.maxStrokes || this.countPoints() >= Sketch.maxPoints) {return;}
+            this.drawing = true;
+            this.canvas.setPointerCapture(e.pointerId);
+            this.strokes.push(this.toSketch(e));
+            this.redraw();
+        });
+        this.canvas.addEventListener('pointermove', (e) => {
+            if (!this.drawing || this.countPoints() >= Sketch.maxPoints) {return;}
+            const s = this.strokes[this.strokes.length - 1];
+            const [x, y] = this.toSketch(e);
+            const dx = x - s[s.length - 2];
+            const dy = y - s[s.length - 1];
+            if (dx * dx + dy * dy < Sketch.minDistance * Sketch.minDistance) {return;}
+            s.push(x, y);
+            this.redraw();
+        });
+        const end = () => {this.drawing = false;};
+        this.canvas.addEventListener('pointerup', end);
+        this.canvas.addEventListener('pointercancel', end);
+        return this.canvas;
+    }
+
+    /**
+     * Creates an image of the drawing the given player made for the current question
+     * @param {string} username
+     * @param {string} className
+     * @returns {Document.Object} the image
+     */
+    createSketchImage(username, className) {
+        let cq = this.getCurrentQuestion();
+        const img = document.createElement('img');
+        img.className = className;
+        img.alt = `Drawing by ${username}`;
+        img.src = `/api/sketch?question=${cq.questionNumber}&username=${encodeURIComponent(username)}`;
+        return img;
+    }
+
+    /**
+     * Creates the drawing the host has chosen to show, if there is one
+     * @returns {Document.Object} the drawing and its caption or null
+     */
+    createFeatured() {
+        let cq = this.getCurrentQuestion();
+        if (!cq || !cq.featured) {return null;}
+        const div = document.createElement('div');
+        div.appendChild(this.createSketchImage(cq.featured, 'sketch-featured'));
+        const caption = document.createElement('div');
+        caption.className = 'sketch-caption';
+        caption.textContent = cq.featured;
+        div.appendChild(caption);
+        return div;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        if (this.questionNumber !== cq.questionNumber) {
+            this.questionNumber = cq.questionNumber;
+            this.strokes = [];
+        }
+        const container = document.createElement('div');
+        let cp = this.getCurrentPlayer();
+        if (!cp || cp.isSpectator || cp.isAdmin) {
+            const featured = this.createFeatured();
+            if (featured) {container.appendChild(featured);}
+            return container;
+        }
+        const enabled = this.isQuestionActive() && !this.hasAnswered();
+        container.appendChild(this.createCanvas(enabled));
+        this.redraw();
+        if (!enabled) {return container;}
+        const buttons = document.createElement('div');
+        buttons.className = 'sketch-buttons';
+        const undo = document.createElement('button');
+        undo.textContent = 'Undo';
+        undo.addEventListener('click', () => {
+            this.strokes.pop();
+            this.redraw();
+        });
+        const clear = document.createElement('button');
+        clear.textContent = 'Clear';
+        clear.addEventListener('click', () => {
+            this.strokes = [];
+            this.redraw();
+        });
+        buttons.appendChild(undo);
+        buttons.appendChild(clear);
+        container.appendChild(buttons);
+        return container;
+    }
+
+    /**
+     * Sends the drawing, the host decides the points later
+     * @returns {Answer} the answer object or null if nothing has been drawn
+     */
+    getAnswer() {
+        if (this.strokes.length === 0) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.strokes = this.strokes;
+        answer.answer = 'drawing';
+        answer.comment = '';
+        return answer;
+    }
+
+    /**
+     * Shows the drawing the host has chosen
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        if (!container) {return container;}
+        const featured = this.createFeatured();
+        if (featured) {container.appendChild(featured);}
+        return container;
+    }
+}
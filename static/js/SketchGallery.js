@@ -0,0 +1,102 @@
+/**
+ * PageElement for the host which shows every drawing made for the current
+ * 'sketch' question so that the host can award points for each and choose
+ * which drawing is shown on the observer screens
+ */
+class SketchGallery extends PageElement {
+    constructor() {
+        super('sketch-gallery', ['sketch']);
+        this.judged = null;
+        this.featured = null;
+        this.questionNumber = -1;
+    }
+
+    static click(username, action) {
+        if (!action) {return;}
+        let points = 0;
+        let pts = document.getElementById(`sketch-points-${username}`);
+        if (pts) {points = pts.value;}
+        GameAPI.sendHttpRequest(`/api/sketches?username=${username}&action=${action}&points=${points}`);
+    }
+
+    shouldShow() {
+        let cp = this.getCurrentPlayer();
+        return !!(cp && cp.isAdmin);
+    }
+
+    shouldUpdate() {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return false;}
+        // redraw when a drawing arrives or is judged
+        const judged = (cq.answers ?? []).filter(a => a.answer === 'drawing').map(a => `${a.username}:${a.points}`).join();
+        const featured = cq.featured ?? '';
+        if (judged !== this.judged || featured !== this.featured || cq.questionNumber !== this.questionNumber) {
+            this.judged = judged;
+            this.featured = featured;
+            this.questionNumber = cq.questionNumber;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #sketch-gallery {
+                display: flex;
+                flex-wrap: wrap;
+                gap: 10px;
+                margin: 10px 0;
+            }
+            .sketch-tile {
+                width: 180px;
+                padding: 5px;
+                border: 2px solid transparent;
+                border-radius: 6px;
+                background-color: var(--bccdarkgrey);
+                color: white;
+                font-size: 0.9em;
+            }
+            .sketch-tile.featured {
+                border-color: var(--bcclightgold);
+            }
+            .sketch-tile img {
+                width: 100%;
+                display: block;
+                border-radius: 4px;
+            }
+            .sketch-tile input {
+                width: 3em;
+            }
+        `;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+        container.style.display = 'contents';
+        // the host sees the points so far in the answers
+        for (const a of cq.answers ?? []) {
+            if (a.answer !== 'drawing') {continue;}
+            const tile = document.createElement('div');
+            tile.className = 'sketch-tile';
+            if (a.username === cq.featured) {tile.classList.add('featured');}
+            tile.innerHTML = `
+                <img src="/api/sketch?question=${cq.questionNumber}&username=${encodeURIComponent(a.username)}" alt="${a.username}">
+                <div>${a.username} (${parseFloat(a.points).toFixed(1)})</div>
+                <input type="number" min="0" max="${cq.pointsAvailable}" step="any" id="sketch-points-${a.username}" value="${a.points}">
+                <button class="small-button" onclick="SketchGallery.click('${a.username}', 'judge')">judge</button>
+                <button class="small-button" onclick="SketchGallery.click('${a.username}', 'show')">show</button>
+            `;
+            container.appendChild(tile);
+        }
+        if (cq.featured) {
+            const hide = document.createElement('button');
+            hide.className = 'small-button';
+            hide.textContent = 'hide drawing';
+            hide.addEventListener('click', () => SketchGallery.click('', 'show'));
+            container.appendChild(hide);
+        }
+        return container;
+    }
+}
@@ -1,6 +1,9 @@
 /**
  * PageElement that manages the displaying of google streetview for
- * geolocation questions
+ * geolocation questions, or of a local panorama image for venues without
+ * an internet connection. Panoramas are equirectangular images which the
+ * server slices into tiles at several zoom levels (see /api/panorama) so
+ * only the tiles in view are fetched, at a size to suit the screen
  */
 class StreetView extends PageElement {
     constructor() {
@@ -9,6 +12,13 @@ class StreetView extends PageElement {
         this.container = null;
         this.iframe = null;
         this.url = null;
+        // the panorama viewer
+        this.pyramid = null;
+        this.view = null;
+        this.scale = 1;
+        this.offsetX = 0;
+        this.offsetY = 0;
+        this.tiles = new Map();
     }
 
     /** just speed things up a little */
@@ -19,14 +29,19 @@ class StreetView extends PageElement {
         if (!this.url) {return true;}
         // if we have relevant data for this kind of question
         let cq = this.getCurrentQuestion()
-        if (!cq || !cq.streetView) {return false;}
+        if (!cq || !(cq.panorama || cq.streetView)) {return false;}
         // if we've started a different question
-        if (cq.streetView !== this.url) {return true;}
+        if ((cq.panorama || cq.streetView) !== this.url) {return true;}
         return false;
     }
 
     getContent(gs) {
         let cq = this.getCurrentQuestion();
+        if (cq.panorama) {
+            this.url = cq.panorama;
+            this.createPanorama(cq);
+            return;
+        }
         this.url = cq.streetView;
         const embedUrl = this.baseUrl + this.url;
         this.container = this.getElement();
@@ -60,4 +75,145 @@ class StreetView extends PageElement {
         this.container.appendChild(this.iframe);
         this.container.appendChild(overlay);
     }
-}
\ No newline at end of file
+
+    /**
+     * Replaces the content of the container with a viewer for the local
+     * panorama of the given question
+     * @param {Question} cq the current question
+     */
+    createPanorama(cq) {
+        this.container = this.getElement();
+        this.container.innerHTML = '';
+        this.view = document.createElement('div');
+        this.view.className = 'panorama-view';
+        this.container.appendChild(this.view);
+        this.tiles = new Map();
+        this.pyramid = null;
+        const buttons = document.createElement('div');
+        buttons.className = 'panorama-buttons';
+        for (const [label, factor] of [['+', 1.5], ['-', 1 / 1.5]]) {
+            const b = document.createElement('button');
+            b.textContent = label;
+            b.addEventListener('click', () => this.zoom(factor, this.view.clientWidth / 2, this.view.clientHeight / 2));
+            buttons.appendChild(b);
+        }
+        this.container.appendChild(buttons);
+        this.addPanoramaEvents();
+        const url = this.url;
+        fetch(`/api/panorama?question=${cq.questionNumber}`)
+            .then(response => response.ok ? response.json() : null)
+            .then(pyramid => {
+                // the question may have moved on while we waited
+                if (!pyramid || url !== this.url) {return;}
+                this.pyramid = pyramid;
+                this.scale = this.minScale();
+                this.offsetX = 0;
+                this.offsetY = 0;
+                this.drawPanorama();
+            })
+            .catch(error => this.warn(`Failed to load panorama: ${error.message}`));
+    }
+
+    /**
+     * @returns {number} the smallest scale, at which the panorama fills the view from top to bottom
+     */
+    minScale() {
+        return this.view.clientHeight / this.pyramid.height;
+    }
+
+    /**
+     * Zooms the panorama about the given point of the view
+     * @param {number} factor how much to zoom by, more than one zooms in
+     * @param {number} x the x coordinate of the point in the view
+     * @param {number} y the y coordinate of the point in the view
+     */
+    zoom(factor, x, y) {
+        if (!this.pyramid) {return;}
+        const scale = Math.max(this.minScale(), Math.min(2, this.scale * factor));
+        this.offsetX = (this.offsetX + x) * scale / this.scale - x;
+        this.offsetY = (this.offsetY + y) * scale / this.scale - y;
+        this.scale = scale;
+        this.drawPanorama();
+    }
+
+    /**
+     * Lets the player drag the panorama around and zoom with the mouse wheel
+     */
+    addPanoramaEvents() {
+        let last = null;
+        this.view.addEventListener('pointerdown', (e) => {
+            last = [e.clientX, e.clientY];
+            this.view.setPointerCapture(e.pointerId);
+        });
+        this.view.addEventListener('pointermove', (e) => {
+            if (!last || !this.pyramid) {return;}
+            this.offsetX -= e.clientX - last[0];
+            this.offsetY -= e.clientY - last[1];
+            last = [e.clientX, e.clientY];
+            this.drawPanorama();
+        });
+        const end = () => {last = null;};
+        this.view.addEventListener('pointerup', end);
+        this.view.addEventListener('pointercancel', end);
+        this.view.addEventListener('wheel', (e) => {
+            e.preventDefault();
+            const r = this.view.getBoundingClientRect();
+            this.zoom(e.deltaY < 0 ? 1.2 : 1 / 1.2, e.clientX - r.left, e.clientY - r.top);
+        }, { passive: false });
+    }
+
+    /**
+     * Positions the tiles of the panorama which are in view, fetching any new
+     * ones from the server. The smallest zoom level with at least as much detail
+     * as the screen is used and the panorama wraps around horizontally
+     */
+    drawPanorama() {
+        const p = this.pyramid;
+        if (!p || !this.view) {return;}
+        const vw = this.view.clientWidth;
+        const vh = this.view.clientHeight;
+        const width = p.width * this.scale;
+        const height = p.height * this.scale;
+        this.offsetY = Math.max(0, Math.min(height - vh, this.offsetY));
+        this.offsetX = ((this.offsetX % width) + width) % width;
+        let level = p.levels.length - 1;
+        while (level > 0 && p.levels[level - 1].width >= width * window.devicePixelRatio) {level--;}
+        const l = p.levels[level];
+        // the size on screen of one pixel of the level
+        const f = width / l.width;
+        const used = new Set();
+        for (let wrap = 0; wrap * width < this.offsetX + vw; wrap++) {
+            for (let col = 0; col < l.cols; col++) {
+                const left = wrap * width + col * p.tileSize * f - this.offsetX;
+                const tw = Math.min(p.tileSize, l.width - col * p.tileSize) * f;
+                if (left + tw < 0 || left > vw) {continue;}
+                for (let row = 0; row < l.rows; row++) {
+                    const top = row * p.tileSize * f - this.offsetY;
+                    const th = Math.min(p.tileSize, l.height - row * p.tileSize) * f;
+                    if (top + th < 0 || top > vh) {continue;}
+                    const key = `${wrap}/${level}/${col}/${row}`;
+                    let img = this.tiles.get(key);
+                    if (!img) {
+                        img = document.createElement('img');
+                        img.draggable = false;
+                        img.src = `/api/panorama-tile?question=${this.getCurrentQuestion().questionNumber}&level=${level}&col=${col}&row=${row}`;
+                        this.tiles.set(key, img);
+                        this.view.appendChild(img);
+                    }
+                    // overlap by a pixel to hide the seams
+                    img.style.left = `${left}px`;
+                    img.style.top = `${top}px`;
+                    img.style.width = `${tw + 1}px`;
+                    img.style.height = `${th + 1}px`;
+                    used.add(key);
+                }
+            }
+        }
+        for (const [key, img] of this.tiles) {
+            if (!used.has(key)) {
+                img.remove();
+                this.tiles.delete(key);
+            }
+        }
+    }
+}
@@ -0,0 +1,214 @@
+/**
+ * PageElement which implements the rapid fire 'truefalse' question type.
+ * The question holds a list of statements which the player answers one after
+ * another, true or false, within the single question time limit.
+ * Each response is sent to the server as soon as it is made so that the server
+ * can record when it was made. The server scores the answer once all statements
+ * have been answered (or the time runs out)
+ */
+class TrueFalse extends PageElement {
+    constructor() {
+        super('true-false-container', ['truefalse']);
+        this.isPlayableComponent = true;
+        this.responseCount = -1;
+        this.lastQuestionActive = false;
+        this.isSending = false;
+    }
+
+    shouldUpdate() {
+        const currentQuestionActive = this.isQuestionActive();
+        const responseCount = this.getResponses().length;
+        if (currentQuestionActive !== this.lastQuestionActive || responseCount !== this.responseCount) {
+            this.lastQuestionActive = currentQuestionActive;
+            this.responseCount = responseCount;
+            return true;
+        }
+        return false;
+    }
+
+    /**
+     * @returns {array} the responses the server has recorded for the current player so far
+     */
+    getResponses() {
+        let cq = this.getCurrentQuestion();
+        let cp = this.getCurrentPlayer();
+        if (!cq || !cp || !cq.statementResponses) {return [];}
+        return cq.statementResponses[cp.username] ?? [];
+    }
+
+    /**
+     * Sends the players response to the statement at the given index to the server
+     * @param {number} index the index of the statement being answered
+     * @param {boolean} response true if the player thinks the statement is true
+     */
+    async sendResponse(index, response) {
+        if (this.isSending || !this.isQuestionActive()) {return;}
+        this.isSending = true;
+        try {
+            const r = await fetch('/api/submit-statement', {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify({index: index, response: response})
+            });
+            if (!r.ok) {
+                this.warn('Server rejected statement response:', r.status);
+            }
+        } catch (error) {
+            this.warn('Failed to submit statement response:', error);
+        } finally {
+            this.isSending = false;
+        }
+        this.getApi().update();
+    }
+
+    createStyles() {
+        return `
+            #true-false-container {
+                padding: 15px;
+                margin: 0 auto;
+                text-align: center;
+            }
+            .true-false-progress {
+                color: var(--bcclightgold);
+                font-size: 1.2em;
+                margin-bottom: 10px;
+            }
+            .true-false-statement {
+                color: white;
+                font-size: 1.6em;
+                margin: 20px 0;
+            }
+            .true-false-buttons {
+                display: flex;
+                gap: 12px;
+                justify-content: center;
+            }
+            .true-false-button {
+                flex: 1;
+                max-width: 300px;
+                padding: 20px;
+                font-size: 1.4em;
+                border: 2px solid var(--bccblue);
+                border-radius: 8px;
+                cursor: pointer;
+                color: white;
+            }
+            .true-false-button.true {
+                background: var(--bccsage);
+            }
+            .true-false-button.false {
+                background: var(--bccrust);
+            }
+            .true-false-button:disabled {
+                opacity: 0.5;
+                cursor: not-allowed;
+            }
+            .true-false-reveal {
+                width: 100%;
+                text-align: left;
+            }
+        `;
+    }
+
+    getContent(api) {
+        const cq = this.getCurrentQuestion();
+        if (!cq || !cq.statements) {return null;}
+        const container = document.createElement('div');
+        const total = cq.statements.length;
+        const responses = this.getResponses();
+        const cp = this.getCurrentPlayer();
+
+        const progress = document.createElement('div');
+        progress.className = 'true-false-progress';
+        container.appendChild(progress);
+
+        // spectators just get told how many statements there are
+        if (!cp || cp.isSpectator) {
+            progress.textContent = `${total} statements, true or false?`;
+            return container;
+        }
+
+        if (responses.length >= total || this.hasAnswered()) {
+            const correct = responses.filter(r => r.correct).length;
+            progress.textContent = `All done! ${correct} out of ${total} correct`;
+            return container;
+        }
+
+        const index = responses.length;
+        progress.textContent = `Statement ${index + 1} of ${total}`;
+
+        const statement = document.createElement('div');
+        statement.className = 'true-false-statement';
+        statement.innerHTML = this.isQuestionActive() ? cq.statements[index].statement : 'Get ready...';
+        container.appendChild(statement);
+
+        const buttons = document.createElement('div');
+        buttons.className = 'true-false-buttons';
+        for (const value of [true, false]) {
+            const button = document.createElement('button');
+            button.className = `true-false-button ${value}`;
+            button.textContent = value ? 'True' : 'False';
+            button.disabled = !this.isQuestionActive();
+            button.addEventListener('click', () => {
+                buttons.querySelectorAll('button').forEach(b => b.disabled = true);
+                this.sendResponse(index, value);
+            });
+            buttons.appendChild(button);
+        }
+        container.appendChild(buttons);
+        return container;
+    }
+
+    /**
+     * The server records each statement response as it is made, so the answer
+     * submitted here just tells the server the player has finished early
+     * @returns {Answer} the answer object or null if nothing has been answered yet
+     */
+    getAnswer() {
+        if (this.getResponses().length === 0) {return null;}
+        return this.getApi().createAnswerObject();
+    }
+
+    /**
+     * Shows every statement along with whether it was true and how many
+     * players it caught out, worst first
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        const cq = this.getCurrentQuestion();
+        if (!container || !cq || !cq.statements) {return container;}
+        const statements = [...cq.statements].sort((a, b) => b.misses - a.misses);
+        let html = `
+            <table class="table true-false-reveal">
+                <thead>
+                    <tr>
+                        <th>Statement</th>
+                        <th>Answer</th>
+                        <th>Caught out</th>
+                    </tr>
+                </thead>
+                <tbody>
+        `;
+        for (const s of statements) {
+            html += `
+                <tr>
+                    <td>${s.statement}</td>
+                    <td>${s.isTrue ? 'True' : 'False'}</td>
+                    <td>${s.misses}</td>
+                </tr>
+            `;
+        }
+        html += `
+                </tbody>
+            </table>
+        `;
+        const t = document.createElement('div');
+        t.innerHTML = html;
+        container.appendChild(t);
+        return container;
+    }
+}
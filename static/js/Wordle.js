@@ -0,0 +1,234 @@
+/**
+ * PageElement which implements the 'wordle' question type.
+ * The target word never leaves the server until the question has ended.
+ * Each guess is sent to the server which responds with green/yellow/grey
+ * feedback for each letter and records the guess against the player.
+ */
+class Wordle extends PageElement {
+    static colours = {
+        "green": "#6aaa64",
+        "yellow": "#c9b458",
+        "grey": "#787c7e"
+    };
+
+    constructor() {
+        super('wordle-container', ['wordle']);
+        this.isPlayableComponent = true;
+        this.guessCount = -1;
+        this.lastQuestionActive = false;
+        this.textInput = null;
+        this.errorDiv = null;
+        this.isSending = false;
+    }
+
+    shouldUpdate() {
+        const currentQuestionActive = this.isQuestionActive();
+        const guessCount = this.getGuesses().length;
+        if (currentQuestionActive !== this.lastQuestionActive || guessCount !== this.guessCount) {
+            this.lastQuestionActive = currentQuestionActive;
+            this.guessCount = guessCount;
+            return true;
+        }
+        return false;
+    }
+
+    /**
+     * @param {string} username the player whose guesses we want, defaults to the current player
+     * @returns {array} the guesses the server has recorded for the player
+     */
+    getGuesses(username) {
+        let cq = this.getCurrentQuestion();
+        if (!cq || !cq.guesses) {return [];}
+        if (!username) {
+            let cp = this.getCurrentPlayer();
+            if (!cp) {return [];}
+            username = cp.username;
+        }
+        return cq.guesses[username] ?? [];
+    }
+
+    /**
+     * Sends the guess in the text input to the server
+     */
+    async sendGuess() {
+        if (this.isSending || !this.isQuestionActive() || !this.textInput) {return;}
+        const guess = this.textInput.value.trim();
+        if (!guess) {return;}
+        this.isSending = true;
+        try {
+            const r = await fetch('/api/submit-guess', {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify({guess: guess})
+            });
+            if (!r.ok) {
+                this.errorDiv.textContent = await r.text();
+            } else {
+                this.textInput.value = '';
+                this.errorDiv.textContent = '';
+            }
+        } catch (error) {
+            this.warn('Failed to submit guess:', error);
+        } finally {
+            this.isSending = false;
+        }
+        this.getApi().update();
+    }
+
+    createStyles() {
+        return `
+            #wordle-container {
+                padding: 15px;
+                margin: 0 auto;
+                text-align: center;
+            }
+            .wordle-board {
+                display: inline-flex;
+                flex-direction: column;
+                gap: 5px;
+                margin-bottom: 15px;
+            }
+            .wordle-row {
+                display: flex;
+                gap: 5px;
+            }
+            .wordle-cell {
+                width: 2.5em;
+                height: 2.5em;
+                border: 2px solid var(--bcclightgrey);
+                color: white;
+                font-size: 1.4em;
+                font-weight: bold;
+                text-transform: uppercase;
+                display: flex;
+                align-items: center;
+                justify-content: center;
+            }
+            .wordle-input {
+                padding: 10px;
+                font-size: 1.2em;
+                text-transform: uppercase;
+                border: 2px solid var(--bccblue);
+                border-radius: 8px;
+            }
+            .wordle-error {
+                color: var(--bcclightgold);
+                min-height: 1.2em;
+                margin-top: 5px;
+            }
+            .wordle-results {
+                display: flex;
+                flex-wrap: wrap;
+                gap: 20px;
+                justify-content: center;
+            }
+        `;
+    }
+
+    /**
+     * Creates a board showing the given guesses with empty rows for any
+     * guesses not yet made
+     * @param {array} guesses the guesses to show
+     * @param {boolean} showLetters false to show only the colours
+     * @returns {Document.Object} the board
+     */
+    createBoard(guesses, showLetters) {
+        let cq = this.getCurrentQuestion();
+        const length = cq.wordLength || 5;
+        const rows = cq.maxGuesses || 6;
+        const board = document.createElement('div');
+        board.className = 'wordle-board';
+        for (let i = 0; i < rows; i++) {
+            const row = document.createElement('div');
+            row.className = 'wordle-row';
+            const g = guesses[i];
+            for (let j = 0; j < length; j++) {
+                const cell = document.createElement('div');
+                cell.className = 'wordle-cell';
+                if (g) {
+                    cell.style.backgroundColor = Wordle.colours[g.feedback[j]];
+                    cell.style.borderColor = Wordle.colours[g.feedback[j]];
+                    if (showLetters) {cell.textContent = [...g.guess][j];}
+                }
+                row.appendChild(cell);
+            }
+            board.appendChild(row);
+        }
+        return board;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+        const cp = this.getCurrentPlayer();
+        if (!cp || cp.isSpectator) {
+            const info = document.createElement('div');
+            info.className = 'wordle-error';
+            info.textContent = `Find the ${cq.wordLength}-letter word in ${cq.maxGuesses || 6} guesses`;
+            container.appendChild(info);
+            return container;
+        }
+        const guesses = this.getGuesses();
+        container.appendChild(this.createBoard(guesses, true));
+
+        if (this.hasAnswered()) {return container;}
+
+        const inputRow = document.createElement('div');
+        this.textInput = document.createElement('input');
+        this.textInput.type = 'text';
+        this.textInput.className = 'wordle-input';
+        this.textInput.maxLength = cq.wordLength;
+        this.textInput.disabled = !this.isQuestionActive();
+        this.textInput.addEventListener('keydown', (e) => {
+            if (e.key === 'Enter') {this.sendGuess();}
+        });
+        inputRow.appendChild(this.textInput);
+        const button = document.createElement('button');
+        button.textContent = 'Guess';
+        button.disabled = !this.isQuestionActive();
+        button.addEventListener('click', () => this.sendGuess());
+        inputRow.appendChild(button);
+        container.appendChild(inputRow);
+
+        this.errorDiv = document.createElement('div');
+        this.errorDiv.className = 'wordle-error';
+        container.appendChild(this.errorDiv);
+        return container;
+    }
+
+    /**
+     * Guesses are submitted as they are made, so submitting an answer just
+     * tells the server that the player is giving up with the guesses made so far
+     * @returns {Answer} the answer object or null if no guesses have been made
+     */
+    getAnswer() {
+        if (this.getGuesses().length === 0) {return null;}
+        return this.getApi().createAnswerObject();
+    }
+
+    /**
+     * Shows the target word and the colours of every players guesses
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq || !cq.guesses) {return container;}
+        const results = document.createElement('div');
+        results.className = 'wordle-results';
+        for (const username of Object.keys(cq.guesses)) {
+            const div = document.createElement('div');
+            const name = document.createElement('div');
+            name.textContent = username;
+            div.appendChild(name);
+            div.appendChild(this.createBoard(cq.guesses[username], false));
+            results.appendChild(div);
+        }
+        container.appendChild(results);
+        return container;
+    }
+}
@@ -56,11 +56,20 @@ class GameAPI {
     initialisePageElements() {
         // Move page element initialization to separate method
         this.allPageElements.push(new QuestionView());
+        this.allPageElements.push(new MediaPlayer());
         this.allPageElements.push(new ClickMap());
         this.allPageElements.push(new FreeText());
         this.allPageElements.push(new MultiChoice());
         this.allPageElements.push(new StreetView());
         this.allPageElements.push(new GridImage());
+        this.allPageElements.push(new TrueFalse());
+        this.allPageElements.push(new RevealImage());
+        this.allPageElements.push(new Wordle());
+        this.allPageElements.push(new Crossword());
+        this.allPageElements.push(new Expression());
+        this.allPageElements.push(new Matching());
+        this.allPageElements.push(new RangeEstimate());
+        this.allPageElements.push(new Sketch());
         // Initialize buttons
         this.allPageElements.push(new AnswerButton());
         this.allPageElements.push(new NextQuestionButton());
@@ -76,6 +85,10 @@ class GameAPI {
         this.allPageElements.push(new CurrentAnswers());
         // host
         this.allPageElements.push(new PlayerAdmin());
+        this.allPageElements.push(new SketchGallery());
+        this.allPageElements.push(new QuestionGenerator());
+        this.allPageElements.push(new PackSelector());
+        this.allPageElements.push(new SeenWarning());
         // player
         this.allPageElements.push(new PlayerMessage());
     }
@@ -558,6 +571,7 @@ class GameAPI {
             switch(questionType) {
                 case 'kazakhstan':
                 case 'geolocation':
+                case 'country':
                     return element instanceof ClickMap;
                 case 'multichoice':
                     return element instanceof MultiChoice;
@@ -565,6 +579,22 @@ class GameAPI {
                     return element instanceof FreeText;
                 case 'gridimage':
                     return element instanceof GridImage;
+                case 'truefalse':
+                    return element instanceof TrueFalse;
+                case 'reveal':
+                    return element instanceof RevealImage;
+                case 'wordle':
+                    return element instanceof Wordle;
+                case 'crossword':
+                    return element instanceof Crossword;
+                case 'expression':
+                    return element instanceof Expression;
+                case 'matching':
+                    return element instanceof Matching;
+                case 'range':
+                    return element instanceof RangeEstimate;
+                case 'sketch':
+                    return element instanceof Sketch;
                 default:
                     return null;
             }
@@ -1641,7 +1671,7 @@ class ClickMap extends PageElement {
     ];
 
     constructor() {
-        super('click-container', ['geolocation','kazakhstan'])
+        super('click-container', ['geolocation','kazakhstan','country'])
         this.isPlayableComponent = true;
         this.answerx = null;
         this.answery = null;
@@ -1662,6 +1692,9 @@ class ClickMap extends PageElement {
         this.clickTimeThreshold = 200; // milliseconds to consider it a click
         this.secondPointerDown = false;
         this.currentMarker = null;
+        // for questions with hit regions, the points clicked and their markers
+        this.clicks = [];
+        this.targetMarkers = [];
         // dimensions etc
         this.startPoint = { x: 0, y: 0 };
         this.viewBoxStart = { x: 0, y: 0 };
@@ -1781,7 +1814,13 @@ class ClickMap extends PageElement {
 
         // draw marker for any answer the player has already submitted
         let a = this.getPlayerAnswer()
-        if (a) {
+        this.clicks = [];
+        this.targetMarkers = [];
+        if (this.isServerScored(cq)) {
+            for (const c of a?.clicks ?? []) {
+                this.drawMarker(c[0], c[1], "#FFFFFF", null);
+            }
+        } else if (a) {
             let coords = this.parseCoordinates(a.answer);
             let x = coords[0];
             let y = coords[1];
@@ -1843,6 +1882,8 @@ class ClickMap extends PageElement {
         this.getContent();
         // now get the location of markers from the current question
         let cq = this.getCurrentQuestion();
+        if (cq.regions) {return this.drawRegions();}
+        if (cq.type === "country") {return this.drawCountries();}
         // First add the correct answer marker at the very beginning (bottom z-order)
         // This ensures it's drawn first and other elements appear on top
         if (!cq.correctAnswers || cq.correctAnswers.length < 1) {return this.svg;}
@@ -1858,7 +1899,8 @@ class ClickMap extends PageElement {
         // Add circles for each answer with different colors and 80% opacity
         let p = this.getCurrentPlayer();
         cq.answers.forEach((answer, index) => {
-            let coords = this.parseCoordinates(answer.answer);
+            // answers scored by the server hold the map coordinates in clicks
+            let coords = answer.clicks?.[0] ?? this.parseCoordinates(answer.answer);
             const x = coords[0];
             const y = coords[1];
             // Select a color based on the index, cycling through the colors array
@@ -1879,6 +1921,14 @@ class ClickMap extends PageElement {
         let cq = gs.getCurrentQuestion();
         let ap = cq.pointsAvailable;
 
+        if (this.isServerScored(cq)) {
+            if (this.clicks.length === 0) {return null;}
+            a.clicks = this.clicks;
+            a.answer = this.clicks.map(c => `${c[0].toFixed(0)} - ${c[1].toFixed(0)}`).join(', ');
+            a.comment = '';
+            return a;
+        }
+
         if (this.answerx === null || this.answery === null) {
             return null;
         }
@@ -1943,6 +1993,109 @@ class ClickMap extends PageElement {
         return a;
     }
 
+    /**
+     * Questions with hit regions, a map projection or countries are scored
+     * by the server from the points clicked
+     * @param {Question} cq the current question
+     * @returns {boolean} true if the server scores the question
+     */
+    isServerScored(cq) {
+        return !!(cq.targets || cq.projection || cq.type === "country");
+    }
+
+    /**
+     * Colours the country shapes of the map for a 'country' question. The
+     * correct countries, which only arrive once the question has ended, are
+     * shown in green and every other country a player picked in their colour
+     * @returns {Document.Object} the svg
+     */
+    drawCountries() {
+        let cq = this.getCurrentQuestion();
+        const colour = (id, fill) => {
+            const shape = id ? this.svg.querySelector(`[id="${CSS.escape(id)}"]`) : null;
+            if (!shape) {return;}
+            // groups pass the fill on to the paths inside them
+            for (const s of [shape, ...shape.querySelectorAll('path, polygon')]) {
+                s.style.fill = fill;
+            }
+        };
+        let p = this.getCurrentPlayer();
+        (cq.answers ?? []).forEach((answer, index) => {
+            colour(answer.country, ClickMap.colors[index % ClickMap.colors.length]);
+            // the current players click has already been drawn by getContent
+            if (p && answer.username === p.username) {return;}
+            for (const c of answer.clicks ?? []) {
+                this.drawMarker(c[0], c[1], ClickMap.colors[index % ClickMap.colors.length], null);
+            }
+        });
+        for (const id of cq.correctAnswers ?? []) {
+            colour(id, "#6aaa64");
+        }
+        return this.svg;
+    }
+
+    /**
+     * Draws the hit regions of the current question, which only arrive once
+     * the question has ended, followed by every players clicks
+     * @returns {Document.Object} the svg
+     */
+    drawRegions() {
+        let cq = this.getCurrentQuestion();
+        const ns = "http://www.w3.org/2000/svg";
+        for (const r of cq.regions) {
+            let shape = null;
+            if (r.polygon && r.polygon.length >= 3) {
+                shape = document.createElementNS(ns, "polygon");
+                shape.setAttribute("points", r.polygon.map(p => p.join(',')).join(' '));
+            } else {
+                shape = document.createElementNS(ns, "ellipse");
+                shape.setAttribute("cx", r.cx);
+                shape.setAttribute("cy", r.cy);
+                shape.setAttribute("rx", r.rx);
+                shape.setAttribute("ry", r.ry);
+            }
+            shape.setAttribute("fill", "#6aaa64");
+            shape.setAttribute("fill-opacity", "0.4");
+            shape.setAttribute("stroke", "#6aaa64");
+            shape.setAttribute("stroke-width", "3");
+            this.svg.appendChild(shape);
+        }
+        let p = this.getCurrentPlayer();
+        (cq.answers ?? []).forEach((answer, index) => {
+            // the current players clicks have already been drawn by getContent
+            if (p && answer.username === p.username) {return;}
+            const color = ClickMap.colors[index % ClickMap.colors.length];
+            for (const c of answer.clicks ?? []) {
+                this.drawMarker(c[0], c[1], color, null);
+            }
+        });
+        return this.svg;
+    }
+
+    /**
+     * Places the players marker where they clicked. For questions with more than
+     * one target each click adds a marker, up to one per target, after which
+     * the oldest marker is replaced
+     * @param {number} x the x screen coordinate of the click
+     * @param {number} y the y screen coordinate of the click
+     */
+    placeMarker(x, y) {
+        let cq = this.getCurrentQuestion();
+        if (!cq.targets || cq.targets < 2) {
+            this.addMarker(x, y, "#000000", ClickMap.markerIdPrefix);
+            if (this.isServerScored(cq) && this.answerx !== null) {this.clicks = [[this.answerx, this.answery]];}
+            return;
+        }
+        const marker = this.addMarker(x, y, "#000000", null);
+        if (!marker) {return;}
+        this.clicks.push([this.answerx, this.answery]);
+        this.targetMarkers.push(marker);
+        if (this.clicks.length > cq.targets) {
+            this.clicks.shift();
+            this.targetMarkers.shift().remove();
+        }
+    }
+
     drawMarker(x, y, colour, id) {
         if (!this.svg) return;
         if (!x || !y || !colour) return;
@@ -2104,7 +2257,7 @@ class ClickMap extends PageElement {
                     //let y = this.parseCoordinate(dy)
                     let x = tx;
                     let y = ty;
-                    this.addMarker(x, y, "#000000", ClickMap.markerIdPrefix);
+                    this.placeMarker(x, y);
                 }
             }, 350);
         }, nonPassiveOpts);
@@ -2368,6 +2521,273 @@ class ClickMap extends PageElement {
     }
 }
 
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement which implements the 'crossword' question type.
+ * The grid and clues come from the question, the answers to the clues do not
+ * arrive until the question has ended. The player fills in the cells and submits
+ * the whole grid, the server checks it cell by cell and awards a share of the
+ * points for each word filled in correctly.
+ */
+class Crossword extends PageElement {
+    constructor() {
+        super('crossword-container', ['crossword']);
+        this.isPlayableComponent = true;
+        this.lastQuestionActive = false;
+        this.inputs = [];
+    }
+
+    shouldUpdate() {
+        // only redraw when the question starts or stops so typed letters aren't lost
+        const currentQuestionActive = this.isQuestionActive();
+        if (currentQuestionActive !== this.lastQuestionActive) {
+            this.lastQuestionActive = currentQuestionActive;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #crossword-container {
+                padding: 15px;
+                margin: 0 auto;
+                display: flex;
+                flex-wrap: wrap;
+                gap: 20px;
+                justify-content: center;
+            }
+            .crossword-grid {
+                display: inline-grid;
+                gap: 2px;
+                background-color: black;
+                border: 2px solid black;
+                align-self: flex-start;
+            }
+            .crossword-cell {
+                position: relative;
+                width: 2.2em;
+                height: 2.2em;
+                background-color: white;
+            }
+            .crossword-cell.black {
+                background-color: black;
+            }
+            .crossword-cell.wrong {
+                background-color: #f3c5c5;
+            }
+            .crossword-number {
+                position: absolute;
+                top: 1px;
+                left: 2px;
+                font-size: 0.6em;
+                color: black;
+            }
+            .crossword-cell input, .crossword-letter {
+                width: 100%;
+                height: 100%;
+                border: none;
+                background: transparent;
+                text-align: center;
+                font-size: 1.2em;
+                font-weight: bold;
+                text-transform: uppercase;
+                color: black;
+                display: flex;
+                align-items: center;
+                justify-content: center;
+                box-sizing: border-box;
+            }
+            .crossword-clues {
+                color: white;
+                text-align: left;
+                max-width: 400px;
+            }
+            .crossword-clues h3 {
+                color: var(--bcclightgold);
+                margin: 10px 0 5px 0;
+            }
+            .crossword-results {
+                color: white;
+                width: 100%;
+            }
+        `;
+    }
+
+    /**
+     * @returns {object} the clue number to show in each cell keyed by 'row,col'
+     */
+    getNumbers() {
+        let cq = this.getCurrentQuestion();
+        const ret = {};
+        for (const clue of cq.clues ?? []) {
+            if (clue.length > 0) {ret[`${clue.row},${clue.col}`] = clue.number;}
+        }
+        return ret;
+    }
+
+    /**
+     * Creates the grid of cells
+     * @param {function} cellContent called with (row, col) for each white cell
+     * and returns the element to put in it
+     * @returns {Document.Object} the grid
+     */
+    createGrid(cellContent) {
+        let cq = this.getCurrentQuestion();
+        const rows = cq.crosswordGrid ?? [];
+        const numbers = this.getNumbers();
+        const grid = document.createElement('div');
+        grid.className = 'crossword-grid';
+        const width = Math.max(...rows.map(r => [...r].length));
+        grid.style.gridTemplateColumns = `repeat(${width}, 2.2em)`;
+        rows.forEach((row, r) => {
+            const chars = [...row];
+            for (let c = 0; c < width; c++) {
+                const cell = document.createElement('div');
+                cell.className = 'crossword-cell';
+                if (c >= chars.length || chars[c] === '#') {
+                    cell.classList.add('black');
+                    grid.appendChild(cell);
+                    continue;
+                }
+                const number = numbers[`${r},${c}`];
+                if (number) {
+                    const n = document.createElement('span');
+                    n.className = 'crossword-number';
+                    n.textContent = number;
+                    cell.appendChild(n);
+                }
+                cell.appendChild(cellContent(r, c, cell));
+                grid.appendChild(cell);
+            }
+        });
+        return grid;
+    }
+
+    /**
+     * Creates the across and down clue lists
+     * @returns {Document.Object} the clue lists
+     */
+    createClues() {
+        let cq = this.getCurrentQuestion();
+        const container = document.createElement('div');
+        container.className = 'crossword-clues';
+        for (const direction of ['across', 'down']) {
+            const clues = (cq.clues ?? []).filter(c => c.direction === direction);
+            if (clues.length === 0) {continue;}
+            const heading = document.createElement('h3');
+            heading.textContent = direction.charAt(0).toUpperCase() + direction.slice(1);
+            container.appendChild(heading);
+            for (const clue of clues) {
+                const div = document.createElement('div');
+                div.textContent = `${clue.number}. ${clue.clue} (${clue.length})`;
+                container.appendChild(div);
+            }
+        }
+        return container;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+        container.style.display = 'contents';
+        let cp = this.getCurrentPlayer();
+        const isPlayer = cp && !cp.isSpectator;
+        const disabled = !this.isQuestionActive() || this.hasAnswered();
+        this.inputs = [];
+        container.appendChild(this.createGrid((r, c) => {
+            if (!isPlayer) {return document.createElement('span');}
+            const input = document.createElement('input');
+            input.type = 'text';
+            input.maxLength = 1;
+            input.disabled = disabled;
+            input.dataset.row = r;
+            input.dataset.col = c;
+            input.addEventListener('input', () => {
+                // move on to the next cell as each letter is typed
+                if (!input.value) {return;}
+                const i = this.inputs.indexOf(input);
+                if (i >= 0 && i + 1 < this.inputs.length) {this.inputs[i + 1].focus();}
+            });
+            this.inputs.push(input);
+            return input;
+        }));
+        container.appendChild(this.createClues());
+        return container;
+    }
+
+    /**
+     * Sends the whole filled grid, one string per row, as the answer.
+     * Unused cells are sent as '#' and empty cells as spaces
+     * @returns {Answer} the answer object or null if no cells have been filled in
+     */
+    getAnswer() {
+        let cq = this.getCurrentQuestion();
+        if (!cq || this.inputs.length === 0) {return null;}
+        const cells = (cq.crosswordGrid ?? []).map(row => [...row].map(c => c === '#' ? '#' : ' '));
+        let filled = false;
+        for (const input of this.inputs) {
+            const letter = input.value.trim().toUpperCase();
+            if (!letter) {continue;}
+            cells[input.dataset.row][input.dataset.col] = letter;
+            filled = true;
+        }
+        if (!filled) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.cells = cells.map(row => row.join(''));
+        answer.comment = '';
+        this.inputs.forEach(input => input.disabled = true);
+        return answer;
+    }
+
+    /**
+     * Shows the completed grid and which words each player got
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq) {return container;}
+        // work out the solution from the clue answers
+        const solution = {};
+        for (const clue of cq.clues ?? []) {
+            if (!clue.answer || clue.length === 0) {continue;}
+            [...clue.answer].forEach((letter, i) => {
+                const r = clue.direction === 'down' ? clue.row + i : clue.row;
+                const c = clue.direction === 'down' ? clue.col : clue.col + i;
+                solution[`${r},${c}`] = letter;
+            });
+        }
+        // mark the cells the current player got wrong
+        let cp = this.getCurrentPlayer();
+        const mine = (cq.answers ?? []).find(a => cp && a.username === cp.username);
+        container.appendChild(this.createGrid((r, c, cell) => {
+            const letter = document.createElement('span');
+            letter.className = 'crossword-letter';
+            letter.textContent = solution[`${r},${c}`] ?? '';
+            if (mine && mine.cells && [...(mine.cells[r] ?? '')][c] !== letter.textContent) {
+                cell.classList.add('wrong');
+            }
+            return letter;
+        }));
+        const results = document.createElement('div');
+        results.className = 'crossword-results';
+        for (const a of cq.answers ?? []) {
+            const div = document.createElement('div');
+            const words = a.words && a.words.length > 0 ? a.words.join(', ') : 'none';
+            div.textContent = `${a.username}: ${words}`;
+            results.appendChild(div);
+        }
+        container.appendChild(results);
+        return container;
+    }
+}
+
+
 // *******************************************************
 // ***** PageElement.js 
 // *******************************************************
@@ -2469,6 +2889,190 @@ class CurrentAnswers extends PageElement {
     }
 }
 
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement which implements the countdown numbers style 'expression' question type.
+ * The player is given some numbers and a target and types an arithmetic
+ * expression using each number at most once to get as close to the target as they can.
+ * The server evaluates the expression and scores it on its distance from the target.
+ */
+class Expression extends PageElement {
+    constructor() {
+        super('expression-container', ['expression']);
+        this.isPlayableComponent = true;
+        this.lastQuestionActive = false;
+        this.textInput = null;
+        this.tiles = [];
+    }
+
+    shouldUpdate() {
+        const currentQuestionActive = this.isQuestionActive();
+        if (currentQuestionActive !== this.lastQuestionActive) {
+            this.lastQuestionActive = currentQuestionActive;
+            // enable or disable in place so that the expression being typed isn't lost
+            const disabled = !currentQuestionActive || this.hasAnswered();
+            if (this.textInput) {this.textInput.disabled = disabled;}
+            this.tiles.forEach(tile => tile.disabled = disabled);
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #expression-container {
+                padding: 15px;
+                margin: 0 auto;
+                text-align: center;
+            }
+            .expression-target {
+                color: var(--bcclightgold);
+                font-size: 3em;
+                font-weight: bold;
+                margin-bottom: 15px;
+            }
+            .expression-tiles {
+                display: flex;
+                flex-wrap: wrap;
+                gap: 10px;
+                justify-content: center;
+                margin-bottom: 15px;
+            }
+            .expression-tile {
+                min-width: 2.5em;
+                padding: 10px;
+                font-size: 1.4em;
+                font-weight: bold;
+                background-color: var(--bccblue);
+                color: white;
+                border: none;
+                border-radius: 6px;
+                cursor: pointer;
+            }
+            .expression-tile.operator {
+                background-color: var(--bccdarkgrey);
+            }
+            .expression-input {
+                width: 100%;
+                padding: 15px 20px;
+                border: 2px solid var(--bccblue);
+                border-radius: 8px;
+                font-size: 1.4em;
+                box-sizing: border-box;
+            }
+            .expression-results {
+                color: white;
+                width: 100%;
+                border-collapse: collapse;
+                margin-top: 10px;
+            }
+            .expression-results td {
+                padding: 4px 10px;
+                text-align: left;
+            }
+        `;
+    }
+
+    /**
+     * Creates a button which adds the given text to the expression
+     * @param {string} text the text to add
+     * @param {string} className extra class for the button
+     * @returns {Document.Object} the button
+     */
+    createTile(text, className) {
+        const button = document.createElement('button');
+        button.className = `expression-tile ${className}`;
+        button.textContent = text;
+        button.disabled = !this.isQuestionActive() || this.hasAnswered();
+        button.addEventListener('click', () => {
+            if (!this.textInput || this.textInput.disabled) {return;}
+            this.textInput.value += text;
+            this.textInput.focus();
+        });
+        this.tiles.push(button);
+        return button;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+        this.tiles = [];
+
+        const target = document.createElement('div');
+        target.className = 'expression-target';
+        target.textContent = cq.target;
+        container.appendChild(target);
+
+        let cp = this.getCurrentPlayer();
+        const isPlayer = cp && !cp.isSpectator;
+        const numbers = document.createElement('div');
+        numbers.className = 'expression-tiles';
+        for (const n of cq.numbers ?? []) {
+            numbers.appendChild(this.createTile(`${n}`, 'number'));
+        }
+        container.appendChild(numbers);
+
+        // spectators only see the target and numbers
+        if (!isPlayer) {return container;}
+
+        const operators = document.createElement('div');
+        operators.className = 'expression-tiles';
+        for (const op of [...(cq.operators || '+-*/'), '(', ')']) {
+            operators.appendChild(this.createTile(op, 'operator'));
+        }
+        container.appendChild(operators);
+
+        this.textInput = document.createElement('input');
+        this.textInput.type = 'text';
+        this.textInput.className = 'expression-input';
+        this.textInput.placeholder = 'eg. (25 + 50) * 3';
+        this.textInput.disabled = !this.isQuestionActive() || this.hasAnswered();
+        container.appendChild(this.textInput);
+        return container;
+    }
+
+    /**
+     * The server evaluates the expression, so we just send what the player typed
+     * @returns {Answer} the answer object or null if nothing has been typed
+     */
+    getAnswer() {
+        if (!this.textInput) {return null;}
+        const text = this.textInput.value.trim();
+        if (!text) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.answer = text;
+        answer.comment = '';
+        this.textInput.disabled = true;
+        this.tiles.forEach(tile => tile.disabled = true);
+        return answer;
+    }
+
+    /**
+     * Shows each players expression along with its value
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq || !cq.answers) {return container;}
+        const table = document.createElement('table');
+        table.className = 'expression-results';
+        for (const a of cq.answers) {
+            if (a.answer === '...') {continue;}
+            const row = table.insertRow();
+            row.insertCell().textContent = a.username;
+            row.insertCell().textContent = a.answer;
+            row.insertCell().textContent = a.comment;
+        }
+        container.appendChild(table);
+        return container;
+    }
+}
+
+
 // *******************************************************
 // ***** PageElement.js 
 // *******************************************************
@@ -2691,21 +3295,21 @@ class FreeText extends PageElement {
 
         // Get the current question to access the correct answer
         const currentQuestion = gs.getCurrentQuestion();
-        if (!currentQuestion || !currentQuestion.correctAnswers || !currentQuestion.penalisationFactor) {
-            this.warn('FreeText: No correct answer available or missing penalisationFactor');
+        if (!currentQuestion || !currentQuestion.correctAnswers) {
+            this.warn('FreeText: No correct answer available');
             return null;
         }
-        let maxDistance = parseInt(currentQuestion.penalisationFactor);
+        // no penalisationFactor means the answer must be exact, as for numbers
+        let maxDistance = parseInt(currentQuestion.penalisationFactor ?? 0);
         if (isNaN(maxDistance)) {
             this.warn('FreeText: penalisationFactor is not a number');
             return null;
         }
         if (maxDistance == 0) {
-            if (currentQuestion.correctAnswers.includes(textInput)) {
-                answer.points = currentQuestion.pointsAvailable;
-            } else {
-                answer.points = 0;
-            }
+            const ca = currentQuestion.correctAnswers.find(ca => ca.toLowerCase() === textValue.toLowerCase());
+            answer.points = ca ? currentQuestion.pointsAvailable : 0;
+            answer.answer = ca ?? textValue;
+            answer.comment = ca ? 'Exact match' : 'No exact match';
             return answer;
         }
         for (const ca of currentQuestion.correctAnswers) {
@@ -2738,6 +3342,8 @@ class FreeText extends PageElement {
 /**
  * A PageElement which implements an image which is overlayed with
  * a grid of elements (div's) into which answers can be dragged and dropped
+ * Local images are sliced into tiles by the server, each fetched by an
+ * opaque id, remote images are divided up here
  */
 class GridImage extends PageElement {
     constructor() {
@@ -2897,8 +3503,13 @@ class GridImage extends PageElement {
         const gridBoard = document.createElement('div');
         gridBoard.className = 'grid-board';
 
-        // First, let's determine the image dimensions
-        if (cq.imageUrl) {
+        // tiles sliced by the server already know their size
+        if (cq.tiles && cq.tileSize) {
+            gridBoard.style.aspectRatio = `${cq.tileSize[0] * this.gridSize.cols} / ${cq.tileSize[1] * this.gridSize.rows}`;
+            gridBoard.style.width = '100%';
+            gridBoard.style.height = 'auto';
+        } else if (cq.imageUrl) {
+            // First, let's determine the image dimensions
             const img = new Image();
             img.onload = () => {
                 const aspectRatio = img.height / img.width;
@@ -2922,11 +3533,18 @@ class GridImage extends PageElement {
             cell.className = 'grid-cell';
             cell.dataset.cellIndex = i;
 
-            if (cq.imageUrl) {
+            if (cq.tiles) {
                 cell.style.cssText = `
                     position: relative;
                     overflow: hidden;
-                    background-image: url(${cq.imageUrl});
+                    background-image: url(/api/grid-tile?id=${cq.tiles[i]});
+                    background-size: 100% 100%;
+                `;
+            } else if (cq.imageUrl) {
+                cell.style.cssText = `
+                    position: relative;
+                    overflow: hidden;
+                    background-image: url(${cq.imageUrl});
                     background-size: ${this.gridSize.cols * 100}% ${this.gridSize.rows * 100}%;
                     background-position: ${(i % this.gridSize.cols) * -100}% ${Math.floor(i / this.gridSize.cols) * -100}%;
                 `;
@@ -3296,6 +3914,9 @@ class Leaderboard extends PageElement {
             return container;
         }
 
+        // only show calibration once some range questions have been played
+        const showCalibration = Object.values(api.leaderboard ?? {}).some(p => p.rangesAnswered > 0);
+
         // Create table
         const t = document.createElement('table');
         let h = `
@@ -3305,6 +3926,7 @@ class Leaderboard extends PageElement {
                         <th>Player</th>
                         <th>Rating</th>
                         <th>Total</th>
+                        ${showCalibration ? '<th>Calibration</th>' : ''}
                     </tr>
                 </thead>
                 <tbody>
@@ -3325,6 +3947,7 @@ class Leaderboard extends PageElement {
                         <td>${player.username}</td>
                         <td>${player.percent}%</td>
                         <td>${pts}</td>
+                        ${showCalibration ? `<td>${this.getCalibration(player)}</td>` : ''}
                     </tr>
                 `;
             }
@@ -3344,6 +3967,458 @@ class Leaderboard extends PageElement {
             return container;
         }
     }
+
+    /**
+     * @param {Player} player
+     * @returns {string} how often the players ranges held the true value eg. 3/4 (75%)
+     */
+    getCalibration(player) {
+        if (!player.rangesAnswered) {return '-';}
+        const pc = Math.round(100 * player.rangesHit / player.rangesAnswered);
+        return `${player.rangesHit}/${player.rangesAnswered} (${pc}%)`;
+    }
+}
+
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement which implements the 'matching' pairs question type.
+ * The question choices make up the left hand list and the question matches
+ * the right hand list, either of which may be text or images (imgUrl).
+ * The player links items by clicking one on the left and then one on the right,
+ * the links are drawn as lines between the two lists.
+ * The server scores each correct pair, the correct pairs are only sent once
+ * the question has ended.
+ */
+class Matching extends PageElement {
+    constructor() {
+        super('matching-container', ['matching']);
+        this.isPlayableComponent = true;
+        this.lastQuestionActive = false;
+        this.links = [];
+        this.selected = -1;
+        this.questionNumber = -1;
+        this.board = null;
+        this.lines = null;
+        this.linkColour = 'var(--bcclightgold)';
+        this.correctColour = '#6aaa64';
+        this.wrongColour = '#c0504d';
+        window.addEventListener('resize', () => this.drawLinks());
+    }
+
+    shouldUpdate() {
+        const currentQuestionActive = this.isQuestionActive();
+        if (currentQuestionActive !== this.lastQuestionActive) {
+            this.lastQuestionActive = currentQuestionActive;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #matching-container {
+                padding: 15px;
+                margin: 0 auto;
+            }
+            .matching-board {
+                position: relative;
+                display: flex;
+                justify-content: space-between;
+                gap: 80px;
+                max-width: 800px;
+                margin: 0 auto;
+            }
+            .matching-column {
+                display: flex;
+                flex-direction: column;
+                gap: 10px;
+                flex: 1;
+                z-index: 1;
+            }
+            .matching-item {
+                padding: 10px;
+                background-color: var(--bccblue);
+                color: white;
+                border: 2px solid transparent;
+                border-radius: 8px;
+                cursor: pointer;
+                text-align: center;
+                user-select: none;
+            }
+            .matching-item.selected {
+                border-color: var(--bcclightgold);
+            }
+            .matching-item.linked {
+                background-color: var(--bccdarkgrey);
+            }
+            .matching-item img {
+                max-width: 100%;
+                max-height: 120px;
+                display: block;
+                margin: 0 auto;
+            }
+            .matching-lines {
+                position: absolute;
+                top: 0;
+                left: 0;
+                width: 100%;
+                height: 100%;
+                pointer-events: none;
+            }
+        `;
+    }
+
+    /**
+     * Creates one item of either list
+     * @param {Choice} choice the item
+     * @returns {Document.Object} the item
+     */
+    createItem(choice) {
+        const item = document.createElement('div');
+        item.className = 'matching-item';
+        if (choice.imgUrl) {
+            const img = document.createElement('img');
+            img.src = choice.imgUrl;
+            img.alt = choice.choice;
+            // lines must be redrawn once images have a size
+            img.addEventListener('load', () => this.drawLinks());
+            item.appendChild(img);
+        }
+        if (choice.choice) {
+            const text = document.createElement('div');
+            text.textContent = choice.choice;
+            item.appendChild(text);
+        }
+        return item;
+    }
+
+    /**
+     * Creates the two lists and the layer the links are drawn on
+     * @param {function} onLeft called with the index of a clicked left hand item
+     * @param {function} onRight called with the index of a clicked right hand item
+     * @returns {Document.Object} the board
+     */
+    createBoard(onLeft, onRight) {
+        let cq = this.getCurrentQuestion();
+        this.board = document.createElement('div');
+        this.board.className = 'matching-board';
+        const svg = document.createElementNS('http://www.w3.org/2000/svg', 'svg');
+        svg.classList.add('matching-lines');
+        this.board.appendChild(svg);
+        const left = document.createElement('div');
+        left.className = 'matching-column left';
+        (cq.choices ?? []).forEach((c, i) => {
+            const item = this.createItem(c);
+            if (onLeft) {item.addEventListener('click', () => onLeft(i));}
+            left.appendChild(item);
+        });
+        const right = document.createElement('div');
+        right.className = 'matching-column right';
+        (cq.matches ?? []).forEach((m, i) => {
+            const item = this.createItem(m);
+            if (onRight) {item.addEventListener('click', () => onRight(i));}
+            right.appendChild(item);
+        });
+        this.board.appendChild(left);
+        this.board.appendChild(right);
+        return this.board;
+    }
+
+    /**
+     * Draws the given links between the two lists
+     * @param {array} lines objects of {from, to, colour} where from and to are
+     * indexes into the left and right lists
+     */
+    drawLines(lines) {
+        if (!this.board || !this.board.isConnected) {return;}
+        const svg = this.board.querySelector('.matching-lines');
+        const lefts = this.board.querySelectorAll('.matching-column.left .matching-item');
+        const rights = this.board.querySelectorAll('.matching-column.right .matching-item');
+        const b = this.board.getBoundingClientRect();
+        svg.replaceChildren();
+        for (const line of lines) {
+            if (!lefts[line.from] || !rights[line.to]) {continue;}
+            const l = lefts[line.from].getBoundingClientRect();
+            const r = rights[line.to].getBoundingClientRect();
+            const path = document.createElementNS('http://www.w3.org/2000/svg', 'line');
+            path.setAttribute('x1', l.right - b.left);
+            path.setAttribute('y1', l.top + l.height / 2 - b.top);
+            path.setAttribute('x2', r.left - b.left);
+            path.setAttribute('y2', r.top + r.height / 2 - b.top);
+            path.setAttribute('stroke', line.colour);
+            path.setAttribute('stroke-width', '4');
+            path.setAttribute('stroke-linecap', 'round');
+            svg.appendChild(path);
+        }
+    }
+
+    /**
+     * Draws the players links, or the correct links once the answer is shown
+     */
+    drawLinks() {
+        if (!this.board) {return;}
+        // wait for the board to be laid out
+        requestAnimationFrame(() => requestAnimationFrame(() => {
+            if (this.lines) {
+                this.drawLines(this.lines);
+                return;
+            }
+            const lines = [];
+            this.links.forEach((to, from) => {
+                if (to >= 0) {lines.push({from: from, to: to, colour: this.linkColour});}
+            });
+            this.drawLines(lines);
+            const lefts = this.board.querySelectorAll('.matching-column.left .matching-item');
+            const rights = this.board.querySelectorAll('.matching-column.right .matching-item');
+            lefts.forEach((item, i) => {
+                item.classList.toggle('selected', i === this.selected);
+                item.classList.toggle('linked', this.links[i] >= 0);
+            });
+            rights.forEach((item, i) => item.classList.toggle('linked', this.links.includes(i)));
+        }));
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        this.lines = null;
+        if (this.questionNumber !== cq.questionNumber) {
+            this.questionNumber = cq.questionNumber;
+            this.links = (cq.choices ?? []).map(() => -1);
+            this.selected = -1;
+        }
+        let cp = this.getCurrentPlayer();
+        const canPlay = cp && !cp.isSpectator && this.isQuestionActive() && !this.hasAnswered();
+        if (!canPlay) {
+            const board = this.createBoard(null, null);
+            this.drawLinks();
+            return board;
+        }
+        const board = this.createBoard(
+            (i) => {
+                // clicking a linked item again unlinks it
+                if (this.links[i] >= 0) {
+                    this.links[i] = -1;
+                    this.selected = -1;
+                } else {
+                    this.selected = i === this.selected ? -1 : i;
+                }
+                this.drawLinks();
+            },
+            (i) => {
+                if (this.selected < 0) {return;}
+                // a right hand item can only be linked once
+                const existing = this.links.indexOf(i);
+                if (existing >= 0) {this.links[existing] = -1;}
+                this.links[this.selected] = i;
+                this.selected = -1;
+                this.drawLinks();
+            }
+        );
+        this.drawLinks();
+        return board;
+    }
+
+    /**
+     * Sends the index of the right hand item linked to each left hand item
+     * @returns {Answer} the answer object or null if nothing has been linked
+     */
+    getAnswer() {
+        if (!this.links.some(l => l >= 0)) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.links = [...this.links];
+        answer.answer = '';
+        answer.comment = '';
+        return answer;
+    }
+
+    /**
+     * Shows the correct links, along with whether the current player got each one
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq) {return container;}
+        let cp = this.getCurrentPlayer();
+        const mine = (cq.answers ?? []).find(a => cp && a.username === cp.username);
+        const matches = (cq.matches ?? []).map(m => m.choice.trim().toLowerCase());
+        this.lines = [];
+        (cq.choices ?? []).forEach((c, from) => {
+            const to = matches.indexOf((c.answer ?? '').trim().toLowerCase());
+            if (to < 0) {return;}
+            let colour = this.linkColour;
+            if (mine && mine.links) {
+                colour = mine.links[from] === to ? this.correctColour : this.wrongColour;
+            }
+            this.lines.push({from: from, to: to, colour: colour});
+        });
+        container.appendChild(this.createBoard(null, null));
+        this.drawLinks();
+        return container;
+    }
+}
+
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement which plays the audio or video clip attached to a question.
+ * Can be used with any question type, players answer using the normal answer
+ * component for the question. Only the observer screens play the clip.
+ * The server tells us the time at which the clip should be at its start offset
+ * (currentQuestion.mediaStartsAt) and its own clock (gameState.serverTime) so
+ * every screen can work out where in the clip it should be and play together.
+ */
+class MediaPlayer extends PageElement {
+    constructor() {
+        super('media-container', ['*']);
+        this.media = null;
+        this.startTimer = null;
+        this.clockOffset = 0;
+        // how far (seconds) a screen may drift before we seek to correct it
+        this.maxDrift = 0.3;
+    }
+
+    shouldShow() {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return false;}
+        return !!(cq.audioUrl || cq.videoUrl);
+    }
+
+    shouldUpdate() {
+        // keep the playback in sync on every poll
+        if (this.media) {this.sync();}
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #media-container {
+                width: 100%;
+                max-width: 800px;
+                margin: 0 auto 20px auto;
+                text-align: center;
+            }
+            .media-video {
+                width: 100%;
+                height: auto;
+                border-radius: 8px;
+            }
+            .media-enable-button {
+                padding: 10px 20px;
+                font-size: 1.2em;
+                cursor: pointer;
+            }
+        `;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+        if (cq.videoUrl) {
+            this.media = document.createElement('video');
+            this.media.className = 'media-video';
+            this.media.src = cq.videoUrl;
+            this.media.playsInline = true;
+        } else {
+            this.media = document.createElement('audio');
+            this.media.src = cq.audioUrl;
+        }
+        this.media.preload = 'auto';
+        this.media.controls = false;
+        // stop at the end offset if there is one
+        this.media.addEventListener('timeupdate', () => {
+            if (cq.mediaEnd && this.media.currentTime >= cq.mediaEnd) {
+                this.media.pause();
+            }
+        });
+        container.appendChild(this.media);
+        this.sync();
+        return container;
+    }
+
+    /**
+     * @returns {number} the current time (ms) according to the server clock
+     */
+    getServerNow() {
+        let gs = this.getGameState();
+        if (gs && gs.serverTime) {
+            this.clockOffset = gs.serverTime - Date.now();
+        }
+        return Date.now() + this.clockOffset;
+    }
+
+    /**
+     * Works out where the clip should be right now and plays, pauses or
+     * seeks the media element to match
+     */
+    sync() {
+        let cq = this.getCurrentQuestion();
+        if (!cq || !this.media) {return;}
+        if (!cq.mediaStartsAt || !this.isQuestionActive()) {
+            this.cancelStart();
+            if (!this.media.paused) {this.media.pause();}
+            return;
+        }
+        const start = cq.mediaStart || 0;
+        const untilStart = cq.mediaStartsAt - this.getServerNow();
+        if (untilStart > 0) {
+            // not time yet, park at the start offset and schedule playback
+            if (!this.startTimer) {
+                this.media.currentTime = start;
+                this.startTimer = setTimeout(() => {
+                    this.startTimer = null;
+                    this.sync();
+                }, untilStart);
+            }
+            return;
+        }
+        const target = start + (-untilStart / 1000);
+        if (cq.mediaEnd && target >= cq.mediaEnd) {
+            if (!this.media.paused) {this.media.pause();}
+            return;
+        }
+        if (Math.abs(this.media.currentTime - target) > this.maxDrift) {
+            this.media.currentTime = target;
+        }
+        if (this.media.paused) {this.play();}
+    }
+
+    /**
+     * Starts playback. Browsers may refuse to play sound until the page has
+     * been interacted with, in which case we offer a button to press
+     */
+    play() {
+        const p = this.media.play();
+        if (!p) {return;}
+        p.catch(error => {
+            this.warn('Playback was blocked:', error);
+            const container = this.getElement();
+            if (!container || container.querySelector('.media-enable-button')) {return;}
+            const button = document.createElement('button');
+            button.className = 'media-enable-button';
+            button.textContent = 'Enable sound';
+            button.addEventListener('click', () => {
+                button.remove();
+                this.sync();
+            });
+            container.appendChild(button);
+        });
+    }
+
+    cancelStart() {
+        if (this.startTimer) {
+            clearTimeout(this.startTimer);
+            this.startTimer = null;
+        }
+    }
 }
 
 
@@ -3691,49 +4766,207 @@ class NextQuestionButton extends AnimatedButton {
 // *******************************************************
 // ***** PageElement.js 
 // *******************************************************
-// Example implementation for Start Question Button
-class PauseQuestionButton extends AnimatedButton {
+/**
+ * PageElement for the host which lists the question packs and lets the
+ * host choose which of them to play before the game starts, either in
+ * order or composed into a game of falling percents
+ */
+class PackSelector extends PageElement {
     constructor() {
-        super('pause-question-button', 3); // 3 second cooldown
+        super('pack-selector', ['*']);
+        this.packs = null;
+        this.packsLoaded = false;
+        this.selected = '';
     }
 
-    async buttonAction() {
-        await fetch('/api/pause-question')
-        .then(response => {
-            if (!response.ok) {
-                return false;
-            }
-            return true
-        })
-        .catch(error => {return false;});
+    static checkedPacks() {
+        const checked = document.querySelectorAll('#pack-selector input[type=checkbox]:checked');
+        return Array.from(checked).map(cb => cb.value).join(',');
     }
-}
 
-// *******************************************************
-// ***** PageElement.js 
-// *******************************************************
-/**
- * PageElement Class which deals with displaying the current players
- * and allows for administration of those players
- */
-class PlayerAdmin extends PageElement {
-    constructor() {
-        super('player-admin',['*'])
-        this.numplayers = 0;
-        this.totalPoints = 0;
+    static async select() {
+        await GameAPI.sendHttpRequest(`/api/select-packs?packs=${PackSelector.checkedPacks()}`);
     }
 
-    static click(username, action) {
-        if (!username || !action) {return;}
-        let pts = document.getElementById("player-admin-points");
-        let points = 0;
-        if (pts) {points = pts.value;}
-        GameAPI.sendHttpRequest(`/api/players?username=${username}&action=${action}&points=${points}`);
+    static async compose() {
+        const value = id => document.getElementById(`pack-selector-${id}`)?.value ?? '';
+        const params = ['count', 'max', 'min', 'perCategory', 'require', 'seed']
+            .map(p => `${p}=${value(p)}`).join('&');
+        await GameAPI.sendHttpRequest(`/api/compose-game?packs=${PackSelector.checkedPacks()}&${params}`);
+    }
+
+    static async importCsv() {
+        const file = document.getElementById('pack-selector-csv')?.files?.[0];
+        const name = document.getElementById('pack-selector-name')?.value ?? '';
+        if (!file || name === '') {return;}
+        const form = new FormData();
+        form.append('file', file);
+        const response = await fetch(`/api/import-csv?pack=${encodeURIComponent(name)}`, {
+            method: 'POST',
+            body: form
+        });
+        const result = document.getElementById('pack-selector-result');
+        if (result) {result.textContent = response.ok ? '' : await response.text();}
+        // list the new pack
+        const ps = GameAPI.getInstance().allPageElements.find(pe => pe instanceof PackSelector);
+        if (ps && response.ok) {ps.loadPacks();}
+    }
+
+    async loadPacks() {
+        const response = await GameAPI.sendHttpRequest('/api/packs');
+        if (!response) {
+            this.warn('Failed to load the question packs');
+            return;
+        }
+        this.packs = JSON.parse(response).packs;
+        this.packsLoaded = true;
+    }
+
+    shouldShow() {
+        let cp = this.getCurrentPlayer();
+        return !!(cp && cp.isAdmin);
+    }
+
+    shouldUpdate() {
+        if (this.packs === null) {
+            this.packs = [];
+            this.loadPacks();
+            return false;
+        }
+        const gs = this.getGameState();
+        const selected = (gs?.packs ?? []).join(',') + '#' + (gs?.composition?.seed ?? '');
+        if (this.packsLoaded || selected !== this.selected) {
+            this.packsLoaded = false;
+            this.selected = selected;
+            return true;
+        }
+        return false;
     }
 
     createStyles() {
         return `
-        .small-button {
+            #pack-selector {
+                margin: 10px 0;
+                color: white;
+            }
+            #pack-selector label {
+                display: block;
+            }
+            #pack-selector input[type=number] {
+                width: 3.5em;
+            }
+            #pack-selector input[type=text] {
+                width: 8em;
+            }
+            #pack-selector .pack-details {
+                color: #aaa;
+                font-size: 0.8em;
+            }
+        `;
+    }
+
+    getContent(api) {
+        const container = document.createElement('div');
+        const selected = this.getGameState()?.packs ?? [];
+        const composition = this.getGameState()?.composition;
+        const difficulties = ['', 'easy', 'medium', 'hard'];
+        for (const p of this.packs ?? []) {
+            const details = [
+                p.author ? `by ${p.author}` : '',
+                difficulties[p.difficulty] ?? '',
+                `${p.questions} questions`,
+                p.duration ? `${p.duration} minutes` : '',
+                (p.tags ?? []).join(', ')
+            ].filter(d => d !== '').join(' · ');
+            const label = document.createElement('label');
+            label.innerHTML = `
+                <input type="checkbox" value="${p.name}" ${selected.includes(p.name) ? 'checked' : ''}>
+                ${p.title} <span class="pack-details">${details}</span>
+                <a class="pack-details" href="/api/export-csv?pack=${p.name}">[csv]</a>
+            `;
+            container.appendChild(label);
+        }
+        const compose = document.createElement('div');
+        compose.innerHTML = `
+            <button class="small-button" onclick="PackSelector.select()">play chosen packs in order</button>
+            or compose
+            <input type="number" id="pack-selector-count" min="1" value="${composition?.count ?? 20}">
+            questions from
+            <input type="number" id="pack-selector-max" min="1" max="100" value="${composition?.maxPercent ?? 90}">%
+            to
+            <input type="number" id="pack-selector-min" min="1" max="100" value="${composition?.minPercent ?? 1}">%
+            at most
+            <input type="number" id="pack-selector-perCategory" min="0" value="${composition?.perCategory ?? 3}">
+            per category including
+            <input type="text" id="pack-selector-require" placeholder="eg. geolocation" value="${(composition?.require ?? []).join(',')}">
+            seed
+            <input type="text" id="pack-selector-seed" placeholder="random">
+            <button class="small-button" onclick="PackSelector.compose()">compose</button>
+            ${composition ? `<span class="pack-details">composed with seed ${composition.seed}</span>` : ''}
+        `;
+        container.appendChild(compose);
+        const upload = document.createElement('div');
+        upload.innerHTML = `
+            new pack
+            <input type="text" id="pack-selector-name" placeholder="name">
+            from a spreadsheet saved as CSV
+            <input type="file" id="pack-selector-csv" accept=".csv,text/csv">
+            <button class="small-button" onclick="PackSelector.importCsv()">import</button>
+            <a class="pack-details" href="/api/export-csv">[questions.json as csv]</a>
+            <pre id="pack-selector-result" class="pack-details"></pre>
+        `;
+        container.appendChild(upload);
+        return container;
+    }
+}
+
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+// Example implementation for Start Question Button
+class PauseQuestionButton extends AnimatedButton {
+    constructor() {
+        super('pause-question-button', 3); // 3 second cooldown
+    }
+
+    async buttonAction() {
+        await fetch('/api/pause-question')
+        .then(response => {
+            if (!response.ok) {
+                return false;
+            }
+            return true
+        })
+        .catch(error => {return false;});
+    }
+}
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement Class which deals with displaying the current players
+ * and allows for administration of those players
+ */
+class PlayerAdmin extends PageElement {
+    constructor() {
+        super('player-admin',['*'])
+        this.numplayers = 0;
+        this.totalPoints = 0;
+    }
+
+    static click(username, action) {
+        if (!username || !action) {return;}
+        let pts = document.getElementById("player-admin-points");
+        let points = 0;
+        if (pts) {points = pts.value;}
+        GameAPI.sendHttpRequest(`/api/players?username=${username}&action=${action}&points=${points}`);
+    }
+
+    createStyles() {
+        return `
+        .small-button {
             padding: 2px 4px;
             font-size: 0.8em;
             margin: 1px;
@@ -3951,6 +5184,81 @@ class PreviousQuestionButton extends AnimatedButton {
     }
 }
 
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement for the host which adds generated questions, such as
+ * identifying flags or logic puzzles, to the end of the game and reloads
+ * the questions file after it has been edited
+ */
+class QuestionGenerator extends PageElement {
+    constructor() {
+        super('question-generator', ['*']);
+        this.totalQuestions = -1;
+    }
+
+    static click(kind) {
+        const count = document.getElementById('question-generator-count')?.value ?? 1;
+        const difficulty = document.getElementById('question-generator-difficulty')?.value ?? 0;
+        GameAPI.sendHttpRequest(`/api/generate-questions?kind=${kind}&count=${count}&difficulty=${difficulty}`);
+    }
+
+    static reload() {
+        GameAPI.sendHttpRequest('/api/reload-questions');
+    }
+
+    shouldShow() {
+        let cp = this.getCurrentPlayer();
+        return !!(cp && cp.isAdmin);
+    }
+
+    shouldUpdate() {
+        const total = this.getGameState()?.totalQuestions ?? 0;
+        if (total !== this.totalQuestions) {
+            this.totalQuestions = total;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #question-generator {
+                display: flex;
+                flex-wrap: wrap;
+                align-items: center;
+                gap: 5px;
+                margin: 10px 0;
+                color: white;
+            }
+            #question-generator input {
+                width: 3em;
+            }
+        `;
+    }
+
+    getContent(api) {
+        const container = document.createElement('div');
+        container.style.display = 'contents';
+        container.innerHTML = `
+            <span>${this.totalQuestions} questions, add</span>
+            <input type="number" id="question-generator-count" min="1" max="50" value="5">
+            <select id="question-generator-difficulty">
+                <option value="0">any</option>
+                <option value="1">easy</option>
+                <option value="2">medium</option>
+                <option value="3">hard</option>
+            </select>
+            <button class="small-button" onclick="QuestionGenerator.click('flags')">flag questions</button>
+            <button class="small-button" onclick="QuestionGenerator.click('puzzles')">logic puzzles</button>
+            <button class="small-button" onclick="QuestionGenerator.reload()">reload questions</button>
+        `;
+        return container;
+    }
+}
+
+
 // *******************************************************
 // ***** PageElement.js 
 // *******************************************************
@@ -4112,112 +5420,807 @@ class QuestionView extends PageElement {
 // *******************************************************
 // ***** PageElement.js 
 // *******************************************************
-class ShowAnswerButton extends AnimatedButton {
+/**
+ * PageElement which implements the 'range' estimation question type.
+ * Rather than a single number the player gives a lower and upper bound which
+ * they think the true value lies between. The server scores the range, only
+ * ranges that hold the true value score and narrower ranges score more.
+ */
+class RangeEstimate extends PageElement {
     constructor() {
-        super('show-answer-button', 2);
-    }
-
-    async buttonAction() {
-        await fetch('/api/show-answer')
-            .then(response => {
-                if (!response.ok) {
-                    return false;
-                }
-                return true
-            })
-            .catch(error => {return false;});
+        super('range-container', ['range']);
+        this.isPlayableComponent = true;
+        this.lastQuestionActive = false;
+        this.lowerInput = null;
+        this.upperInput = null;
     }
 
-    isEnabled() {
-        let cq = this.getCurrentQuestion()
-        if (!cq) {return false;}
-        return cq.isTimedOut;
+    shouldUpdate() {
+        const currentQuestionActive = this.isQuestionActive();
+        if (currentQuestionActive !== this.lastQuestionActive) {
+            this.lastQuestionActive = currentQuestionActive;
+            // enable or disable in place so that typed values aren't lost
+            const disabled = !currentQuestionActive || this.hasAnswered();
+            if (this.lowerInput) {this.lowerInput.disabled = disabled;}
+            if (this.upperInput) {this.upperInput.disabled = disabled;}
+        }
+        return false;
     }
-}
 
-// *******************************************************
-// ***** PageElement.js 
-// *******************************************************
-// Example implementation for Start Question Button
-class StartQuestionButton extends AnimatedButton {
-    constructor() {
-        super('start-question-button', 3); // 3 second cooldown
+    createStyles() {
+        return `
+            #range-container {
+                padding: 15px;
+                margin: 0 auto;
+                text-align: center;
+            }
+            .range-inputs {
+                display: flex;
+                gap: 15px;
+                align-items: center;
+                justify-content: center;
+                color: white;
+                font-size: 1.2em;
+            }
+            .range-input {
+                width: 40%;
+                padding: 15px 20px;
+                border: 2px solid var(--bccblue);
+                border-radius: 8px;
+                font-size: 16px;
+            }
+            .range-input:disabled {
+                opacity: 0.5;
+                cursor: not-allowed;
+            }
+            .range-results {
+                color: white;
+                width: 100%;
+                border-collapse: collapse;
+                margin-top: 10px;
+            }
+            .range-results td {
+                padding: 4px 10px;
+                text-align: left;
+            }
+        `;
     }
 
-    async buttonAction() {
-        await fetch('/api/start-question')
-        .then(response => {
-            if (!response.ok) {
-                this.warn("bad response from start question");
-                return false;
-            }
-            debug("good response from start question");
-            return true
-        })
-        .catch(error => {
-            this.warn("error from start question", error);
-            return false;
-        });
+    /**
+     * @param {string} placeholder the placeholder text
+     * @returns {Document.Object} a number input for one of the bounds
+     */
+    createInput(placeholder) {
+        const input = document.createElement('input');
+        input.type = 'number';
+        input.step = 'any';
+        input.className = 'range-input';
+        input.placeholder = placeholder;
+        input.disabled = !this.isQuestionActive() || this.hasAnswered();
+        return input;
     }
 
-    isEnabled() {
-        let a = super.isQuestionActive()
-        return !a;
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        let cp = this.getCurrentPlayer();
+        if (!cp || cp.isSpectator) {return null;}
+        const container = document.createElement('div');
+        container.className = 'range-inputs';
+        this.lowerInput = this.createInput('at least');
+        this.upperInput = this.createInput('at most');
+        const and = document.createElement('span');
+        and.textContent = 'to';
+        container.appendChild(this.lowerInput);
+        container.appendChild(and);
+        container.appendChild(this.upperInput);
+        return container;
     }
-}
 
-// *******************************************************
-// ***** PageElement.js 
-// *******************************************************
-class StopQuestionButton extends AnimatedButton {
-    constructor() {
-        super('stop-question-button', 3); // 3 second cooldown
+    /**
+     * The server checks the bounds and scores the range, so we just send them
+     * @returns {Answer} the answer object or null if either bound is missing
+     */
+    getAnswer() {
+        if (!this.lowerInput || !this.upperInput) {return null;}
+        const lower = parseFloat(this.lowerInput.value);
+        const upper = parseFloat(this.upperInput.value);
+        if (isNaN(lower) || isNaN(upper)) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.lower = lower;
+        answer.upper = upper;
+        answer.answer = `${lower} to ${upper}`;
+        answer.comment = '';
+        this.lowerInput.disabled = true;
+        this.upperInput.disabled = true;
+        return answer;
     }
 
-    async buttonAction() {
-        await fetch('/api/stop-question')
-        .then(response => {
-            if (!response.ok) {
-                return false;
-            }
-            return true
-        })
-        .catch(error => {return false;});
+    /**
+     * Shows each players range and whether it held the true value
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq || !cq.answers) {return container;}
+        const table = document.createElement('table');
+        table.className = 'range-results';
+        for (const a of cq.answers) {
+            if (a.lower === undefined || a.upper === undefined) {continue;}
+            const row = table.insertRow();
+            row.insertCell().textContent = a.username;
+            row.insertCell().textContent = a.answer;
+            row.insertCell().textContent = a.inRange ? '✔' : '✘';
+            row.insertCell().textContent = parseFloat(a.points).toFixed(1);
+        }
+        container.appendChild(table);
+        return container;
     }
 }
 
+
 // *******************************************************
 // ***** PageElement.js 
 // *******************************************************
 /**
- * PageElement that manages the displaying of google streetview for
- * geolocation questions
+ * PageElement which implements the progressive image 'reveal' question type.
+ * The image starts heavily obscured and becomes clearer as the time runs down.
+ * The obscured stages are generated by the server which decides which stage
+ * to serve, so the full image is never available before the question ends.
+ * The player types their answer, the earlier they answer correctly the more points
  */
-class StreetView extends PageElement {
+class RevealImage extends PageElement {
     constructor() {
-        super('streetview-container', ['geolocation']);
-        this.baseUrl = "https://www.google.com/maps/embed?pb=";
-        this.container = null;
-        this.iframe = null;
-        this.url = null;
+        super('reveal-container', ['reveal']);
+        this.isPlayableComponent = true;
+        this.stage = -1;
+        this.image = null;
+        this.textInput = null;
+        this.lastQuestionActive = false;
     }
 
-    /** just speed things up a little */
-    createStyles() {}
-
     shouldUpdate() {
-        // if we haven't been instantiated properly yet
-        if (!this.url) {return true;}
-        // if we have relevant data for this kind of question
-        let cq = this.getCurrentQuestion()
-        if (!cq || !cq.streetView) {return false;}
-        // if we've started a different question
-        if (cq.streetView !== this.url) {return true;}
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return false;}
+        const stage = cq.revealStage ?? 0;
+        // swap the image without redrawing the input the player is typing in
+        if (this.image && stage !== this.stage) {
+            this.stage = stage;
+            this.image.src = `/api/reveal-image?stage=${stage}`;
+        }
+        const currentQuestionActive = this.isQuestionActive();
+        if (currentQuestionActive !== this.lastQuestionActive) {
+            this.lastQuestionActive = currentQuestionActive;
+            if (this.textInput) {
+                this.textInput.disabled = !currentQuestionActive || this.hasAnswered();
+            }
+        }
         return false;
     }
 
-    getContent(gs) {
+    createStyles() {
+        return `
+            #reveal-container {
+                padding: 15px;
+                margin: 0 auto;
+            }
+            .reveal-image-container {
+                width: 100%;
+                max-width: 800px;
+                margin: 0 auto 20px auto;
+                border-radius: 8px;
+                overflow: hidden;
+            }
+            .reveal-image {
+                width: 100%;
+                height: auto;
+                display: block;
+                image-rendering: pixelated;
+            }
+            .reveal-input {
+                width: 100%;
+                padding: 15px 20px;
+                border: 2px solid var(--bccblue);
+                border-radius: 8px;
+                font-size: 16px;
+            }
+            .reveal-input:disabled {
+                opacity: 0.5;
+                cursor: not-allowed;
+            }
+        `;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+
+        const imageContainer = document.createElement('div');
+        imageContainer.className = 'reveal-image-container';
+        this.stage = cq.revealStage ?? 0;
+        this.image = document.createElement('img');
+        this.image.className = 'reveal-image';
+        this.image.alt = 'Reveal Image';
+        this.image.src = `/api/reveal-image?stage=${this.stage}`;
+        imageContainer.appendChild(this.image);
+        container.appendChild(imageContainer);
+
+        // spectators only see the image
+        let cp = this.getCurrentPlayer();
+        if (!cp || cp.isSpectator) {return container;}
+
+        this.textInput = document.createElement('input');
+        this.textInput.type = 'text';
+        this.textInput.className = 'reveal-input';
+        this.textInput.placeholder = 'What is it?';
+        this.textInput.disabled = !this.isQuestionActive() || this.hasAnswered();
+        container.appendChild(this.textInput);
+        return container;
+    }
+
+    /**
+     * The server decides the points based on the reveal stage at the time
+     * the answer arrives, so we just send what the player typed
+     * @returns {Answer} the answer object or null if nothing has been typed
+     */
+    getAnswer() {
+        if (!this.textInput) {return null;}
+        const text = this.textInput.value.trim();
+        if (!text) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.answer = text;
+        answer.comment = '';
+        this.textInput.disabled = true;
+        return answer;
+    }
+
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq || !cq.imageUrl) {return container;}
+        const image = document.createElement('img');
+        image.className = 'reveal-image';
+        image.src = cq.imageUrl;
+        container.prepend(image);
+        return container;
+    }
+}
+
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement for the host which warns, before a question is started,
+ * how many of the current players have been shown it in an earlier game
+ */
+class SeenWarning extends PageElement {
+    constructor() {
+        super('seen-warning', ['*']);
+        this.lastWarning = null;
+    }
+
+    getWarning() {
+        const cq = this.getCurrentQuestion();
+        if (!cq || !cq.seenBy || this.isQuestionActive() || cq.isTimedOut) {return '';}
+        const players = Object.values(this.getPlayers() ?? {}).filter(p => !p.isAdmin && !p.isSpectator);
+        const seen = (this.getGameState()?.allQuestions ?? []).filter(q => q.seenBy).length;
+        return `Seen before by ${cq.seenBy} of the ${players.length} current players` +
+            ` (${seen} of the questions to come have been seen by someone)`;
+    }
+
+    shouldShow() {
+        let cp = this.getCurrentPlayer();
+        return !!(cp && cp.isAdmin) && this.getWarning() !== '';
+    }
+
+    shouldUpdate() {
+        const warning = this.getWarning();
+        if (warning !== this.lastWarning) {
+            this.lastWarning = warning;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #seen-warning {
+                color: var(--bcclightgold);
+                margin: 10px 0;
+            }
+        `;
+    }
+
+    getContent(api) {
+        const container = document.createElement('div');
+        container.textContent = this.lastWarning;
+        return container;
+    }
+}
+
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+class ShowAnswerButton extends AnimatedButton {
+    constructor() {
+        super('show-answer-button', 2);
+    }
+
+    async buttonAction() {
+        await fetch('/api/show-answer')
+            .then(response => {
+                if (!response.ok) {
+                    return false;
+                }
+                return true
+            })
+            .catch(error => {return false;});
+    }
+
+    isEnabled() {
+        let cq = this.getCurrentQuestion()
+        if (!cq) {return false;}
+        return cq.isTimedOut;
+    }
+}
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement which implements the pictionary style 'sketch' question type.
+ * Players draw on a canvas which is sent to the server as a list of strokes,
+ * each a flat list of x,y points in a 1000 x 1000 square.
+ * The host judges the drawings (see SketchGallery) and chooses which
+ * drawing the observer screens show.
+ */
+class Sketch extends PageElement {
+    // must match the limits in internal/game/sketch.go
+    static size = 1000;
+    static maxStrokes = 200;
+    static maxPoints = 5000;
+    // points closer together than this (in sketch units) are dropped
+    static minDistance = 6;
+
+    constructor() {
+        super('sketch-container', ['sketch']);
+        this.isPlayableComponent = true;
+        this.lastQuestionActive = false;
+        this.questionNumber = -1;
+        this.featured = null;
+        this.strokes = [];
+        this.canvas = null;
+        this.drawing = false;
+    }
+
+    shouldUpdate() {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return false;}
+        const currentQuestionActive = this.isQuestionActive();
+        const featured = cq.featured ?? '';
+        if (currentQuestionActive !== this.lastQuestionActive || featured !== this.featured) {
+            this.lastQuestionActive = currentQuestionActive;
+            this.featured = featured;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #sketch-container {
+                padding: 15px;
+                margin: 0 auto;
+                text-align: center;
+            }
+            .sketch-canvas {
+                width: 100%;
+                max-width: 500px;
+                aspect-ratio: 1;
+                background-color: white;
+                border-radius: 8px;
+                touch-action: none;
+                cursor: crosshair;
+            }
+            .sketch-buttons {
+                display: flex;
+                gap: 10px;
+                justify-content: center;
+                margin-top: 10px;
+            }
+            .sketch-featured {
+                width: 100%;
+                max-width: 700px;
+                border-radius: 8px;
+            }
+            .sketch-caption {
+                color: var(--bcclightgold);
+                font-size: 1.4em;
+                margin-top: 10px;
+            }
+        `;
+    }
+
+    /**
+     * @returns {number} the number of points in the drawing so far
+     */
+    countPoints() {
+        return this.strokes.reduce((n, s) => n + s.length / 2, 0);
+    }
+
+    /**
+     * Converts a pointer event into a point in the sketch square
+     * @param {PointerEvent} e
+     * @returns {array} [x, y]
+     */
+    toSketch(e) {
+        const r = this.canvas.getBoundingClientRect();
+        const x = Math.round((e.clientX - r.left) * Sketch.size / r.width);
+        const y = Math.round((e.clientY - r.top) * Sketch.size / r.height);
+        return [Math.max(0, Math.min(Sketch.size, x)), Math.max(0, Math.min(Sketch.size, y))];
+    }
+
+    /**
+     * Redraws the strokes onto the canvas
+     */
+    redraw() {
+        if (!this.canvas) {return;}
+        const ctx = this.canvas.getContext('2d');
+        ctx.clearRect(0, 0, Sketch.size, Sketch.size);
+        ctx.lineWidth = 8;
+        ctx.lineCap = 'round';
+        ctx.lineJoin = 'round';
+        ctx.strokeStyle = 'black';
+        for (const s of this.strokes) {
+            ctx.beginPath();
+            ctx.moveTo(s[0], s[1]);
+            for (let i = 2; i < s.length; i += 2) {ctx.lineTo(s[i], s[i + 1]);}
+            if (s.length === 2) {ctx.lineTo(s[0], s[1]);}
+            ctx.stroke();
+        }
+    }
+
+    /**
+     * Creates the canvas the player draws on
+     * @param {boolean} enabled false if the player can no longer draw
+     * @returns {Document.Object} the canvas
+     */
+    createCanvas(enabled) {
+        this.canvas = document.createElement('canvas');
+        this.canvas.className = 'sketch-canvas';
+        this.canvas.width = Sketch.size;
+        this.canvas.height = Sketch.size;
+        if (!enabled) {return this.canvas;}
+        this.canvas.addEventListener('pointerdown', (e) => {
+            if (this.strokes.length >= Sketch.maxStrokes || this.countPoints() >= Sketch.maxPoints) {return;}
+            this.drawing = true;
+            this.canvas.setPointerCapture(e.pointerId);
+            this.strokes.push(this.toSketch(e));
+            this.redraw();
+        });
+        this.canvas.addEventListener('pointermove', (e) => {
+            if (!this.drawing || this.countPoints() >= Sketch.maxPoints) {return;}
+            const s = this.strokes[this.strokes.length - 1];
+            const [x, y] = this.toSketch(e);
+            const dx = x - s[s.length - 2];
+            const dy = y - s[s.length - 1];
+            if (dx * dx + dy * dy < Sketch.minDistance * Sketch.minDistance) {return;}
+            s.push(x, y);
+            this.redraw();
+        });
+        const end = () => {this.drawing = false;};
+        this.canvas.addEventListener('pointerup', end);
+        this.canvas.addEventListener('pointercancel', end);
+        return this.canvas;
+    }
+
+    /**
+     * Creates an image of the drawing the given player made for the current question
+     * @param {string} username
+     * @param {string} className
+     * @returns {Document.Object} the image
+     */
+    createSketchImage(username, className) {
+        let cq = this.getCurrentQuestion();
+        const img = document.createElement('img');
+        img.className = className;
+        img.alt = `Drawing by ${username}`;
+        img.src = `/api/sketch?question=${cq.questionNumber}&username=${encodeURIComponent(username)}`;
+        return img;
+    }
+
+    /**
+     * Creates the drawing the host has chosen to show, if there is one
+     * @returns {Document.Object} the drawing and its caption or null
+     */
+    createFeatured() {
+        let cq = this.getCurrentQuestion();
+        if (!cq || !cq.featured) {return null;}
+        const div = document.createElement('div');
+        div.appendChild(this.createSketchImage(cq.featured, 'sketch-featured'));
+        const caption = document.createElement('div');
+        caption.className = 'sketch-caption';
+        caption.textContent = cq.featured;
+        div.appendChild(caption);
+        return div;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        if (this.questionNumber !== cq.questionNumber) {
+            this.questionNumber = cq.questionNumber;
+            this.strokes = [];
+        }
+        const container = document.createElement('div');
+        let cp = this.getCurrentPlayer();
+        if (!cp || cp.isSpectator || cp.isAdmin) {
+            const featured = this.createFeatured();
+            if (featured) {container.appendChild(featured);}
+            return container;
+        }
+        const enabled = this.isQuestionActive() && !this.hasAnswered();
+        container.appendChild(this.createCanvas(enabled));
+        this.redraw();
+        if (!enabled) {return container;}
+        const buttons = document.createElement('div');
+        buttons.className = 'sketch-buttons';
+        const undo = document.createElement('button');
+        undo.textContent = 'Undo';
+        undo.addEventListener('click', () => {
+            this.strokes.pop();
+            this.redraw();
+        });
+        const clear = document.createElement('button');
+        clear.textContent = 'Clear';
+        clear.addEventListener('click', () => {
+            this.strokes = [];
+            this.redraw();
+        });
+        buttons.appendChild(undo);
+        buttons.appendChild(clear);
+        container.appendChild(buttons);
+        return container;
+    }
+
+    /**
+     * Sends the drawing, the host decides the points later
+     * @returns {Answer} the answer object or null if nothing has been drawn
+     */
+    getAnswer() {
+        if (this.strokes.length === 0) {return null;}
+        let answer = this.getApi().createAnswerObject();
+        answer.strokes = this.strokes;
+        answer.answer = 'drawing';
+        answer.comment = '';
+        return answer;
+    }
+
+    /**
+     * Shows the drawing the host has chosen
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        if (!container) {return container;}
+        const featured = this.createFeatured();
+        if (featured) {container.appendChild(featured);}
+        return container;
+    }
+}
+
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement for the host which shows every drawing made for the current
+ * 'sketch' question so that the host can award points for each and choose
+ * which drawing is shown on the observer screens
+ */
+class SketchGallery extends PageElement {
+    constructor() {
+        super('sketch-gallery', ['sketch']);
+        this.judged = null;
+        this.featured = null;
+        this.questionNumber = -1;
+    }
+
+    static click(username, action) {
+        if (!action) {return;}
+        let points = 0;
+        let pts = document.getElementById(`sketch-points-${username}`);
+        if (pts) {points = pts.value;}
+        GameAPI.sendHttpRequest(`/api/sketches?username=${username}&action=${action}&points=${points}`);
+    }
+
+    shouldShow() {
+        let cp = this.getCurrentPlayer();
+        return !!(cp && cp.isAdmin);
+    }
+
+    shouldUpdate() {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return false;}
+        // redraw when a drawing arrives or is judged
+        const judged = (cq.answers ?? []).filter(a => a.answer === 'drawing').map(a => `${a.username}:${a.points}`).join();
+        const featured = cq.featured ?? '';
+        if (judged !== this.judged || featured !== this.featured || cq.questionNumber !== this.questionNumber) {
+            this.judged = judged;
+            this.featured = featured;
+            this.questionNumber = cq.questionNumber;
+            return true;
+        }
+        return false;
+    }
+
+    createStyles() {
+        return `
+            #sketch-gallery {
+                display: flex;
+                flex-wrap: wrap;
+                gap: 10px;
+                margin: 10px 0;
+            }
+            .sketch-tile {
+                width: 180px;
+                padding: 5px;
+                border: 2px solid transparent;
+                border-radius: 6px;
+                background-color: var(--bccdarkgrey);
+                color: white;
+                font-size: 0.9em;
+            }
+            .sketch-tile.featured {
+                border-color: var(--bcclightgold);
+            }
+            .sketch-tile img {
+                width: 100%;
+                display: block;
+                border-radius: 4px;
+            }
+            .sketch-tile input {
+                width: 3em;
+            }
+        `;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+        container.style.display = 'contents';
+        // the host sees the points so far in the answers
+        for (const a of cq.answers ?? []) {
+            if (a.answer !== 'drawing') {continue;}
+            const tile = document.createElement('div');
+            tile.className = 'sketch-tile';
+            if (a.username === cq.featured) {tile.classList.add('featured');}
+            tile.innerHTML = `
+                <img src="/api/sketch?question=${cq.questionNumber}&username=${encodeURIComponent(a.username)}" alt="${a.username}">
+                <div>${a.username} (${parseFloat(a.points).toFixed(1)})</div>
+                <input type="number" min="0" max="${cq.pointsAvailable}" step="any" id="sketch-points-${a.username}" value="${a.points}">
+                <button class="small-button" onclick="SketchGallery.click('${a.username}', 'judge')">judge</button>
+                <button class="small-button" onclick="SketchGallery.click('${a.username}', 'show')">show</button>
+            `;
+            container.appendChild(tile);
+        }
+        if (cq.featured) {
+            const hide = document.createElement('button');
+            hide.className = 'small-button';
+            hide.textContent = 'hide drawing';
+            hide.addEventListener('click', () => SketchGallery.click('', 'show'));
+            container.appendChild(hide);
+        }
+        return container;
+    }
+}
+
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+// Example implementation for Start Question Button
+class StartQuestionButton extends AnimatedButton {
+    constructor() {
+        super('start-question-button', 3); // 3 second cooldown
+    }
+
+    async buttonAction() {
+        await fetch('/api/start-question')
+        .then(response => {
+            if (!response.ok) {
+                this.warn("bad response from start question");
+                return false;
+            }
+            debug("good response from start question");
+            return true
+        })
+        .catch(error => {
+            this.warn("error from start question", error);
+            return false;
+        });
+    }
+
+    isEnabled() {
+        let a = super.isQuestionActive()
+        return !a;
+    }
+}
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+class StopQuestionButton extends AnimatedButton {
+    constructor() {
+        super('stop-question-button', 3); // 3 second cooldown
+    }
+
+    async buttonAction() {
+        await fetch('/api/stop-question')
+        .then(response => {
+            if (!response.ok) {
+                return false;
+            }
+            return true
+        })
+        .catch(error => {return false;});
+    }
+}
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement that manages the displaying of google streetview for
+ * geolocation questions, or of a local panorama image for venues without
+ * an internet connection. Panoramas are equirectangular images which the
+ * server slices into tiles at several zoom levels (see /api/panorama) so
+ * only the tiles in view are fetched, at a size to suit the screen
+ */
+class StreetView extends PageElement {
+    constructor() {
+        super('streetview-container', ['geolocation']);
+        this.baseUrl = "https://www.google.com/maps/embed?pb=";
+        this.container = null;
+        this.iframe = null;
+        this.url = null;
+        // the panorama viewer
+        this.pyramid = null;
+        this.view = null;
+        this.scale = 1;
+        this.offsetX = 0;
+        this.offsetY = 0;
+        this.tiles = new Map();
+    }
+
+    /** just speed things up a little */
+    createStyles() {}
+
+    shouldUpdate() {
+        // if we haven't been instantiated properly yet
+        if (!this.url) {return true;}
+        // if we have relevant data for this kind of question
+        let cq = this.getCurrentQuestion()
+        if (!cq || !(cq.panorama || cq.streetView)) {return false;}
+        // if we've started a different question
+        if ((cq.panorama || cq.streetView) !== this.url) {return true;}
+        return false;
+    }
+
+    getContent(gs) {
         let cq = this.getCurrentQuestion();
+        if (cq.panorama) {
+            this.url = cq.panorama;
+            this.createPanorama(cq);
+            return;
+        }
         this.url = cq.streetView;
         const embedUrl = this.baseUrl + this.url;
         this.container = this.getElement();
@@ -4246,13 +6249,155 @@ class StreetView extends PageElement {
         overlay.style.zIndex = '1000';
         overlay.style.borderRadius = '4px'; // Optional: rounded corners
 
-        // Clear container and add both elements
-        this.container.innerHTML = '';
-        this.container.appendChild(this.iframe);
-        this.container.appendChild(overlay);
+        // Clear container and add both elements
+        this.container.innerHTML = '';
+        this.container.appendChild(this.iframe);
+        this.container.appendChild(overlay);
+    }
+
+    /**
+     * Replaces the content of the container with a viewer for the local
+     * panorama of the given question
+     * @param {Question} cq the current question
+     */
+    createPanorama(cq) {
+        this.container = this.getElement();
+        this.container.innerHTML = '';
+        this.view = document.createElement('div');
+        this.view.className = 'panorama-view';
+        this.container.appendChild(this.view);
+        this.tiles = new Map();
+        this.pyramid = null;
+        const buttons = document.createElement('div');
+        buttons.className = 'panorama-buttons';
+        for (const [label, factor] of [['+', 1.5], ['-', 1 / 1.5]]) {
+            const b = document.createElement('button');
+            b.textContent = label;
+            b.addEventListener('click', () => this.zoom(factor, this.view.clientWidth / 2, this.view.clientHeight / 2));
+            buttons.appendChild(b);
+        }
+        this.container.appendChild(buttons);
+        this.addPanoramaEvents();
+        const url = this.url;
+        fetch(`/api/panorama?question=${cq.questionNumber}`)
+            .then(response => response.ok ? response.json() : null)
+            .then(pyramid => {
+                // the question may have moved on while we waited
+                if (!pyramid || url !== this.url) {return;}
+                this.pyramid = pyramid;
+                this.scale = this.minScale();
+                this.offsetX = 0;
+                this.offsetY = 0;
+                this.drawPanorama();
+            })
+            .catch(error => this.warn(`Failed to load panorama: ${error.message}`));
+    }
+
+    /**
+     * @returns {number} the smallest scale, at which the panorama fills the view from top to bottom
+     */
+    minScale() {
+        return this.view.clientHeight / this.pyramid.height;
+    }
+
+    /**
+     * Zooms the panorama about the given point of the view
+     * @param {number} factor how much to zoom by, more than one zooms in
+     * @param {number} x the x coordinate of the point in the view
+     * @param {number} y the y coordinate of the point in the view
+     */
+    zoom(factor, x, y) {
+        if (!this.pyramid) {return;}
+        const scale = Math.max(this.minScale(), Math.min(2, this.scale * factor));
+        this.offsetX = (this.offsetX + x) * scale / this.scale - x;
+        this.offsetY = (this.offsetY + y) * scale / this.scale - y;
+        this.scale = scale;
+        this.drawPanorama();
+    }
+
+    /**
+     * Lets the player drag the panorama around and zoom with the mouse wheel
+     */
+    addPanoramaEvents() {
+        let last = null;
+        this.view.addEventListener('pointerdown', (e) => {
+            last = [e.clientX, e.clientY];
+            this.view.setPointerCapture(e.pointerId);
+        });
+        this.view.addEventListener('pointermove', (e) => {
+            if (!last || !this.pyramid) {return;}
+            this.offsetX -= e.clientX - last[0];
+            this.offsetY -= e.clientY - last[1];
+            last = [e.clientX, e.clientY];
+            this.drawPanorama();
+        });
+        const end = () => {last = null;};
+        this.view.addEventListener('pointerup', end);
+        this.view.addEventListener('pointercancel', end);
+        this.view.addEventListener('wheel', (e) => {
+            e.preventDefault();
+            const r = this.view.getBoundingClientRect();
+            this.zoom(e.deltaY < 0 ? 1.2 : 1 / 1.2, e.clientX - r.left, e.clientY - r.top);
+        }, { passive: false });
+    }
+
+    /**
+     * Positions the tiles of the panorama which are in view, fetching any new
+     * ones from the server. The smallest zoom level with at least as much detail
+     * as the screen is used and the panorama wraps around horizontally
+     */
+    drawPanorama() {
+        const p = this.pyramid;
+        if (!p || !this.view) {return;}
+        const vw = this.view.clientWidth;
+        const vh = this.view.clientHeight;
+        const width = p.width * this.scale;
+        const height = p.height * this.scale;
+        this.offsetY = Math.max(0, Math.min(height - vh, this.offsetY));
+        this.offsetX = ((this.offsetX % width) + width) % width;
+        let level = p.levels.length - 1;
+        while (level > 0 && p.levels[level - 1].width >= width * window.devicePixelRatio) {level--;}
+        const l = p.levels[level];
+        // the size on screen of one pixel of the level
+        const f = width / l.width;
+        const used = new Set();
+        for (let wrap = 0; wrap * width < this.offsetX + vw; wrap++) {
+            for (let col = 0; col < l.cols; col++) {
+                const left = wrap * width + col * p.tileSize * f - this.offsetX;
+                const tw = Math.min(p.tileSize, l.width - col * p.tileSize) * f;
+                if (left + tw < 0 || left > vw) {continue;}
+                for (let row = 0; row < l.rows; row++) {
+                    const top = row * p.tileSize * f - this.offsetY;
+                    const th = Math.min(p.tileSize, l.height - row * p.tileSize) * f;
+                    if (top + th < 0 || top > vh) {continue;}
+                    const key = `${wrap}/${level}/${col}/${row}`;
+                    let img = this.tiles.get(key);
+                    if (!img) {
+                        img = document.createElement('img');
+                        img.draggable = false;
+                        img.src = `/api/panorama-tile?question=${this.getCurrentQuestion().questionNumber}&level=${level}&col=${col}&row=${row}`;
+                        this.tiles.set(key, img);
+                        this.view.appendChild(img);
+                    }
+                    // overlap by a pixel to hide the seams
+                    img.style.left = `${left}px`;
+                    img.style.top = `${top}px`;
+                    img.style.width = `${tw + 1}px`;
+                    img.style.height = `${th + 1}px`;
+                    used.add(key);
+                }
+            }
+        }
+        for (const [key, img] of this.tiles) {
+            if (!used.has(key)) {
+                img.remove();
+                this.tiles.delete(key);
+            }
+        }
     }
 }
 
+
 // *******************************************************
 // ***** PageElement.js 
 // *******************************************************
@@ -4377,6 +6522,464 @@ class Timer extends PageElement {
     }
 }
 
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement which implements the rapid fire 'truefalse' question type.
+ * The question holds a list of statements which the player answers one after
+ * another, true or false, within the single question time limit.
+ * Each response is sent to the server as soon as it is made so that the server
+ * can record when it was made. The server scores the answer once all statements
+ * have been answered (or the time runs out)
+ */
+class TrueFalse extends PageElement {
+    constructor() {
+        super('true-false-container', ['truefalse']);
+        this.isPlayableComponent = true;
+        this.responseCount = -1;
+        this.lastQuestionActive = false;
+        this.isSending = false;
+    }
+
+    shouldUpdate() {
+        const currentQuestionActive = this.isQuestionActive();
+        const responseCount = this.getResponses().length;
+        if (currentQuestionActive !== this.lastQuestionActive || responseCount !== this.responseCount) {
+            this.lastQuestionActive = currentQuestionActive;
+            this.responseCount = responseCount;
+            return true;
+        }
+        return false;
+    }
+
+    /**
+     * @returns {array} the responses the server has recorded for the current player so far
+     */
+    getResponses() {
+        let cq = this.getCurrentQuestion();
+        let cp = this.getCurrentPlayer();
+        if (!cq || !cp || !cq.statementResponses) {return [];}
+        return cq.statementResponses[cp.username] ?? [];
+    }
+
+    /**
+     * Sends the players response to the statement at the given index to the server
+     * @param {number} index the index of the statement being answered
+     * @param {boolean} response true if the player thinks the statement is true
+     */
+    async sendResponse(index, response) {
+        if (this.isSending || !this.isQuestionActive()) {return;}
+        this.isSending = true;
+        try {
+            const r = await fetch('/api/submit-statement', {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify({index: index, response: response})
+            });
+            if (!r.ok) {
+                this.warn('Server rejected statement response:', r.status);
+            }
+        } catch (error) {
+            this.warn('Failed to submit statement response:', error);
+        } finally {
+            this.isSending = false;
+        }
+        this.getApi().update();
+    }
+
+    createStyles() {
+        return `
+            #true-false-container {
+                padding: 15px;
+                margin: 0 auto;
+                text-align: center;
+            }
+            .true-false-progress {
+                color: var(--bcclightgold);
+                font-size: 1.2em;
+                margin-bottom: 10px;
+            }
+            .true-false-statement {
+                color: white;
+                font-size: 1.6em;
+                margin: 20px 0;
+            }
+            .true-false-buttons {
+                display: flex;
+                gap: 12px;
+                justify-content: center;
+            }
+            .true-false-button {
+                flex: 1;
+                max-width: 300px;
+                padding: 20px;
+                font-size: 1.4em;
+                border: 2px solid var(--bccblue);
+                border-radius: 8px;
+                cursor: pointer;
+                color: white;
+            }
+            .true-false-button.true {
+                background: var(--bccsage);
+            }
+            .true-false-button.false {
+                background: var(--bccrust);
+            }
+            .true-false-button:disabled {
+                opacity: 0.5;
+                cursor: not-allowed;
+            }
+            .true-false-reveal {
+                width: 100%;
+                text-align: left;
+            }
+        `;
+    }
+
+    getContent(api) {
+        const cq = this.getCurrentQuestion();
+        if (!cq || !cq.statements) {return null;}
+        const container = document.createElement('div');
+        const total = cq.statements.length;
+        const responses = this.getResponses();
+        const cp = this.getCurrentPlayer();
+
+        const progress = document.createElement('div');
+        progress.className = 'true-false-progress';
+        container.appendChild(progress);
+
+        // spectators just get told how many statements there are
+        if (!cp || cp.isSpectator) {
+            progress.textContent = `${total} statements, true or false?`;
+            return container;
+        }
+
+        if (responses.length >= total || this.hasAnswered()) {
+            const correct = responses.filter(r => r.correct).length;
+            progress.textContent = `All done! ${correct} out of ${total} correct`;
+            return container;
+        }
+
+        const index = responses.length;
+        progress.textContent = `Statement ${index + 1} of ${total}`;
+
+        const statement = document.createElement('div');
+        statement.className = 'true-false-statement';
+        statement.innerHTML = this.isQuestionActive() ? cq.statements[index].statement : 'Get ready...';
+        container.appendChild(statement);
+
+        const buttons = document.createElement('div');
+        buttons.className = 'true-false-buttons';
+        for (const value of [true, false]) {
+            const button = document.createElement('button');
+            button.className = `true-false-button ${value}`;
+            button.textContent = value ? 'True' : 'False';
+            button.disabled = !this.isQuestionActive();
+            button.addEventListener('click', () => {
+                buttons.querySelectorAll('button').forEach(b => b.disabled = true);
+                this.sendResponse(index, value);
+            });
+            buttons.appendChild(button);
+        }
+        container.appendChild(buttons);
+        return container;
+    }
+
+    /**
+     * The server records each statement response as it is made, so the answer
+     * submitted here just tells the server the player has finished early
+     * @returns {Answer} the answer object or null if nothing has been answered yet
+     */
+    getAnswer() {
+        if (this.getResponses().length === 0) {return null;}
+        return this.getApi().createAnswerObject();
+    }
+
+    /**
+     * Shows every statement along with whether it was true and how many
+     * players it caught out, worst first
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        const cq = this.getCurrentQuestion();
+        if (!container || !cq || !cq.statements) {return container;}
+        const statements = [...cq.statements].sort((a, b) => b.misses - a.misses);
+        let html = `
+            <table class="table true-false-reveal">
+                <thead>
+                    <tr>
+                        <th>Statement</th>
+                        <th>Answer</th>
+                        <th>Caught out</th>
+                    </tr>
+                </thead>
+                <tbody>
+        `;
+        for (const s of statements) {
+            html += `
+                <tr>
+                    <td>${s.statement}</td>
+                    <td>${s.isTrue ? 'True' : 'False'}</td>
+                    <td>${s.misses}</td>
+                </tr>
+            `;
+        }
+        html += `
+                </tbody>
+            </table>
+        `;
+        const t = document.createElement('div');
+        t.innerHTML = html;
+        container.appendChild(t);
+        return container;
+    }
+}
+
+
+// *******************************************************
+// ***** PageElement.js 
+// *******************************************************
+/**
+ * PageElement which implements the 'wordle' question type.
+ * The target word never leaves the server until the question has ended.
+ * Each guess is sent to the server which responds with green/yellow/grey
+ * feedback for each letter and records the guess against the player.
+ */
+class Wordle extends PageElement {
+    static colours = {
+        "green": "#6aaa64",
+        "yellow": "#c9b458",
+        "grey": "#787c7e"
+    };
+
+    constructor() {
+        super('wordle-container', ['wordle']);
+        this.isPlayableComponent = true;
+        this.guessCount = -1;
+        this.lastQuestionActive = false;
+        this.textInput = null;
+        this.errorDiv = null;
+        this.isSending = false;
+    }
+
+    shouldUpdate() {
+        const currentQuestionActive = this.isQuestionActive();
+        const guessCount = this.getGuesses().length;
+        if (currentQuestionActive !== this.lastQuestionActive || guessCount !== this.guessCount) {
+            this.lastQuestionActive = currentQuestionActive;
+            this.guessCount = guessCount;
+            return true;
+        }
+        return false;
+    }
+
+    /**
+     * @param {string} username the player whose guesses we want, defaults to the current player
+     * @returns {array} the guesses the server has recorded for the player
+     */
+    getGuesses(username) {
+        let cq = this.getCurrentQuestion();
+        if (!cq || !cq.guesses) {return [];}
+        if (!username) {
+            let cp = this.getCurrentPlayer();
+            if (!cp) {return [];}
+            username = cp.username;
+        }
+        return cq.guesses[username] ?? [];
+    }
+
+    /**
+     * Sends the guess in the text input to the server
+     */
+    async sendGuess() {
+        if (this.isSending || !this.isQuestionActive() || !this.textInput) {return;}
+        const guess = this.textInput.value.trim();
+        if (!guess) {return;}
+        this.isSending = true;
+        try {
+            const r = await fetch('/api/submit-guess', {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify({guess: guess})
+            });
+            if (!r.ok) {
+                this.errorDiv.textContent = await r.text();
+            } else {
+                this.textInput.value = '';
+                this.errorDiv.textContent = '';
+            }
+        } catch (error) {
+            this.warn('Failed to submit guess:', error);
+        } finally {
+            this.isSending = false;
+        }
+        this.getApi().update();
+    }
+
+    createStyles() {
+        return `
+            #wordle-container {
+                padding: 15px;
+                margin: 0 auto;
+                text-align: center;
+            }
+            .wordle-board {
+                display: inline-flex;
+                flex-direction: column;
+                gap: 5px;
+                margin-bottom: 15px;
+            }
+            .wordle-row {
+                display: flex;
+                gap: 5px;
+            }
+            .wordle-cell {
+                width: 2.5em;
+                height: 2.5em;
+                border: 2px solid var(--bcclightgrey);
+                color: white;
+                font-size: 1.4em;
+                font-weight: bold;
+                text-transform: uppercase;
+                display: flex;
+                align-items: center;
+                justify-content: center;
+            }
+            .wordle-input {
+                padding: 10px;
+                font-size: 1.2em;
+                text-transform: uppercase;
+                border: 2px solid var(--bccblue);
+                border-radius: 8px;
+            }
+            .wordle-error {
+                color: var(--bcclightgold);
+                min-height: 1.2em;
+                margin-top: 5px;
+            }
+            .wordle-results {
+                display: flex;
+                flex-wrap: wrap;
+                gap: 20px;
+                justify-content: center;
+            }
+        `;
+    }
+
+    /**
+     * Creates a board showing the given guesses with empty rows for any
+     * guesses not yet made
+     * @param {array} guesses the guesses to show
+     * @param {boolean} showLetters false to show only the colours
+     * @returns {Document.Object} the board
+     */
+    createBoard(guesses, showLetters) {
+        let cq = this.getCurrentQuestion();
+        const length = cq.wordLength || 5;
+        const rows = cq.maxGuesses || 6;
+        const board = document.createElement('div');
+        board.className = 'wordle-board';
+        for (let i = 0; i < rows; i++) {
+            const row = document.createElement('div');
+            row.className = 'wordle-row';
+            const g = guesses[i];
+            for (let j = 0; j < length; j++) {
+                const cell = document.createElement('div');
+                cell.className = 'wordle-cell';
+                if (g) {
+                    cell.style.backgroundColor = Wordle.colours[g.feedback[j]];
+                    cell.style.borderColor = Wordle.colours[g.feedback[j]];
+                    if (showLetters) {cell.textContent = [...g.guess][j];}
+                }
+                row.appendChild(cell);
+            }
+            board.appendChild(row);
+        }
+        return board;
+    }
+
+    getContent(api) {
+        let cq = this.getCurrentQuestion();
+        if (!cq) {return null;}
+        const container = document.createElement('div');
+        const cp = this.getCurrentPlayer();
+        if (!cp || cp.isSpectator) {
+            const info = document.createElement('div');
+            info.className = 'wordle-error';
+            info.textContent = `Find the ${cq.wordLength}-letter word in ${cq.maxGuesses || 6} guesses`;
+            container.appendChild(info);
+            return container;
+        }
+        const guesses = this.getGuesses();
+        container.appendChild(this.createBoard(guesses, true));
+
+        if (this.hasAnswered()) {return container;}
+
+        const inputRow = document.createElement('div');
+        this.textInput = document.createElement('input');
+        this.textInput.type = 'text';
+        this.textInput.className = 'wordle-input';
+        this.textInput.maxLength = cq.wordLength;
+        this.textInput.disabled = !this.isQuestionActive();
+        this.textInput.addEventListener('keydown', (e) => {
+            if (e.key === 'Enter') {this.sendGuess();}
+        });
+        inputRow.appendChild(this.textInput);
+        const button = document.createElement('button');
+        button.textContent = 'Guess';
+        button.disabled = !this.isQuestionActive();
+        button.addEventListener('click', () => this.sendGuess());
+        inputRow.appendChild(button);
+        container.appendChild(inputRow);
+
+        this.errorDiv = document.createElement('div');
+        this.errorDiv.className = 'wordle-error';
+        container.appendChild(this.errorDiv);
+        return container;
+    }
+
+    /**
+     * Guesses are submitted as they are made, so submitting an answer just
+     * tells the server that the player is giving up with the guesses made so far
+     * @returns {Answer} the answer object or null if no guesses have been made
+     */
+    getAnswer() {
+        if (this.getGuesses().length === 0) {return null;}
+        return this.getApi().createAnswerObject();
+    }
+
+    /**
+     * Shows the target word and the colours of every players guesses
+     * @param {GameAPI} api
+     * @returns {Document.Object}
+     */
+    getAnswerContent(api) {
+        const container = super.getAnswerContent(api);
+        let cq = this.getCurrentQuestion();
+        if (!container || !cq || !cq.guesses) {return container;}
+        const results = document.createElement('div');
+        results.className = 'wordle-results';
+        for (const username of Object.keys(cq.guesses)) {
+            const div = document.createElement('div');
+            const name = document.createElement('div');
+            name.textContent = username;
+            div.appendChild(name);
+            div.appendChild(this.createBoard(cq.guesses[username], false));
+            results.appendChild(div);
+        }
+        container.appendChild(results);
+        return container;
+    }
+}
+
+
 // *******************************************************
 // ***** PageElement.js 
 // *******************************************************